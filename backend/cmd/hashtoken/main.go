@@ -0,0 +1,46 @@
+// backend/cmd/hashtoken/main.go - Prints the bcrypt hash of an admin token for ADMIN_TOKEN_HASH
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/fullstack-pw/cks/backend/internal/config"
+)
+
+func main() {
+	if len(os.Args) > 2 {
+		fmt.Fprintln(os.Stderr, "usage: hashtoken [<token>]")
+		fmt.Fprintln(os.Stderr, "the token is read from stdin when not given as an argument")
+		os.Exit(1)
+	}
+
+	token := ""
+	if len(os.Args) == 2 {
+		token = os.Args[1]
+	} else {
+		// Reading from stdin keeps the token out of the shell history
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			fmt.Fprintf(os.Stderr, "failed to read token: %v\n", err)
+			os.Exit(1)
+		}
+		token = strings.TrimRight(line, "\r\n")
+	}
+	if token == "" {
+		fmt.Fprintln(os.Stderr, "token must not be empty")
+		os.Exit(1)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(token), config.AdminTokenHashCost)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to hash token: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(hash))
+}
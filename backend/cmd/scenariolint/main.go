@@ -0,0 +1,43 @@
+// backend/cmd/scenariolint/main.go - Validates scenario directories without starting the server
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/scenarios"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: scenariolint <scenario-dir> [<scenario-dir>...]")
+		os.Exit(1)
+	}
+
+	// Only surface warnings from the scenario loader
+	logger := logrus.New()
+	logger.SetOutput(os.Stderr)
+	logger.SetLevel(logrus.WarnLevel)
+
+	failed := false
+	for _, scenarioPath := range os.Args[1:] {
+		problems := lintSchemas(scenarioPath)
+		problems = append(problems, scenarios.LintScenario(scenarioPath, logger)...)
+		if len(problems) == 0 {
+			fmt.Printf("%s: OK\n", scenarioPath)
+			continue
+		}
+
+		failed = true
+		for _, problem := range problems {
+			fmt.Printf("%s: %v\n", scenarioPath, problem)
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
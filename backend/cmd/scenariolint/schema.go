@@ -0,0 +1,92 @@
+// backend/cmd/scenariolint/schema.go - JSON Schema checks of scenario files
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v2"
+
+	"github.com/fullstack-pw/cks/backend/schemas"
+)
+
+var (
+	metadataSchema   = mustLoadSchema("scenario-metadata.json", schemas.ScenarioMetadata)
+	validationSchema = mustLoadSchema("scenario-validation.json", schemas.ScenarioValidation)
+
+	validationFilePattern = regexp.MustCompile(`^\d+-validation\.yaml$`)
+)
+
+// mustLoadSchema compiles an embedded schema; the schemas ship with the binary, so a broken one is a bug
+func mustLoadSchema(name, schema string) *gojsonschema.Schema {
+	compiled, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schema))
+	if err != nil {
+		panic(fmt.Sprintf("invalid schema %s: %v", name, err))
+	}
+	return compiled
+}
+
+// lintSchemas checks metadata.yaml and the task validation files against their JSON Schemas.
+// Unlike unmarshalling into the models, this reports unknown fields and type mismatches.
+// Missing and unparsable files are left to LintScenario.
+func lintSchemas(scenarioPath string) []error {
+	problems := lintSchemaFile(scenarioPath, "metadata.yaml", metadataSchema)
+
+	entries, _ := os.ReadDir(filepath.Join(scenarioPath, "validation"))
+	for _, entry := range entries {
+		if entry.IsDir() || !validationFilePattern.MatchString(entry.Name()) {
+			continue
+		}
+		problems = append(problems, lintSchemaFile(scenarioPath, filepath.Join("validation", entry.Name()), validationSchema)...)
+	}
+
+	return problems
+}
+
+// lintSchemaFile checks a YAML file of the scenario against schema
+func lintSchemaFile(scenarioPath, file string, schema *gojsonschema.Schema) []error {
+	content, err := os.ReadFile(filepath.Join(scenarioPath, file))
+	if err != nil {
+		return nil
+	}
+
+	var document interface{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil
+	}
+
+	result, err := schema.Validate(gojsonschema.NewGoLoader(jsonCompatible(document)))
+	if err != nil {
+		return []error{fmt.Errorf("%s: schema validation failed: %v", file, err)}
+	}
+
+	var problems []error
+	for _, resultError := range result.Errors() {
+		problems = append(problems, fmt.Errorf("%s: schema: %s", file, resultError))
+	}
+	return problems
+}
+
+// jsonCompatible converts the map[interface{}]interface{} values yaml.v2 decodes into the
+// map[string]interface{} values JSON Schema validation expects
+func jsonCompatible(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = jsonCompatible(value)
+		}
+		return m
+	case []interface{}:
+		for i, value := range v {
+			v[i] = jsonCompatible(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -1,10 +1,21 @@
 // backend/cmd/server/main.go
 
+//go:generate go run github.com/swaggo/swag/cmd/swag@v1.16.4 init --dir ../.. --generalInfo cmd/server/main.go --output ../../docs --outputTypes json,yaml --parseInternal
+
+// @title CKS Practice Environment API
+// @version 1.0
+// @description Sessions, scenarios, terminals and validation of the CKS practice environment.
+// @BasePath /api/v1
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description Admin session token from POST /auth/token as "Bearer <token>"
 package main
 
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -13,12 +24,17 @@ import (
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
+	"github.com/fullstack-pw/cks/backend/internal/audit"
 	"github.com/fullstack-pw/cks/backend/internal/clusterpool"
 	"github.com/fullstack-pw/cks/backend/internal/config"
 	"github.com/fullstack-pw/cks/backend/internal/controllers"
@@ -28,6 +44,7 @@ import (
 	"github.com/fullstack-pw/cks/backend/internal/services"
 	"github.com/fullstack-pw/cks/backend/internal/sessions"
 	"github.com/fullstack-pw/cks/backend/internal/terminal"
+	"github.com/fullstack-pw/cks/backend/internal/tracing"
 	"github.com/fullstack-pw/cks/backend/internal/validation"
 )
 
@@ -38,6 +55,9 @@ func main() {
 	if err != nil {
 		logger.WithError(err).Fatal("Failed to load configuration")
 	}
+	if err := cfg.Validate(); err != nil {
+		logger.Fatalf("Invalid configuration:\n%v", err)
+	}
 
 	// Configure formatter based on config
 	switch cfg.LogFormat {
@@ -62,6 +82,20 @@ func main() {
 	}
 	logger.SetLevel(logLevel)
 
+	if cfg.AdminTokenHash == "" && cfg.AdminToken != "" {
+		logger.Warn("ADMIN_TOKEN is configured in plain text; set ADMIN_TOKEN_HASH to its bcrypt hash instead (see cmd/hashtoken)")
+		hash, err := bcrypt.GenerateFromPassword([]byte(cfg.AdminToken), config.AdminTokenHashCost)
+		if err != nil {
+			logger.WithError(err).Fatal("Failed to hash admin token")
+		}
+		cfg.AdminTokenHash = string(hash)
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg, logger)
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to initialize tracing")
+	}
+
 	// Set up Gin
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -69,23 +103,42 @@ func main() {
 
 	router := gin.Default()
 
-	// Configure middleware
+	// The client IP used by rate limiting and the audit log only comes from X-Forwarded-For
+	// when the request is from a trusted proxy; by default no proxy is trusted
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		logger.WithError(err).Fatal("Invalid TRUSTED_PROXIES")
+	}
+	// Only those proxies may also assert the signed-in user with USER_ID_HEADER
+	userIdentity, err := middleware.UserIdentity(cfg.UserIDHeader, cfg.TrustedProxies)
+	if err != nil {
+		logger.WithError(err).Fatal("Invalid TRUSTED_PROXIES")
+	}
+
+	// Configure middleware. Credentialed requests are only allowed from an explicit origin
+	// list, never when every origin is allowed.
+	allowCredentials := !middleware.AllowsAnyOrigin(cfg.CorsAllowOrigins)
+	if !allowCredentials {
+		logger.Warn("CORS_ALLOW_ORIGINS allows every origin; credentialed cross-origin requests are disabled")
+	}
 	router.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{cfg.CorsAllowOrigin},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowOriginFunc:  middleware.AllowOrigins(cfg.CorsAllowOrigins),
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
 		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
+		AllowCredentials: allowCredentials,
 		MaxAge:           12 * time.Hour,
 	}))
 	router.Use(middleware.RequestID())
+	router.Use(middleware.Tracing())
 	router.Use(middleware.Logger())
+	router.Use(userIdentity)
 
 	// Health check and metrics
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	router.StaticFile("/swagger/doc.json", cfg.APISpecPath)
 
 	// Create Kubernetes client configuration
 	var k8sConfig *rest.Config
@@ -115,17 +168,23 @@ func main() {
 		logger.WithError(err).Fatal("Failed to create kubevirt client")
 	}
 
-	// Create unified validator (ADD THIS)
+	// Create unified validator
 	unifiedValidator := validation.NewUnifiedValidator(kubevirtClient, logger)
 
-	// Create terminal manager (existing)
+	// Create terminal manager
 	terminalManager := terminal.NewManager(kubeClient, kubevirtClient, k8sConfig, logger)
+	terminalManager.IdleTimeout = time.Duration(cfg.TerminalIdleTimeoutMinutes) * time.Minute
+	terminalManager.HistoryRetention = time.Duration(cfg.TerminalHistoryRetentionMinutes) * time.Minute
+	if err := terminalManager.SetHistoryRedactPatterns(cfg.HistoryRedactPatterns); err != nil {
+		logger.WithError(err).Fatal("Invalid terminal history redact patterns")
+	}
 
 	// Create scenario manager first
 	scenarioManager, err := scenarios.NewScenarioManager(cfg.ScenariosPath, logger)
 	if err != nil {
 		logger.WithError(err).Fatal("Failed to create scenario manager")
 	}
+	scenarioManager.CacheTTL = time.Duration(cfg.ScenarioCacheTTLSeconds) * time.Second
 
 	// Create cluster pool manager
 	clusterPoolManager, err := clusterpool.NewManager(cfg, kubeClient, kubevirtClient, logger)
@@ -133,6 +192,9 @@ func main() {
 		logger.WithError(err).Fatal("Failed to create cluster pool manager")
 	}
 
+	terminalManager.SetNamespaceResolver(clusterPoolManager.NamespaceForSession)
+	prometheus.MustRegister(terminal.NewMetricsCollector(terminalManager))
+
 	// Update session manager creation with cluster pool
 	sessionManager, err := sessions.NewSessionManager(cfg, kubeClient, kubevirtClient, unifiedValidator, logger, scenarioManager, clusterPoolManager)
 	if err != nil {
@@ -144,18 +206,47 @@ func main() {
 	terminalService := services.NewTerminalService(terminalManager)
 	scenarioService := services.NewScenarioService(scenarioManager)
 	sessionManager.SetTerminalCleanupFunc(terminalService.CleanupSessionSSH)
+	sessionManager.SetTaskResultObserver(scenarioManager.RecordTaskResult)
+
+	// Request timeouts per route group; validation runs commands in the VMs and gets the longest
+	validationRoutes := router.Group("", middleware.Timeout(controllers.ValidationRouteTimeout))
+	sessionRoutes := router.Group("", middleware.Timeout(30*time.Second))
+	scenarioRoutes := router.Group("", middleware.Timeout(10*time.Second))
+
+	// Per-client rate limits; task validation gets a stricter limit of its own
+	publicRateLimit := middleware.RateLimiter(10, 20)
+	sessionRoutes.Use(publicRateLimit)
+	scenarioRoutes.Use(publicRateLimit)
+	taskValidationRoutes := validationRoutes.Group("", middleware.RateLimiter(2, 5))
+
+	// Admin endpoints accept session tokens issued in exchange for the admin token
+	adminSessions := middleware.NewAdminSessions(cfg.AdminTokenHash, time.Duration(cfg.AdminSessionTTLMinutes)*time.Minute)
 
 	// Create and register controllers
-	sessionController := controllers.NewSessionController(sessionService, scenarioService, logger, unifiedValidator)
-	sessionController.RegisterRoutes(router)
+	authController := controllers.NewAuthController(adminSessions, logger)
+	authController.RegisterRoutes(router.Group("", middleware.RateLimiter(0.2, 5)))
+
+	sessionController := controllers.NewSessionController(sessionService, scenarioService, logger)
+	sessionController.RegisterRoutes(sessionRoutes)
+	sessionController.RegisterValidationRoutes(taskValidationRoutes)
+	sessionController.RegisterEventRoutes(router)
+
+	// Every admin route records to the same audit log, which GET /api/v1/admin/audit lists
+	auditLogger := audit.NewLogrusAuditLogger(logger)
+
+	terminalController := controllers.NewTerminalController(terminalService, sessionService, adminSessions, auditLogger, cfg.MaxTerminalConnectionsPerSession, logger)
+	terminalController.RegisterRoutes(sessionRoutes)
+	terminalController.RegisterAdminRoutes(sessionRoutes)
 
-	terminalController := controllers.NewTerminalController(terminalService, sessionService, logger)
-	terminalController.RegisterRoutes(router)
+	scenarioController := controllers.NewScenarioController(scenarioService, sessionService, unifiedValidator, adminSessions, auditLogger, logger)
+	scenarioController.RegisterRoutes(scenarioRoutes)
+	scenarioController.RegisterValidationRoutes(validationRoutes)
+	scenarioController.RegisterAdminRoutes(router)
 
-	scenarioController := controllers.NewScenarioController(scenarioService)
-	scenarioController.RegisterRoutes(router)
+	healthController := controllers.NewHealthController(kubeClient, kubevirtClient, clusterPoolManager, cfg.ScenariosPath, logger)
+	healthController.RegisterRoutes(router)
 
-	adminController := controllers.NewAdminController(sessionManager, kubevirtClient, logger)
+	adminController := controllers.NewAdminController(sessionManager, scenarioService, kubevirtClient, adminSessions, auditLogger, logger)
 	adminController.RegisterRoutes(router)
 
 	// Create HTTP server
@@ -179,14 +270,40 @@ func main() {
 		}
 	}()
 
+	// Serve the validation engine to other services over gRPC
+	var grpcServer *grpc.Server
+	if cfg.ValidationGRPCAddr != "" {
+		listener, err := net.Listen("tcp", cfg.ValidationGRPCAddr)
+		if err != nil {
+			logger.WithError(err).Fatal("Failed to listen for validation gRPC service")
+		}
+		var creds credentials.TransportCredentials
+		if cfg.ValidationGRPCTLSCertFile != "" {
+			creds, err = credentials.NewServerTLSFromFile(cfg.ValidationGRPCTLSCertFile, cfg.ValidationGRPCTLSKeyFile)
+			if err != nil {
+				logger.WithError(err).Fatal("Failed to load validation gRPC service certificate")
+			}
+		}
+		grpcServer, err = validation.NewGRPCServer(unifiedValidator, clusterPoolManager, cfg.ValidationGRPCToken, creds, logger)
+		if err != nil {
+			logger.WithError(err).Fatal("Failed to create validation gRPC service")
+		}
+		go func() {
+			logger.WithField("addr", cfg.ValidationGRPCAddr).Info("Starting validation gRPC service")
+			if err := grpcServer.Serve(listener); err != nil {
+				logger.WithError(err).Error("Validation gRPC service stopped")
+			}
+		}()
+	}
+
 	// Wait for interrupt signal to gracefully shut down the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	logger.Info("Shutting down server...")
 
-	// Create context with timeout for shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// Create context with timeout for shutdown, long enough for in-flight validations
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.GracefulShutdownTimeoutSeconds)*time.Second)
 	defer cancel()
 
 	// Stop cluster pool manager
@@ -194,8 +311,20 @@ func main() {
 
 	// Shutdown server
 	if err := server.Shutdown(ctx); err != nil {
+		sessionManager.Stop()
 		logger.WithError(err).Fatal("Server forced to shutdown")
 	}
 
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+
+	// Wait for validations not tied to a request
+	sessionManager.Stop()
+
+	if err := shutdownTracing(ctx); err != nil {
+		logger.WithError(err).Warn("Failed to flush traces")
+	}
+
 	logger.Info("Server exited properly")
 }
@@ -0,0 +1,100 @@
+// backend/internal/audit/audit.go - Structured audit logging for administrative operations
+
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultBufferSize is the number of recent events kept in memory
+const DefaultBufferSize = 500
+
+// Audit outcomes
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// AuditEvent describes a single administrative action
+type AuditEvent struct {
+	Action     string                 `json:"action"`
+	Actor      string                 `json:"actor"`
+	Resource   string                 `json:"resource"`
+	ResourceID string                 `json:"resourceId,omitempty"`
+	Outcome    string                 `json:"outcome"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	Timestamp  time.Time              `json:"timestamp"`
+}
+
+// AuditLogger records audit events and exposes the most recent ones
+type AuditLogger interface {
+	Log(ctx context.Context, event AuditEvent)
+	Recent() []AuditEvent
+}
+
+// LogrusAuditLogger writes audit events through logrus and keeps the latest events in a ring buffer
+type LogrusAuditLogger struct {
+	logger *logrus.Logger
+	events []AuditEvent
+	next   int
+	full   bool
+	lock   sync.RWMutex
+}
+
+// NewLogrusAuditLogger creates an audit logger retaining the last DefaultBufferSize events
+func NewLogrusAuditLogger(logger *logrus.Logger) *LogrusAuditLogger {
+	return &LogrusAuditLogger{
+		logger: logger,
+		events: make([]AuditEvent, DefaultBufferSize),
+	}
+}
+
+// Log records an audit event
+func (a *LogrusAuditLogger) Log(ctx context.Context, event AuditEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	a.lock.Lock()
+	a.events[a.next] = event
+	a.next = (a.next + 1) % len(a.events)
+	if a.next == 0 {
+		a.full = true
+	}
+	a.lock.Unlock()
+
+	entry := a.logger.WithFields(logrus.Fields{
+		"audit":      true,
+		"action":     event.Action,
+		"actor":      event.Actor,
+		"resource":   event.Resource,
+		"resourceID": event.ResourceID,
+		"outcome":    event.Outcome,
+		"details":    event.Details,
+	})
+
+	if event.Outcome == OutcomeFailure {
+		entry.Warn("Audit event")
+	} else {
+		entry.Info("Audit event")
+	}
+}
+
+// Recent returns buffered events from oldest to newest
+func (a *LogrusAuditLogger) Recent() []AuditEvent {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if !a.full {
+		return append([]AuditEvent(nil), a.events[:a.next]...)
+	}
+
+	events := make([]AuditEvent, 0, len(a.events))
+	events = append(events, a.events[a.next:]...)
+	events = append(events, a.events[:a.next]...)
+	return events
+}
@@ -0,0 +1,74 @@
+// backend/internal/clusterpool/assignment.go - Persisting and verifying cluster assignments
+
+package clusterpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// ErrClusterNotAssigned is returned by VerifySessionCluster when a session does not own the
+// cluster it names
+var ErrClusterNotAssigned = errors.New("cluster not assigned to session")
+
+// persistAssignment records the session a cluster is assigned to in the cluster namespace's
+// ClusterSessionAnnotation, removing it when sessionID is empty. Must be called with m.lock held.
+func (m *Manager) persistAssignment(clusterID, sessionID string) {
+	ctx := context.Background()
+
+	ns, err := m.kubeClient.CoreV1().Namespaces().Get(ctx, clusterID, metav1.GetOptions{})
+	if err == nil {
+		if sessionID == "" {
+			delete(ns.Annotations, ClusterSessionAnnotation)
+		} else {
+			if ns.Annotations == nil {
+				ns.Annotations = make(map[string]string)
+			}
+			ns.Annotations[ClusterSessionAnnotation] = sessionID
+		}
+		_, err = m.kubeClient.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		// The in-memory assignment stays; only remote validation of the session is refused
+		m.logger.WithError(err).WithFields(logrus.Fields{
+			"clusterID": clusterID,
+			"sessionID": sessionID,
+		}).Error("Failed to persist cluster assignment to namespace")
+	}
+}
+
+// VerifySessionCluster checks that the namespace of a session is a pool cluster assigned to the
+// session and that the session's VMs are the cluster's. The assignment is read from the
+// namespace annotation, so sessions of other backends sharing the pool are recognized too.
+func (m *Manager) VerifySessionCluster(ctx context.Context, session *models.Session) error {
+	m.lock.RLock()
+	cluster, exists := m.clusters[session.Namespace]
+	var controlPlaneVM, workerNodeVM string
+	if exists {
+		controlPlaneVM, workerNodeVM = cluster.ControlPlaneVM, cluster.WorkerNodeVM
+	}
+	m.lock.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("%w: namespace %s is not a pool cluster", ErrClusterNotAssigned, session.Namespace)
+	}
+	if session.ControlPlaneVM != controlPlaneVM || session.WorkerNodeVM != workerNodeVM {
+		return fmt.Errorf("%w: VMs %s and %s are not the VMs of cluster %s", ErrClusterNotAssigned, session.ControlPlaneVM, session.WorkerNodeVM, session.Namespace)
+	}
+
+	ns, err := m.kubeClient.CoreV1().Namespaces().Get(ctx, session.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get namespace %s: %w", session.Namespace, err)
+	}
+	if session.ID == "" || ns.Annotations[ClusterSessionAnnotation] != session.ID {
+		return fmt.Errorf("%w: cluster %s, session %s", ErrClusterNotAssigned, session.Namespace, session.ID)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ package clusterpool
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -16,12 +17,18 @@ import (
 )
 
 const (
-	PoolSize = 3 // cluster1, cluster2, cluster3
+	// DefaultPoolSize is used when the configured pool size is not positive
+	DefaultPoolSize = 3
 
 	// Kubernetes annotations for persistent cluster state
 	ClusterStatusAnnotation    = "cks.io/cluster-status"
 	ClusterLastResetAnnotation = "cks.io/last-reset"
 	ClusterCreatedAtAnnotation = "cks.io/created-at"
+	ClusterSessionAnnotation   = "cks.io/assigned-session"
+
+	// Health check settings
+	healthCheckTimeout  = 15 * time.Second
+	errorResetThreshold = 10 * time.Minute // clusters in error longer than this are reset automatically
 )
 
 // Manager manages the cluster pool for session assignment
@@ -33,6 +40,11 @@ type Manager struct {
 	config         *config.Config
 	logger         *logrus.Logger
 
+	// Sessions waiting for a cluster, see WaitForCluster. queuedSessions lists their IDs in
+	// queue order and is guarded by lock.
+	waitQueue      chan *waitRequest
+	queuedSessions []string
+
 	// Background task control
 	stopCh chan struct{}
 }
@@ -45,11 +57,12 @@ func NewManager(
 	logger *logrus.Logger,
 ) (*Manager, error) {
 	manager := &Manager{
-		clusters:       make(map[string]*models.ClusterPool, PoolSize),
+		clusters:       make(map[string]*models.ClusterPool, len(ClusterIDs(cfg))),
 		kubeClient:     kubeClient,
 		kubevirtClient: kubevirtClient,
 		config:         cfg,
 		logger:         logger,
+		waitQueue:      make(chan *waitRequest, waitQueueSize),
 		stopCh:         make(chan struct{}),
 	}
 
@@ -66,9 +79,7 @@ func NewManager(
 func (m *Manager) initializePool() {
 	m.logger.Info("Initializing cluster pool from namespace annotations...")
 
-	clusterIDs := []string{"cluster1", "cluster2", "cluster3"}
-
-	for _, clusterID := range clusterIDs {
+	for _, clusterID := range ClusterIDs(m.config) {
 		// Read persistent status from namespace annotation
 		status := m.getClusterStatusFromNamespace(clusterID)
 		lastReset := m.getLastResetFromNamespace(clusterID)
@@ -103,31 +114,78 @@ func (m *Manager) initializePool() {
 	m.logger.WithField("poolSize", len(m.clusters)).Info("Cluster pool initialized from namespace annotations")
 }
 
-// AssignCluster assigns an available cluster to a session
+// ClusterIDs returns the configured pool cluster IDs: <prefix>1 ... <prefix>N
+func ClusterIDs(cfg *config.Config) []string {
+	size := cfg.ClusterPoolSize
+	if size <= 0 {
+		size = DefaultPoolSize
+	}
+
+	prefix := cfg.ClusterPoolNamespacePrefix
+	if prefix == "" {
+		prefix = "cluster"
+	}
+
+	clusterIDs := make([]string, 0, size)
+	for i := 1; i <= size; i++ {
+		clusterIDs = append(clusterIDs, fmt.Sprintf("%s%d", prefix, i))
+	}
+	return clusterIDs
+}
+
+// ClusterIDs returns the IDs of all clusters in the pool
+func (m *Manager) ClusterIDs() []string {
+	return ClusterIDs(m.config)
+}
+
+// NamespaceForSession returns the namespace of the cluster assigned to a session, or "" if none
+func (m *Manager) NamespaceForSession(sessionID string) string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, cluster := range m.clusters {
+		if cluster.AssignedSession == sessionID {
+			return cluster.Namespace
+		}
+	}
+	return ""
+}
+
+// AssignCluster assigns an available cluster to a session. It returns ErrNoAvailableClusters
+// when every cluster is in use.
 func (m *Manager) AssignCluster(sessionID string) (*models.ClusterPool, error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	// Find first available cluster
-	for clusterID, cluster := range m.clusters {
-		if cluster.Status == models.StatusAvailable {
-			// Lock cluster to session
-			cluster.Status = models.StatusLocked
-			cluster.AssignedSession = sessionID
-			cluster.LockTime = time.Now()
-
-			m.logger.WithFields(logrus.Fields{
-				"clusterID": clusterID,
-				"sessionID": sessionID,
-			}).Info("Cluster assigned to session")
+	return m.assignAvailableCluster(sessionID)
+}
 
-			// Return a copy to avoid external modifications
-			clusterCopy := *cluster
-			return &clusterCopy, nil
+// assignAvailableCluster locks the first available cluster to a session. Must be called with m.lock held.
+func (m *Manager) assignAvailableCluster(sessionID string) (*models.ClusterPool, error) {
+	for _, cluster := range m.clusters {
+		if cluster.Status == models.StatusAvailable {
+			return m.lockCluster(cluster, sessionID), nil
 		}
 	}
 
-	return nil, fmt.Errorf("no available clusters in pool")
+	return nil, ErrNoAvailableClusters
+}
+
+// lockCluster assigns a cluster to a session and returns a copy of it. Must be called with m.lock held.
+func (m *Manager) lockCluster(cluster *models.ClusterPool, sessionID string) *models.ClusterPool {
+	cluster.Status = models.StatusLocked
+	cluster.AssignedSession = sessionID
+	cluster.LockTime = time.Now()
+	m.persistAssignment(cluster.ClusterID, sessionID)
+
+	m.logger.WithFields(logrus.Fields{
+		"clusterID": cluster.ClusterID,
+		"sessionID": sessionID,
+	}).Info("Cluster assigned to session")
+
+	// Return a copy to avoid external modifications
+	clusterCopy := *cluster
+	return &clusterCopy
 }
 
 // ReleaseCluster releases a cluster from a session
@@ -142,6 +200,7 @@ func (m *Manager) ReleaseCluster(sessionID string) error {
 			cluster.Status = models.StatusResetting
 			cluster.AssignedSession = ""
 			cluster.LockTime = time.Time{}
+			m.persistAssignment(clusterID, "")
 
 			m.logger.WithFields(logrus.Fields{
 				"clusterID": clusterID,
@@ -174,6 +233,7 @@ func (m *Manager) ReleaseAllClusters() error {
 		cluster.Status = models.StatusResetting
 		cluster.AssignedSession = ""
 		cluster.LockTime = time.Time{}
+		m.persistAssignment(clusterID, "")
 
 		releasedClusters = append(releasedClusters, clusterID)
 
@@ -211,12 +271,15 @@ func (m *Manager) GetPoolStatus() *models.ClusterPoolStats {
 	defer m.lock.RUnlock()
 
 	stats := &models.ClusterPoolStats{
-		TotalClusters:   len(m.clusters),
-		StatusByCluster: make(map[string]models.ClusterStatus),
+		TotalClusters:       len(m.clusters),
+		StatusByCluster:     make(map[string]models.ClusterStatus),
+		LastHealthCheckTime: make(map[string]time.Time),
+		QueuedSessions:      append([]string{}, m.queuedSessions...),
 	}
 
 	for clusterID, cluster := range m.clusters {
 		stats.StatusByCluster[clusterID] = cluster.Status
+		stats.LastHealthCheckTime[clusterID] = cluster.LastHealthCheck
 
 		switch cluster.Status {
 		case models.StatusAvailable:
@@ -233,6 +296,50 @@ func (m *Manager) GetPoolStatus() *models.ClusterPoolStats {
 	return stats
 }
 
+// ListClusters returns copies of all clusters in the pool ordered by cluster ID
+func (m *Manager) ListClusters() []models.ClusterPool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	clusters := make([]models.ClusterPool, 0, len(m.clusters))
+	for _, cluster := range m.clusters {
+		clusters = append(clusters, *cluster)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].ClusterID < clusters[j].ClusterID
+	})
+
+	return clusters
+}
+
+// ResetCluster starts a background reset of an unassigned cluster
+func (m *Manager) ResetCluster(clusterID string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	cluster, exists := m.clusters[clusterID]
+	if !exists {
+		return fmt.Errorf("cluster %s not found", clusterID)
+	}
+
+	if cluster.AssignedSession != "" {
+		return fmt.Errorf("cluster %s is assigned to session %s", clusterID, cluster.AssignedSession)
+	}
+
+	if cluster.Status == models.StatusResetting {
+		return fmt.Errorf("cluster %s is already resetting", clusterID)
+	}
+
+	cluster.Status = models.StatusResetting
+
+	m.logger.WithField("clusterID", clusterID).Info("Manual cluster reset requested")
+
+	go m.resetClusterAsync(clusterID)
+
+	return nil
+}
+
 // GetClusterByID returns a cluster by ID
 func (m *Manager) GetClusterByID(clusterID string) (*models.ClusterPool, error) {
 	m.lock.RLock()
@@ -266,6 +373,7 @@ func (m *Manager) MarkClusterAvailable(clusterID string) error {
 		m.logger.WithError(err).WithField("clusterID", clusterID).Error("Failed to persist cluster status to namespace")
 		// Continue anyway - in-memory state is updated
 	}
+	m.handOverToWaiter(cluster)
 
 	m.logger.WithField("clusterID", clusterID).Info("Cluster marked as available and persisted")
 	return nil
@@ -341,6 +449,7 @@ func (m *Manager) resetClusterAsync(clusterID string) {
 		cluster.Status = models.StatusAvailable
 		cluster.LastReset = time.Now()
 		m.updateClusterStatusInNamespace(clusterID, models.StatusAvailable)
+		m.handOverToWaiter(cluster)
 	}
 	m.lock.Unlock()
 
@@ -353,6 +462,9 @@ func (m *Manager) markClusterError(clusterID string, err error) {
 	defer m.lock.Unlock()
 
 	if cluster, exists := m.clusters[clusterID]; exists {
+		if cluster.Status != models.StatusError {
+			cluster.ErrorSince = time.Now()
+		}
 		cluster.Status = models.StatusError
 
 		// Persist error state
@@ -367,7 +479,12 @@ func (m *Manager) markClusterError(clusterID string, err error) {
 
 // maintenanceLoop performs periodic maintenance tasks
 func (m *Manager) maintenanceLoop() {
-	ticker := time.NewTicker(5 * time.Minute)
+	interval := time.Duration(m.config.HealthCheckIntervalMinutes) * time.Minute
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
@@ -382,21 +499,66 @@ func (m *Manager) maintenanceLoop() {
 
 // performMaintenance checks cluster health and performs cleanup
 func (m *Manager) performMaintenance() {
-	m.lock.Lock()
-	defer m.lock.Unlock()
-
 	m.logger.Debug("Performing cluster pool maintenance")
 
-	for clusterID, cluster := range m.clusters {
-		cluster.LastHealthCheck = time.Now()
+	// Snapshot clusters so the lock is not held during VM commands
+	m.lock.RLock()
+	clusters := make([]models.ClusterPool, 0, len(m.clusters))
+	for _, cluster := range m.clusters {
+		clusters = append(clusters, *cluster)
+	}
+	m.lock.RUnlock()
 
-		// TODO: Add actual health checks in later phases
+	for _, cluster := range clusters {
 		m.logger.WithFields(logrus.Fields{
-			"clusterID":       clusterID,
+			"clusterID":       cluster.ClusterID,
 			"status":          cluster.Status,
 			"assignedSession": cluster.AssignedSession,
 		}).Debug("Cluster maintenance check")
+
+		switch cluster.Status {
+		case models.StatusAvailable, models.StatusLocked:
+			m.healthCheckCluster(context.Background(), &cluster)
+		case models.StatusError:
+			if cluster.AssignedSession == "" && !cluster.ErrorSince.IsZero() && time.Since(cluster.ErrorSince) > errorResetThreshold {
+				m.logger.WithFields(logrus.Fields{
+					"clusterID":  cluster.ClusterID,
+					"errorSince": cluster.ErrorSince,
+				}).Warn("Cluster has been in error state too long, triggering automatic reset")
+				go m.resetClusterAsync(cluster.ClusterID)
+			}
+		}
+	}
+}
+
+// healthCheckCluster verifies that both cluster VMs respond to commands and marks the cluster as error otherwise
+func (m *Manager) healthCheckCluster(ctx context.Context, cluster *models.ClusterPool) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var healthErr error
+	for _, vmName := range []string{cluster.ControlPlaneVM, cluster.WorkerNodeVM} {
+		if _, err := m.kubevirtClient.ExecuteCommandInVM(ctx, cluster.Namespace, vmName, "echo ok", false); err != nil {
+			healthErr = fmt.Errorf("health check failed for VM %s: %w", vmName, err)
+			break
+		}
+	}
+
+	m.lock.Lock()
+	statusChanged := true
+	if c, exists := m.clusters[cluster.ClusterID]; exists {
+		c.LastHealthCheck = time.Now()
+		statusChanged = c.Status != cluster.Status
 	}
+	m.lock.Unlock()
+
+	// Skip clusters that were released or reset while the check was running
+	if healthErr != nil && !statusChanged {
+		m.markClusterError(cluster.ClusterID, healthErr)
+		return
+	}
+
+	m.logger.WithField("clusterID", cluster.ClusterID).Debug("Cluster health check passed")
 }
 
 // Stop gracefully shuts down the cluster pool manager
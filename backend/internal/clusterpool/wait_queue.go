@@ -0,0 +1,159 @@
+// backend/internal/clusterpool/wait_queue.go - Sessions waiting for a cluster to become available
+package clusterpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// waitQueueSize is the number of sessions that can wait for a cluster at once
+const waitQueueSize = 10
+
+var (
+	// ErrNoAvailableClusters is returned by AssignCluster when every cluster is in use
+	ErrNoAvailableClusters = errors.New("no available clusters in pool")
+	// ErrWaitQueueFull is returned by WaitForCluster when waitQueueSize sessions already wait
+	ErrWaitQueueFull = errors.New("cluster wait queue is full")
+)
+
+// waitRequest is a session waiting in the queue. result receives the assigned cluster exactly once.
+type waitRequest struct {
+	sessionID string
+	result    chan *models.ClusterPool
+	cancelled bool // The waiter gave up; guarded by Manager.lock
+}
+
+// WaitForCluster assigns a cluster to a session, waiting for one to become available when the
+// pool is exhausted. Waiting sessions are served in order as clusters finish resetting. The wait
+// ends with the context's error when the context is done first.
+func (m *Manager) WaitForCluster(ctx context.Context, sessionID string) (*models.ClusterPool, error) {
+	m.lock.Lock()
+	cluster, err := m.assignAvailableCluster(sessionID)
+	if !errors.Is(err, ErrNoAvailableClusters) {
+		m.lock.Unlock()
+		return cluster, err
+	}
+
+	req := &waitRequest{sessionID: sessionID, result: make(chan *models.ClusterPool, 1)}
+	if !m.enqueueWaiter(req) {
+		m.lock.Unlock()
+		return nil, ErrWaitQueueFull
+	}
+	position := len(m.queuedSessions)
+	m.lock.Unlock()
+
+	m.logger.WithFields(logrus.Fields{
+		"sessionID": sessionID,
+		"position":  position,
+	}).Info("Session queued for a cluster")
+
+	startTime := time.Now()
+	select {
+	case cluster := <-req.result:
+		m.logger.WithFields(logrus.Fields{
+			"sessionID": sessionID,
+			"clusterID": cluster.ClusterID,
+			"waited":    time.Since(startTime),
+		}).Info("Queued session assigned a cluster")
+		return cluster, nil
+
+	case <-ctx.Done():
+		m.lock.Lock()
+		req.cancelled = true
+		m.removeQueuedSession(sessionID)
+		m.lock.Unlock()
+
+		// A cluster handed over just before the cancellation goes to the next waiter
+		select {
+		case cluster := <-req.result:
+			m.returnCluster(cluster.ClusterID, sessionID)
+		default:
+		}
+		return nil, fmt.Errorf("waiting for a cluster: %w", ctx.Err())
+	}
+}
+
+// enqueueWaiter adds a request to the wait queue, dropping cancelled requests first if the
+// queue is full. Must be called with m.lock held.
+func (m *Manager) enqueueWaiter(req *waitRequest) bool {
+	select {
+	case m.waitQueue <- req:
+	default:
+		m.compactWaitQueue()
+		select {
+		case m.waitQueue <- req:
+		default:
+			return false
+		}
+	}
+	m.queuedSessions = append(m.queuedSessions, req.sessionID)
+	return true
+}
+
+// compactWaitQueue removes cancelled requests from the wait queue, keeping the order of the
+// others. Must be called with m.lock held, which every reader of the queue holds.
+func (m *Manager) compactWaitQueue() {
+	pending := make([]*waitRequest, 0, len(m.waitQueue))
+	for len(m.waitQueue) > 0 {
+		if req := <-m.waitQueue; !req.cancelled {
+			pending = append(pending, req)
+		}
+	}
+	for _, req := range pending {
+		m.waitQueue <- req
+	}
+}
+
+// handOverToWaiter assigns an available cluster to the longest waiting session, if any.
+// Must be called with m.lock held.
+func (m *Manager) handOverToWaiter(cluster *models.ClusterPool) {
+	for {
+		select {
+		case req := <-m.waitQueue:
+			if req.cancelled {
+				continue
+			}
+			m.removeQueuedSession(req.sessionID)
+			req.result <- m.lockCluster(cluster, req.sessionID)
+			return
+		default:
+			return
+		}
+	}
+}
+
+// returnCluster makes a cluster assigned to a session that is no longer waiting available again
+// without a reset, since the session never used it
+func (m *Manager) returnCluster(clusterID, sessionID string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	cluster, exists := m.clusters[clusterID]
+	if !exists || cluster.AssignedSession != sessionID {
+		return
+	}
+	cluster.Status = models.StatusAvailable
+	cluster.AssignedSession = ""
+	cluster.LockTime = time.Time{}
+	m.persistAssignment(clusterID, "")
+
+	m.logger.WithFields(logrus.Fields{
+		"clusterID": clusterID,
+		"sessionID": sessionID,
+	}).Info("Unused cluster returned to the pool")
+
+	m.handOverToWaiter(cluster)
+}
+
+// removeQueuedSession removes a session from queuedSessions. Must be called with m.lock held.
+func (m *Manager) removeQueuedSession(sessionID string) {
+	if i := slices.Index(m.queuedSessions, sessionID); i >= 0 {
+		m.queuedSessions = slices.Delete(m.queuedSessions, i, i+1)
+	}
+}
@@ -11,17 +11,51 @@ import (
 // Config contains application configuration
 type Config struct {
 	// Server settings
-	ServerHost      string
-	ServerPort      int
-	Environment     string
-	LogLevel        string
-	CorsAllowOrigin string
-	LogFormat       string
+	ServerHost       string
+	ServerPort       int
+	Environment      string
+	LogLevel         string
+	CorsAllowOrigins []string // Allowed origins; "*" allows all, "*.example.com" allows subdomains
+	TrustedProxies   []string // Proxy IPs or CIDRs whose X-Forwarded-For is trusted for the client IP
+	UserIDHeader     string   // Header a trusted proxy puts the authenticated user ID in; empty means anonymous
+	LogFormat        string
+	AdminTokenHash   string // bcrypt hash of the bearer token required by protected admin endpoints
+	AdminToken       string // Plain-text admin token, hashed at startup when AdminTokenHash is unset
+
+	// Minutes an admin session token from POST /api/v1/auth/token stays valid
+	AdminSessionTTLMinutes int
 
 	// Session settings
-	SessionTimeoutMinutes  int
-	MaxConcurrentSessions  int
-	CleanupIntervalMinutes int
+	SessionTimeoutMinutes    int
+	MaxConcurrentSessions    int
+	MaxConcurrentValidations int // Validations allowed to run at once across all sessions
+	CleanupIntervalMinutes   int
+	OrphanCleanupEnabled     bool  // Delete leftover session namespaces on startup
+	AllowDeprecatedScenarios bool  // Allow new sessions of scenarios marked deprecated
+	NetworkIsolationEnabled  bool  // Apply a NetworkPolicy isolating namespaces created for sessions
+	WarningMinutes           []int // Minutes before expiry at which an expiry_warning event is sent
+
+	// Default ResourceQuota hard limits of session namespaces
+	QuotaCPU    string
+	QuotaMemory string
+	QuotaPods   string
+
+	// Seconds to wait for in-flight requests and validations on shutdown
+	GracefulShutdownTimeoutSeconds int
+
+	// Terminal settings
+	TerminalIdleTimeoutMinutes       int
+	HistoryRedactPatterns            []string // Regular expressions redacted from terminal command history
+	TerminalHistoryRetentionMinutes  int      // Age after which recorded commands are dropped, 0 keeps them
+	MaxTerminalConnectionsPerSession int      // WebSockets allowed per terminal at once
+
+	// Webhook settings
+	Webhook WebhookConfig
+
+	// Cluster pool settings
+	HealthCheckIntervalMinutes int
+	ClusterPoolSize            int
+	ClusterPoolNamespacePrefix string // Cluster IDs and namespaces are <prefix>1 ... <prefix>N
 
 	// VM settings
 	TemplatePath         string
@@ -37,24 +71,87 @@ type Config struct {
 	ValidateGoldenImage  bool   // Whether to validate image exists before VM creation
 
 	// Scenario settings
-	ScenariosPath string
+	ScenariosPath           string
+	ScenarioCacheTTLSeconds int // Age after which scenarios are reloaded in the background, 0 never reloads
+
+	// API specification generated by swag, served at /swagger/doc.json
+	APISpecPath string
+
+	// OTLP/HTTP endpoint traces are exported to; tracing is disabled when empty
+	OTLPEndpoint string
+
+	// Validation service: address of a remote ValidationService (empty = use the local validator),
+	// and the address this backend serves ValidationService on (empty = disabled)
+	ValidationServiceEndpoint string
+	ValidationGRPCAddr        string
+	ValidationGRPCToken       string // Bearer token shared by the validation service and its clients
+	ValidationGRPCTLSCertFile string // Certificate and key the validation service serves TLS with
+	ValidationGRPCTLSKeyFile  string
+	ValidationServiceCAFile   string // CA verifying the remote validation service, empty connects without TLS
+}
+
+// WebhookConfig configures notifications of session events to an external endpoint
+type WebhookConfig struct {
+	URL    string   // Webhooks are disabled when empty
+	Secret string   // Key for the HMAC-SHA256 request signature
+	Events []string // Event types to deliver, e.g. session.created
 }
 
+// AdminTokenHashCost is the bcrypt cost used to hash admin tokens
+const AdminTokenHashCost = 12
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
 	config := &Config{
 		// Server defaults
-		ServerHost:      getEnv("SERVER_HOST", "0.0.0.0"),
-		ServerPort:      getEnvAsInt("SERVER_PORT", 8080),
-		Environment:     getEnv("ENVIRONMENT", "development"),
-		LogLevel:        getEnv("LOG_LEVEL", "info"),
-		CorsAllowOrigin: getEnv("CORS_ALLOW_ORIGIN", "*"),
-		LogFormat:       getEnv("LOG_FORMAT", "text"),
+		ServerHost:       getEnv("SERVER_HOST", "0.0.0.0"),
+		ServerPort:       getEnvAsInt("SERVER_PORT", 8080),
+		Environment:      getEnv("ENVIRONMENT", "development"),
+		LogLevel:         getEnv("LOG_LEVEL", "info"),
+		CorsAllowOrigins: getEnvAsSlice("CORS_ALLOW_ORIGINS", ",", getEnvAsSlice("CORS_ALLOW_ORIGIN", ",", []string{"*"})),
+		TrustedProxies:   getEnvAsSlice("TRUSTED_PROXIES", ",", nil),
+		UserIDHeader:     getEnv("USER_ID_HEADER", ""),
+		LogFormat:        getEnv("LOG_FORMAT", "text"),
+		AdminTokenHash:   getEnv("ADMIN_TOKEN_HASH", ""),
+		AdminToken:       getEnv("ADMIN_TOKEN", ""),
+
+		AdminSessionTTLMinutes: getEnvAsInt("ADMIN_SESSION_TTL_MINUTES", 60),
 
 		// Session defaults
-		SessionTimeoutMinutes:  getEnvAsInt("SESSION_TIMEOUT_MINUTES", 60),
-		MaxConcurrentSessions:  getEnvAsInt("MAX_CONCURRENT_SESSIONS", 10),
-		CleanupIntervalMinutes: getEnvAsInt("CLEANUP_INTERVAL_MINUTES", 5),
+		SessionTimeoutMinutes:    getEnvAsInt("SESSION_TIMEOUT_MINUTES", 60),
+		MaxConcurrentSessions:    getEnvAsInt("MAX_CONCURRENT_SESSIONS", 10),
+		MaxConcurrentValidations: getEnvAsInt("MAX_CONCURRENT_VALIDATIONS", 5),
+		CleanupIntervalMinutes:   getEnvAsInt("CLEANUP_INTERVAL_MINUTES", 5),
+		OrphanCleanupEnabled:     getEnvAsBool("ORPHAN_CLEANUP_ENABLED", true),
+		AllowDeprecatedScenarios: getEnvAsBool("ALLOW_DEPRECATED_SCENARIOS", false),
+		NetworkIsolationEnabled:  getEnvAsBool("NETWORK_ISOLATION_ENABLED", false),
+		WarningMinutes:           getEnvAsIntSlice("SESSION_WARNING_MINUTES", ",", []int{10, 5, 1}),
+
+		QuotaCPU:    getEnv("SESSION_QUOTA_CPU", "16"),
+		QuotaMemory: getEnv("SESSION_QUOTA_MEMORY", "16Gi"),
+		QuotaPods:   getEnv("SESSION_QUOTA_PODS", "20"),
+
+		GracefulShutdownTimeoutSeconds: getEnvAsInt("GRACEFUL_SHUTDOWN_TIMEOUT_SECONDS", 60),
+
+		// Terminal defaults
+		TerminalIdleTimeoutMinutes:       getEnvAsInt("TERMINAL_IDLE_TIMEOUT_MINUTES", 10),
+		MaxTerminalConnectionsPerSession: getEnvAsInt("MAX_TERMINAL_CONNECTIONS_PER_SESSION", 3),
+		TerminalHistoryRetentionMinutes:  getEnvAsInt("TERMINAL_HISTORY_RETENTION_MINUTES", 60),
+		HistoryRedactPatterns: getEnvAsSlice("TERMINAL_HISTORY_REDACT_PATTERNS", ";", []string{
+			`(?i)(password|passwd|token|secret|api[-_]?key)(\s*[=:]\s*|\s+)\S+`,
+		}),
+
+		// Webhook defaults
+		Webhook: WebhookConfig{
+			URL:    getEnv("WEBHOOK_URL", ""),
+			Secret: getEnv("WEBHOOK_SECRET", ""),
+			Events: getEnvAsSlice("WEBHOOK_EVENTS", ",", []string{"session.created", "session.completed", "task.completed"}),
+		},
+
+		// Cluster pool defaults
+		HealthCheckIntervalMinutes: getEnvAsInt("HEALTH_CHECK_INTERVAL_MINUTES", 5),
+		ClusterPoolSize:            getEnvAsInt("CLUSTER_POOL_SIZE", 3),
+		ClusterPoolNamespacePrefix: getEnv("CLUSTER_POOL_NAMESPACE_PREFIX", "cluster"),
 
 		// VM defaults
 		TemplatePath:         getEnv("TEMPLATE_PATH", "templates"),
@@ -70,7 +167,19 @@ func LoadConfig() (*Config, error) {
 		ValidateGoldenImage:  getEnvAsBool("VALIDATE_GOLDEN_IMAGE", true),
 
 		// Scenario defaults
-		ScenariosPath: getEnv("SCENARIOS_PATH", "scenarios"),
+		ScenariosPath:           getEnv("SCENARIOS_PATH", "scenarios"),
+		ScenarioCacheTTLSeconds: getEnvAsInt("SCENARIO_CACHE_TTL_SECONDS", 0),
+
+		APISpecPath: getEnv("API_SPEC_PATH", "docs/swagger.json"),
+
+		OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+
+		ValidationServiceEndpoint: getEnv("VALIDATION_SERVICE_ENDPOINT", ""),
+		ValidationGRPCAddr:        getEnv("VALIDATION_GRPC_ADDR", ""),
+		ValidationGRPCToken:       getEnv("VALIDATION_GRPC_TOKEN", ""),
+		ValidationGRPCTLSCertFile: getEnv("VALIDATION_GRPC_TLS_CERT_FILE", ""),
+		ValidationGRPCTLSKeyFile:  getEnv("VALIDATION_GRPC_TLS_KEY_FILE", ""),
+		ValidationServiceCAFile:   getEnv("VALIDATION_SERVICE_CA_FILE", ""),
 	}
 
 	return config, nil
@@ -126,3 +235,22 @@ func getEnvAsSlice(key, sep string, defaultValue []string) []string {
 
 	return strings.Split(valueStr, sep)
 }
+
+// getEnvAsIntSlice gets an environment variable as a slice of integers or returns a default value
+func getEnvAsIntSlice(key, sep string, defaultValue []int) []int {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	values := make([]int, 0)
+	for _, part := range strings.Split(valueStr, sep) {
+		value, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return defaultValue
+		}
+		values = append(values, value)
+	}
+
+	return values
+}
@@ -0,0 +1,67 @@
+// internal/config/validate.go - Configuration validation
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"golang.org/x/crypto/bcrypt"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// kubernetesVersionPattern matches Kubernetes release versions, with or without the leading v
+var kubernetesVersionPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+$`)
+
+// Validate checks the configuration and returns every problem found, joined into one error,
+// so operators can fix them all at once. Each problem names the environment variable to change.
+func (c *Config) Validate() error {
+	var problems []error
+	report := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	if c.ServerPort < 1 || c.ServerPort > 65535 {
+		report("SERVER_PORT must be between 1 and 65535, got %d", c.ServerPort)
+	}
+	if c.MaxConcurrentSessions < 1 {
+		report("MAX_CONCURRENT_SESSIONS must be positive, got %d", c.MaxConcurrentSessions)
+	}
+	if c.SessionTimeoutMinutes < 10 || c.SessionTimeoutMinutes > 480 {
+		report("SESSION_TIMEOUT_MINUTES must be between 10 and 480, got %d", c.SessionTimeoutMinutes)
+	}
+
+	if info, err := os.Stat(c.ScenariosPath); err != nil {
+		report("SCENARIOS_PATH %q cannot be read: %v", c.ScenariosPath, err)
+	} else if !info.IsDir() {
+		report("SCENARIOS_PATH %q is not a directory", c.ScenariosPath)
+	}
+	if _, err := os.Stat(c.TemplatePath); err != nil {
+		report("TEMPLATE_PATH %q cannot be read: %v", c.TemplatePath, err)
+	}
+
+	if !kubernetesVersionPattern.MatchString(c.KubernetesVersion) {
+		report("KUBERNETES_VERSION must look like 1.33.0 or v1.33.0, got %q", c.KubernetesVersion)
+	}
+	if _, err := resource.ParseQuantity(c.VMCPUCores); err != nil {
+		report("VM_CPU_CORES must be a CPU quantity such as 2 or 1500m, got %q", c.VMCPUCores)
+	}
+	if c.GracefulShutdownTimeoutSeconds < 1 {
+		report("GRACEFUL_SHUTDOWN_TIMEOUT_SECONDS must be positive, got %d", c.GracefulShutdownTimeoutSeconds)
+	}
+	if c.AdminSessionTTLMinutes < 1 {
+		report("ADMIN_SESSION_TTL_MINUTES must be positive, got %d", c.AdminSessionTTLMinutes)
+	}
+	if c.UserIDHeader != "" && len(c.TrustedProxies) == 0 {
+		report("USER_ID_HEADER requires TRUSTED_PROXIES, the proxies allowed to set it")
+	}
+	if c.AdminTokenHash != "" {
+		if _, err := bcrypt.Cost([]byte(c.AdminTokenHash)); err != nil {
+			report("ADMIN_TOKEN_HASH must be a bcrypt hash: %v", err)
+		}
+	}
+
+	return errors.Join(problems...)
+}
@@ -2,44 +2,138 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 
+	"github.com/fullstack-pw/cks/backend/internal/audit"
 	"github.com/fullstack-pw/cks/backend/internal/kubevirt"
+	"github.com/fullstack-pw/cks/backend/internal/middleware"
+	"github.com/fullstack-pw/cks/backend/internal/models"
+	"github.com/fullstack-pw/cks/backend/internal/scenarios"
+	"github.com/fullstack-pw/cks/backend/internal/services"
 	"github.com/fullstack-pw/cks/backend/internal/sessions"
 )
 
 // AdminController handles administrative operations
 type AdminController struct {
-	sessionManager *sessions.SessionManager
-	kubevirtClient *kubevirt.Client // ADD THIS
-	logger         *logrus.Logger
+	sessionManager  *sessions.SessionManager
+	scenarioService services.ScenarioService
+	kubevirtClient  *kubevirt.Client
+	adminSessions   *middleware.AdminSessions
+	auditLogger     audit.AuditLogger
+	logger          *logrus.Logger
 }
 
 // NewAdminController creates a new admin controller
-func NewAdminController(sessionManager *sessions.SessionManager, kubevirtClient *kubevirt.Client, logger *logrus.Logger) *AdminController {
+func NewAdminController(sessionManager *sessions.SessionManager, scenarioService services.ScenarioService, kubevirtClient *kubevirt.Client, adminSessions *middleware.AdminSessions, auditLogger audit.AuditLogger, logger *logrus.Logger) *AdminController {
 	return &AdminController{
-		sessionManager: sessionManager,
-		kubevirtClient: kubevirtClient, // ADD THIS
-		logger:         logger,
+		sessionManager:  sessionManager,
+		scenarioService: scenarioService,
+		kubevirtClient:  kubevirtClient,
+		adminSessions:   adminSessions,
+		auditLogger:     auditLogger,
+		logger:          logger,
 	}
 }
 
 // RegisterRoutes registers the admin controller routes
 func (ac *AdminController) RegisterRoutes(router *gin.Engine) {
-	admin := router.Group("/api/v1/admin")
+	// Every admin route requires the admin token
+	admin := router.Group("/api/v1/admin", middleware.AdminAuth(ac.adminSessions))
 	{
-		admin.POST("/bootstrap-pool", ac.BootstrapClusterPool)
-		admin.POST("/create-snapshots", ac.CreatePoolSnapshots)
-		admin.POST("/release-all-clusters", ac.ReleaseAllClusters)
+		admin.POST("/bootstrap-pool", ac.audited("bootstrap_pool", "cluster_pool", ac.BootstrapClusterPool))
+		admin.POST("/create-snapshots", ac.audited("create_snapshots", "cluster_pool", ac.CreatePoolSnapshots))
+		admin.POST("/release-all-clusters", ac.audited("release_all_clusters", "cluster_pool", ac.ReleaseAllClusters))
+		admin.GET("/audit", ac.audited("list_audit_events", "audit", ac.ListAuditEvents))
+		admin.GET("/vms", ac.audited("list_vms", "vm", ac.ListVMs))
+		admin.POST("/scenarios", ac.audited("create_scenario", "scenario", ac.CreateScenario))
+	}
+
+	pool := admin.Group("/pool")
+	{
+		pool.GET("/status", ac.audited("get_pool_status", "cluster_pool", ac.GetPoolStatus))
+		pool.POST("/clusters/:id/reset", ac.audited("reset_cluster", "cluster", ac.ResetCluster))
+	}
+
+	sessions := admin.Group("/sessions")
+	{
+		sessions.POST("/bulk-delete", ac.audited("bulk_delete_sessions", "session", ac.BulkDeleteSessions))
+		sessions.GET("/:id/resources", ac.audited("get_session_resources", "session", ac.GetSessionResources))
+		sessions.GET("/:id/vm-events", ac.audited("stream_vm_events", "session", ac.StreamVMEvents))
+		sessions.GET("/:id/vm-console", ac.audited("get_vm_console", "session", ac.GetVMConsoleOutput))
+		sessions.GET("/:id/base-snapshot", ac.audited("get_base_snapshot", "session", ac.GetBaseSnapshot))
+		sessions.POST("/:id/base-snapshot", ac.audited("create_base_snapshot", "session", ac.CreateBaseSnapshot))
+		sessions.DELETE("/:id/base-snapshot", ac.audited("delete_base_snapshot", "session", ac.DeleteBaseSnapshots))
+	}
+}
+
+// audited wraps an admin handler so that an audit event is recorded once it has responded
+func (ac *AdminController) audited(action, resource string, handler gin.HandlerFunc) gin.HandlerFunc {
+	return auditedHandler(ac.auditLogger, action, resource, handler)
+}
+
+// auditedHandler wraps a handler behind the admin token so that auditLogger records an audit
+// event once it has responded. Every admin route is wrapped, whichever controller serves it.
+func auditedHandler(auditLogger audit.AuditLogger, action, resource string, handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		handler(c)
+
+		actor := c.GetString("Actor")
+		if actor == "" {
+			actor = c.ClientIP()
+		}
+
+		outcome := audit.OutcomeSuccess
+		if c.Writer.Status() >= http.StatusBadRequest {
+			outcome = audit.OutcomeFailure
+		}
+
+		auditLogger.Log(c.Request.Context(), audit.AuditEvent{
+			Action:     action,
+			Actor:      actor,
+			Resource:   resource,
+			ResourceID: c.Param("id"),
+			Outcome:    outcome,
+			Details: map[string]interface{}{
+				"method":     c.Request.Method,
+				"path":       c.Request.URL.Path,
+				"statusCode": c.Writer.Status(),
+				"clientIP":   c.ClientIP(),
+			},
+			Timestamp: time.Now(),
+		})
 	}
 }
 
-// BootstrapClusterPool bootstraps all 3 baseline clusters
+// ListAuditEvents returns the most recent admin audit events
+//
+// @Summary List recent admin audit events
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/audit [get]
+func (ac *AdminController) ListAuditEvents(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"events": ac.auditLogger.Recent(),
+	})
+}
+
+// BootstrapClusterPool bootstraps all baseline clusters
+//
+// @Summary Bootstrap the cluster pool
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/bootstrap-pool [post]
 func (ac *AdminController) BootstrapClusterPool(c *gin.Context) {
 	ac.logger.Info("Admin request to bootstrap cluster pool")
 
@@ -59,21 +153,28 @@ func (ac *AdminController) BootstrapClusterPool(c *gin.Context) {
 	ac.logger.Info("Cluster pool bootstrap completed successfully")
 	c.JSON(http.StatusOK, gin.H{
 		"message":  "Cluster pool bootstrapped successfully",
-		"clusters": []string{"cluster1", "cluster2", "cluster3"},
+		"clusters": ac.sessionManager.GetClusterPool().ClusterIDs(),
 		"status":   "completed",
 	})
 }
 
 // CreatePoolSnapshots creates snapshots from all clusters in the pool
+//
+// @Summary Snapshot the cluster pool
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/create-snapshots [post]
 func (ac *AdminController) CreatePoolSnapshots(c *gin.Context) {
 	ac.logger.Info("Admin request to create pool snapshots")
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Minute)
 	defer cancel()
 
-	// Create snapshots for all 3 clusters
+	// Create snapshots for all clusters in the pool
 	results := make(map[string]interface{})
-	clusterIDs := []string{"cluster1", "cluster2", "cluster3"}
+	clusterIDs := ac.sessionManager.GetClusterPool().ClusterIDs()
 
 	for _, clusterID := range clusterIDs {
 		ac.logger.WithField("clusterID", clusterID).Info("Creating snapshots for cluster")
@@ -97,6 +198,13 @@ func (ac *AdminController) CreatePoolSnapshots(c *gin.Context) {
 }
 
 // ReleaseAllClusters releases all clusters in the pool
+//
+// @Summary Release all clusters
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/release-all-clusters [post]
 func (ac *AdminController) ReleaseAllClusters(c *gin.Context) {
 	ac.logger.Info("Admin request to release all clusters")
 
@@ -127,6 +235,431 @@ func (ac *AdminController) ReleaseAllClusters(c *gin.Context) {
 	})
 }
 
+// GetPoolStatus returns pool statistics together with the state of every cluster
+//
+// @Summary Get the cluster pool status
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} models.ClusterPoolDiagnostics
+// @Router /admin/pool/status [get]
+func (ac *AdminController) GetPoolStatus(c *gin.Context) {
+	clusterPool := ac.sessionManager.GetClusterPool()
+
+	c.JSON(http.StatusOK, models.ClusterPoolDiagnostics{
+		ClusterPoolStats: *clusterPool.GetPoolStatus(),
+		Clusters:         clusterPool.ListClusters(),
+	})
+}
+
+// ListVMs lists the VMs of the namespace given by the "namespace" query parameter,
+// or of all namespaces when it is omitted
+//
+// @Summary List VMs
+// @Tags admin
+// @Produce json
+// @Param namespace query string false "Namespace; all when omitted"
+// @Security AdminToken
+// @Success 200 {object} map[string][]kubevirt.VMInfo
+// @Router /admin/vms [get]
+func (ac *AdminController) ListVMs(c *gin.Context) {
+	namespace := c.Query("namespace")
+
+	vms, err := ac.kubevirtClient.ListVMs(c.Request.Context(), namespace, "")
+	if err != nil {
+		ac.logger.WithError(err).WithField("namespace", namespace).Error("Failed to list VMs")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"vms": vms})
+}
+
+// StreamVMEvents streams the Kubernetes events of a session VM as server-sent events.
+// The "vm" query parameter selects "control-plane" (default) or "worker-node".
+//
+// @Summary Stream the Kubernetes events of a session VM
+// @Tags admin
+// @Produce text/event-stream
+// @Param id path string true "Session ID"
+// @Param vm query string false "control-plane (default) or worker-node"
+// @Security AdminToken
+// @Success 200
+// @Failure 404 {object} map[string]string
+// @Router /admin/sessions/{id}/vm-events [get]
+func (ac *AdminController) StreamVMEvents(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	session, err := ac.sessionManager.GetSession(sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	var vmName string
+	switch c.DefaultQuery("vm", "control-plane") {
+	case "control-plane":
+		vmName = session.ControlPlaneVM
+	case "worker-node":
+		vmName = session.WorkerNodeVM
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "vm must be control-plane or worker-node"})
+		return
+	}
+
+	events, err := ac.kubevirtClient.WatchVMEvents(c.Request.Context(), session.Namespace, vmName)
+	if err != nil {
+		ac.logger.WithError(err).WithFields(logrus.Fields{
+			"sessionID": sessionID,
+			"vmName":    vmName,
+		}).Error("Failed to watch VM events")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The stream outlives the server write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		ac.logger.WithError(err).WithField("sessionID", sessionID).Debug("Failed to clear write deadline for VM event stream")
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// Watch ended by the API server or the client
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				ac.logger.WithError(err).WithField("sessionID", sessionID).Error("Failed to encode VM event")
+				continue
+			}
+			if _, err := fmt.Fprintf(c.Writer, "data: %s\n\n", data); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// maxVMConsoleLines bounds the "lines" query parameter of GetVMConsoleOutput
+const maxVMConsoleLines = 1000
+
+// GetVMConsoleOutput captures the serial console output of a session VM, for diagnosing VMs
+// that boot but never become ready. The "vm" query parameter selects "control-plane" (default)
+// or "worker-node" and "lines" the number of last lines returned (default 50).
+//
+// @Summary Capture the serial console output of a session VM
+// @Tags admin
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param vm query string false "control-plane (default) or worker-node"
+// @Param lines query int false "Last lines to return, 1 to 1000 (default 50)"
+// @Security AdminToken
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /admin/sessions/{id}/vm-console [get]
+func (ac *AdminController) GetVMConsoleOutput(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	lines, err := strconv.Atoi(c.DefaultQuery("lines", "50"))
+	if err != nil || lines < 1 || lines > maxVMConsoleLines {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("lines must be an integer from 1 to %d", maxVMConsoleLines)})
+		return
+	}
+
+	session, err := ac.sessionManager.GetSession(sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	vm := c.DefaultQuery("vm", "control-plane")
+	var vmName string
+	switch vm {
+	case "control-plane":
+		vmName = session.ControlPlaneVM
+	case "worker-node":
+		vmName = session.WorkerNodeVM
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "vm must be control-plane or worker-node"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Minute)
+	defer cancel()
+
+	output, err := ac.kubevirtClient.GetVMConsoleOutput(ctx, session.Namespace, vmName, lines)
+	if err != nil {
+		ac.logger.WithError(err).WithFields(logrus.Fields{
+			"sessionID": sessionID,
+			"vmName":    vmName,
+		}).Error("Failed to capture VM console output")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sessionId": sessionID,
+		"vm":        vm,
+		"vmName":    vmName,
+		"output":    output,
+	})
+}
+
+// BulkDeleteSessions deletes every session matching the scenario and age filters.
+// With dryRun set the matching sessions are only listed.
+//
+// @Summary Delete sessions in bulk
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body models.BulkDeleteRequest true "Session filters"
+// @Security AdminToken
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Router /admin/sessions/bulk-delete [post]
+func (ac *AdminController) BulkDeleteSessions(c *gin.Context) {
+	var request models.BulkDeleteRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if request.ScenarioID == "" && request.OlderThanMinutes <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "scenarioId or olderThanMinutes is required"})
+		return
+	}
+
+	filter := sessions.BulkDeleteFilter{
+		ScenarioID: request.ScenarioID,
+		DryRun:     request.DryRun,
+	}
+	if request.OlderThanMinutes > 0 {
+		filter.OlderThan = time.Duration(request.OlderThanMinutes) * time.Minute
+	}
+
+	deleted, sessionIDs, err := ac.sessionManager.BulkDeleteSessions(c.Request.Context(), filter)
+	if err != nil {
+		ac.logger.WithError(err).WithField("scenarioID", request.ScenarioID).Warn("Some sessions could not be deleted")
+	}
+
+	failed := 0
+	if !request.DryRun {
+		failed = len(sessionIDs) - deleted
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deleted":    deleted,
+		"failed":     failed,
+		"sessionIds": sessionIDs,
+		"dryRun":     request.DryRun,
+	})
+}
+
+// CreateBaseSnapshot stops the VMs of a session, snapshots them and starts them again
+//
+// @Summary Snapshot the cluster of a session
+// @Tags admin
+// @Produce json
+// @Param id path string true "Session ID"
+// @Security AdminToken
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /admin/sessions/{id}/base-snapshot [post]
+func (ac *AdminController) CreateBaseSnapshot(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	if _, err := ac.sessionManager.GetSession(sessionID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Stopping, snapshotting and restarting two VMs takes several minutes
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 20*time.Minute)
+	defer cancel()
+
+	if err := ac.sessionManager.CreateBaseClusterSnapshot(ctx, sessionID); err != nil {
+		ac.logger.WithError(err).WithField("sessionID", sessionID).Error("Failed to create base snapshot")
+		status := http.StatusInternalServerError
+		if errors.Is(err, sessions.ErrInvalidSessionState) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	snapshots, err := ac.sessionManager.GetSnapshotInfo(c.Request.Context(), sessionID)
+	if err != nil {
+		ac.logger.WithError(err).WithField("sessionID", sessionID).Warn("Failed to get base snapshot info")
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Base snapshot created",
+		"snapshots": snapshots,
+	})
+}
+
+// GetBaseSnapshot returns the base snapshots of a session
+//
+// @Summary Get the base snapshots of a session
+// @Tags admin
+// @Produce json
+// @Param id path string true "Session ID"
+// @Security AdminToken
+// @Success 200 {object} map[string][]kubevirt.SnapshotInfo
+// @Failure 404 {object} map[string]string
+// @Router /admin/sessions/{id}/base-snapshot [get]
+func (ac *AdminController) GetBaseSnapshot(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	if _, err := ac.sessionManager.GetSession(sessionID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	snapshots, err := ac.sessionManager.GetSnapshotInfo(c.Request.Context(), sessionID)
+	if err != nil {
+		ac.logger.WithError(err).WithField("sessionID", sessionID).Error("Failed to get base snapshot info")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"snapshots": snapshots})
+}
+
+// DeleteBaseSnapshots deletes the base snapshots of a session
+//
+// @Summary Delete the base snapshots of a session
+// @Tags admin
+// @Produce json
+// @Param id path string true "Session ID"
+// @Security AdminToken
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /admin/sessions/{id}/base-snapshot [delete]
+func (ac *AdminController) DeleteBaseSnapshots(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	if _, err := ac.sessionManager.GetSession(sessionID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := ac.sessionManager.DeleteBaseSnapshots(c.Request.Context(), sessionID); err != nil {
+		ac.logger.WithError(err).WithField("sessionID", sessionID).Error("Failed to delete base snapshots")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Base snapshots deleted"})
+}
+
+// GetSessionResources returns the CPU and memory used by each VM of a session
+//
+// @Summary Get the resource usage of session VMs
+// @Tags admin
+// @Produce json
+// @Param id path string true "Session ID"
+// @Security AdminToken
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Router /admin/sessions/{id}/resources [get]
+func (ac *AdminController) GetSessionResources(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	session, err := ac.sessionManager.GetSession(sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	usage := make(map[string]*kubevirt.VMResourceUsage)
+	errs := make(map[string]string)
+	for _, vmName := range []string{session.ControlPlaneVM, session.WorkerNodeVM} {
+		vmUsage, err := ac.kubevirtClient.GetVMResourceUsage(ctx, session.Namespace, vmName)
+		if err != nil {
+			ac.logger.WithError(err).WithFields(logrus.Fields{
+				"sessionID": sessionID,
+				"vmName":    vmName,
+			}).Warn("Failed to get VM resource usage")
+			errs[vmName] = err.Error()
+			continue
+		}
+		usage[vmName] = vmUsage
+	}
+
+	response := gin.H{
+		"sessionId": sessionID,
+		"namespace": session.Namespace,
+		"vms":       usage,
+	}
+	if len(errs) > 0 {
+		response["errors"] = errs
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ResetCluster triggers a background reset of a single cluster
+//
+// @Summary Reset a pool cluster
+// @Tags admin
+// @Produce json
+// @Param id path string true "Cluster ID"
+// @Security AdminToken
+// @Success 202 {object} map[string]interface{}
+// @Router /admin/pool/clusters/{id}/reset [post]
+func (ac *AdminController) ResetCluster(c *gin.Context) {
+	clusterID := c.Param("id")
+
+	ac.logger.WithField("clusterID", clusterID).Info("Admin request to reset cluster")
+
+	clusterPool := ac.sessionManager.GetClusterPool()
+	if _, err := clusterPool.GetClusterByID(clusterID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Cluster not found: %v", err)})
+		return
+	}
+
+	if err := clusterPool.ResetCluster(clusterID); err != nil {
+		ac.logger.WithError(err).WithField("clusterID", clusterID).Warn("Cluster reset rejected")
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Failed to reset cluster",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":   "Cluster reset started",
+		"clusterId": clusterID,
+	})
+}
+
 // createClusterSnapshots creates snapshots for both VMs in a specific cluster
 func (ac *AdminController) createClusterSnapshots(ctx context.Context, clusterID string) (map[string]interface{}, error) {
 	namespace := clusterID // namespace matches clusterID
@@ -178,3 +711,46 @@ func (ac *AdminController) createClusterSnapshots(ctx context.Context, clusterID
 		"namespace": namespace,
 	}, nil
 }
+
+// CreateScenario writes a scenario defined in JSON to the scenarios directory
+//
+// @Summary Create a scenario
+// @Description Tasks need numeric IDs such as "01"; each is written as tasks/<id>-task.md with its rules in validation/<id>-validation.yaml.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param scenario body models.Scenario true "Scenario definition"
+// @Security AdminToken
+// @Success 201 {object} models.Scenario
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /admin/scenarios [post]
+func (ac *AdminController) CreateScenario(c *gin.Context) {
+	var scenario models.Scenario
+	if err := c.ShouldBindJSON(&scenario); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid request: %v", err)})
+		return
+	}
+
+	if err := ac.scenarioService.CreateScenario(&scenario); err != nil {
+		switch {
+		case errors.Is(err, scenarios.ErrScenarioExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case scenarios.IsInvalidError(err):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to create scenario: %v", err)})
+		}
+		return
+	}
+
+	created, err := ac.scenarioService.GetScenario(scenario.ID)
+	if err != nil {
+		// Written but not loadable, e.g. a setup file the loader rejected
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Scenario created but failed to load: %v", err)})
+		return
+	}
+
+	ac.logger.WithField("scenarioID", scenario.ID).Info("Scenario created")
+	c.JSON(http.StatusCreated, created)
+}
@@ -0,0 +1,77 @@
+// backend/internal/controllers/auth_controller.go - Exchange of the admin token for session tokens
+
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/middleware"
+)
+
+// AuthController issues the admin session tokens accepted by the admin endpoints
+type AuthController struct {
+	adminSessions *middleware.AdminSessions
+	logger        *logrus.Logger
+}
+
+// NewAuthController creates a new auth controller
+func NewAuthController(adminSessions *middleware.AdminSessions, logger *logrus.Logger) *AuthController {
+	return &AuthController{
+		adminSessions: adminSessions,
+		logger:        logger,
+	}
+}
+
+// RegisterRoutes registers the auth controller routes
+func (ac *AuthController) RegisterRoutes(router gin.IRouter) {
+	router.POST("/api/v1/auth/token", ac.CreateToken)
+}
+
+// tokenRequest carries the admin token to exchange
+type tokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// tokenResponse carries an admin session token
+type tokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// CreateToken exchanges the admin token for a short-lived admin session token
+//
+// @Summary Exchange the admin token for a session token
+// @Description The returned token is sent as "Bearer <token>" to the admin endpoints until it expires
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body tokenRequest true "Admin token"
+// @Success 200 {object} tokenResponse
+// @Failure 401 {object} map[string]interface{} "Invalid admin token"
+// @Router /auth/token [post]
+func (ac *AuthController) CreateToken(c *gin.Context) {
+	var request tokenRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	token, expiresAt, err := ac.adminSessions.Exchange(request.Token)
+	if err != nil {
+		if errors.Is(err, middleware.ErrInvalidAdminToken) {
+			ac.logger.WithField("clientIP", c.ClientIP()).Warn("Rejected admin token exchange")
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid admin token"})
+			return
+		}
+		ac.logger.WithError(err).Error("Failed to issue admin session token")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenResponse{Token: token, ExpiresAt: expiresAt})
+}
@@ -0,0 +1,184 @@
+// backend/internal/controllers/health_controller.go - Health checks of the backend dependencies
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/fullstack-pw/cks/backend/internal/clusterpool"
+	"github.com/fullstack-pw/cks/backend/internal/kubevirt"
+)
+
+// dependencyCheckTimeout bounds each dependency check
+const dependencyCheckTimeout = 5 * time.Second
+
+// Health statuses, of both the backend and each dependency
+const (
+	healthOK        = "ok"
+	healthDegraded  = "degraded"
+	healthUnhealthy = "unhealthy"
+)
+
+// dependencyStatus is the result of checking one dependency
+type dependencyStatus struct {
+	Status    string      `json:"status"`
+	Error     string      `json:"error,omitempty"`
+	LatencyMs int64       `json:"latencyMs"`
+	Details   interface{} `json:"details,omitempty"`
+}
+
+// HealthController reports the status of the services the backend depends on
+type HealthController struct {
+	kubeClient     kubernetes.Interface
+	kubevirtClient *kubevirt.Client
+	clusterPool    *clusterpool.Manager
+	scenariosPath  string
+	logger         *logrus.Logger
+}
+
+// NewHealthController creates a new health controller
+func NewHealthController(kubeClient kubernetes.Interface, kubevirtClient *kubevirt.Client, clusterPool *clusterpool.Manager, scenariosPath string, logger *logrus.Logger) *HealthController {
+	return &HealthController{
+		kubeClient:     kubeClient,
+		kubevirtClient: kubevirtClient,
+		clusterPool:    clusterPool,
+		scenariosPath:  scenariosPath,
+		logger:         logger,
+	}
+}
+
+// RegisterRoutes registers the health controller routes
+func (hc *HealthController) RegisterRoutes(router gin.IRouter) {
+	router.GET("/api/v1/health/detailed", hc.GetDetailedHealth)
+}
+
+// GetDetailedHealth checks every dependency. Kubernetes and KubeVirt are required, so their
+// failure makes the backend unhealthy; other failures only degrade it.
+//
+// @Summary Check backend dependencies
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Success 207 {object} map[string]interface{} "Degraded"
+// @Failure 503 {object} map[string]interface{}
+// @Router /health/detailed [get]
+func (hc *HealthController) GetDetailedHealth(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	checks := map[string]struct {
+		critical bool
+		check    func(ctx context.Context) (interface{}, error)
+	}{
+		"kubernetes":  {critical: true, check: hc.checkKubernetes},
+		"kubevirt":    {critical: true, check: hc.checkKubeVirt},
+		"scenarios":   {check: hc.checkScenarios},
+		"clusterPool": {check: hc.checkClusterPool},
+	}
+
+	var (
+		wg           sync.WaitGroup
+		mu           sync.Mutex
+		dependencies = make(map[string]dependencyStatus, len(checks))
+		status       = healthOK
+	)
+
+	for name, dependency := range checks {
+		wg.Add(1)
+		go func(name string, critical bool, check func(ctx context.Context) (interface{}, error)) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, dependencyCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			details, err := check(checkCtx)
+			result := dependencyStatus{
+				Status:    healthOK,
+				LatencyMs: time.Since(start).Milliseconds(),
+				Details:   details,
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				result.Error = err.Error()
+
+				if critical {
+					result.Status = healthUnhealthy
+					status = healthUnhealthy
+				} else {
+					result.Status = healthDegraded
+					if status == healthOK {
+						status = healthDegraded
+					}
+				}
+
+				hc.logger.WithError(err).WithField("dependency", name).Warn("Dependency health check failed")
+			}
+			dependencies[name] = result
+		}(name, dependency.critical, dependency.check)
+	}
+	wg.Wait()
+
+	code := http.StatusOK
+	switch status {
+	case healthDegraded:
+		code = http.StatusMultiStatus
+	case healthUnhealthy:
+		code = http.StatusServiceUnavailable
+	}
+
+	c.JSON(code, gin.H{
+		"status":       status,
+		"dependencies": dependencies,
+	})
+}
+
+// checkKubernetes verifies the Kubernetes API answers requests
+func (hc *HealthController) checkKubernetes(ctx context.Context) (interface{}, error) {
+	if _, err := hc.kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{Limit: 1}); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	return nil, nil
+}
+
+// checkKubeVirt verifies the KubeVirt API is available
+func (hc *HealthController) checkKubeVirt(ctx context.Context) (interface{}, error) {
+	return nil, hc.kubevirtClient.VerifyKubeVirtAvailable(ctx)
+}
+
+// checkScenarios verifies the scenario directory is readable
+func (hc *HealthController) checkScenarios(ctx context.Context) (interface{}, error) {
+	info, err := os.Stat(hc.scenariosPath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", hc.scenariosPath)
+	}
+	return nil, nil
+}
+
+// checkClusterPool reports the pool status; the pool is degraded when it has no clusters
+// or some of them are in error
+func (hc *HealthController) checkClusterPool(ctx context.Context) (interface{}, error) {
+	stats := hc.clusterPool.GetPoolStatus()
+	if stats.TotalClusters == 0 {
+		return stats, fmt.Errorf("cluster pool is empty")
+	}
+	if stats.ErrorClusters > 0 {
+		return stats, fmt.Errorf("%d of %d clusters are in error", stats.ErrorClusters, stats.TotalClusters)
+	}
+	return stats, nil
+}
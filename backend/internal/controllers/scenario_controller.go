@@ -3,71 +3,381 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
+	"github.com/fullstack-pw/cks/backend/internal/audit"
+	"github.com/fullstack-pw/cks/backend/internal/middleware"
 	"github.com/fullstack-pw/cks/backend/internal/models"
+	"github.com/fullstack-pw/cks/backend/internal/scenarios"
 	"github.com/fullstack-pw/cks/backend/internal/services"
+	"github.com/fullstack-pw/cks/backend/internal/sessions"
+	"github.com/fullstack-pw/cks/backend/internal/validation"
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 )
 
 // ScenarioController handles HTTP requests related to scenarios
 type ScenarioController struct {
-	scenarioService services.ScenarioService
+	scenarioService  services.ScenarioService
+	sessionService   services.SessionService
+	unifiedValidator *validation.UnifiedValidator
+	adminSessions    *middleware.AdminSessions
+	auditLogger      audit.AuditLogger
+	logger           *logrus.Logger
 }
 
 // NewScenarioController creates a new scenario controller
-func NewScenarioController(scenarioService services.ScenarioService) *ScenarioController {
+func NewScenarioController(scenarioService services.ScenarioService, sessionService services.SessionService, unifiedValidator *validation.UnifiedValidator, adminSessions *middleware.AdminSessions, auditLogger audit.AuditLogger, logger *logrus.Logger) *ScenarioController {
 	return &ScenarioController{
-		scenarioService: scenarioService,
+		scenarioService:  scenarioService,
+		sessionService:   sessionService,
+		unifiedValidator: unifiedValidator,
+		adminSessions:    adminSessions,
+		auditLogger:      auditLogger,
+		logger:           logger,
 	}
 }
 
+// RateScenarioRequest is a difficulty rating from a session that completed the scenario
+type RateScenarioRequest struct {
+	SessionID  string `json:"sessionId" binding:"required"`
+	Difficulty int    `json:"difficulty" binding:"required"` // 1 (easy) to 5 (hard)
+}
+
+// TestScenarioRequest selects the session and task a scenario's validation rules are run against
+type TestScenarioRequest struct {
+	SessionID string `json:"sessionId" binding:"required"`
+	TaskID    string `json:"taskId" binding:"required"`
+}
+
 // RegisterRoutes registers the scenario controller routes
-func (sc *ScenarioController) RegisterRoutes(router *gin.Engine) {
+func (sc *ScenarioController) RegisterRoutes(router gin.IRouter) {
 	scenarios := router.Group("/api/v1/scenarios")
 	{
 		scenarios.GET("", sc.ListScenarios)
 		scenarios.GET("/:id", sc.GetScenario)
 		scenarios.GET("/categories", sc.ListCategories)
+		scenarios.GET("/tags", sc.ListTags)
+		scenarios.GET("/recommended", sc.GetRecommendedScenarios)
+		scenarios.GET("/categories/tree", sc.GetCategoryTree)
 		scenarios.POST("/reload", sc.ReloadScenarios)
-		scenarios.GET("/:id/tasks/:taskId/validation", sc.GetTaskValidation)
+		scenarios.GET("/:id/version", sc.GetScenarioVersion)
+		scenarios.GET("/:id/tasks", sc.GetScenarioTasks)
+		scenarios.GET("/:id/tasks/:taskId/validation", middleware.AdminAuth(sc.adminSessions), auditedHandler(sc.auditLogger, "get_task_validation", "scenario", sc.GetTaskValidation))
+		scenarios.GET("/:id/stats", sc.GetScenarioStats)
+		scenarios.GET("/:id/difficulty-rating", sc.GetDifficultyRating)
+		scenarios.POST("/:id/rate", sc.RateScenario)
+	}
+}
+
+// RegisterValidationRoutes registers the routes that run validation rules against a session
+func (sc *ScenarioController) RegisterValidationRoutes(router gin.IRouter) {
+	router.POST("/api/v1/scenarios/:id/test", middleware.AdminAuth(sc.adminSessions), auditedHandler(sc.auditLogger, "test_scenario", "scenario", sc.TestScenario))
+}
 
+// RegisterAdminRoutes registers the scenario export and import routes, which require the admin token
+func (sc *ScenarioController) RegisterAdminRoutes(router gin.IRouter) {
+	admin := router.Group("/api/v1/admin/scenarios", middleware.AdminAuth(sc.adminSessions))
+	{
+		admin.GET("/:id/export", auditedHandler(sc.auditLogger, "export_scenario", "scenario", sc.ExportScenario))
+		admin.POST("/import", auditedHandler(sc.auditLogger, "import_scenarios", "scenario", sc.ImportScenarios))
 	}
 }
 
 // ListScenarios returns a list of all available scenarios
+//
+// @Summary List scenarios
+// @Tags scenarios
+// @Produce json
+// @Param category query string false "Category ID"
+// @Param difficulty query string false "Difficulty"
+// @Param search query string false "Search text"
+// @Param tags query string false "Comma-separated tags, all required"
+// @Success 200 {array} models.Scenario
+// @Router /scenarios [get]
 func (sc *ScenarioController) ListScenarios(c *gin.Context) {
 	// Get query parameters for filtering
 	category := c.Query("category")
 	difficulty := c.Query("difficulty")
 	searchQuery := c.Query("search")
 
+	// Tags may be repeated or comma-separated
+	var tags []string
+	for _, value := range c.QueryArray("tags") {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
 	// Get scenarios with filters
-	scenarios, err := sc.scenarioService.ListScenarios(category, difficulty, searchQuery)
+	scenarios, err := sc.scenarioService.ListScenarios(category, difficulty, searchQuery, tags)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, scenarios)
+	public := make([]*models.Scenario, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		public = append(public, publicScenario(scenario))
+	}
+
+	c.JSON(http.StatusOK, public)
 }
 
-// GetScenario returns details for a specific scenario
+// GetScenario returns details for a specific scenario, without task solutions, hints or
+// validation rules
+//
+// @Summary Get a scenario
+// @Tags scenarios
+// @Produce json
+// @Param id path string true "Scenario ID"
+// @Success 200 {object} models.Scenario
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /scenarios/{id} [get]
 func (sc *ScenarioController) GetScenario(c *gin.Context) {
 	scenarioID := c.Param("id")
 
 	scenario, err := sc.scenarioService.GetScenario(scenarioID)
 	if err != nil {
+		respondScenarioError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, publicScenario(scenario))
+}
+
+// publicScenario copies a scenario for the public endpoints without the solutions, hints and
+// validation rules of its tasks. Sessions get hints and solutions as their attempts unlock them.
+func publicScenario(scenario *models.Scenario) *models.Scenario {
+	public := *scenario
+	public.Tasks = make([]models.Task, len(scenario.Tasks))
+	for i, task := range scenario.Tasks {
+		task.Solution = ""
+		task.Hints = nil
+		task.Validation = nil
+		public.Tasks[i] = task
+	}
+	return &public
+}
+
+// GetScenarioVersion returns the version and deprecation status of a scenario
+//
+// @Summary Get the version of a scenario
+// @Tags scenarios
+// @Produce json
+// @Param id path string true "Scenario ID"
+// @Success 200 {object} models.ScenarioVersion
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /scenarios/{id}/version [get]
+func (sc *ScenarioController) GetScenarioVersion(c *gin.Context) {
+	scenario, err := sc.scenarioService.GetScenario(c.Param("id"))
+	if err != nil {
+		respondScenarioError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ScenarioVersion{
+		Version:           scenario.Version,
+		Deprecated:        scenario.Deprecated,
+		MinBackendVersion: scenario.MinBackendVersion,
+	})
+}
+
+// GetScenarioTasks previews a scenario's tasks, leaving out validation rules and steps
+//
+// @Summary Preview the tasks of a scenario
+// @Tags scenarios
+// @Produce json
+// @Param id path string true "Scenario ID"
+// @Success 200 {array} models.TaskSummary
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /scenarios/{id}/tasks [get]
+func (sc *ScenarioController) GetScenarioTasks(c *gin.Context) {
+	scenarioID := c.Param("id")
+
+	scenario, err := sc.scenarioService.GetScenario(scenarioID)
+	if err != nil {
+		respondScenarioError(c, err)
+		return
+	}
+
+	tasks := make([]models.TaskSummary, 0, len(scenario.Tasks))
+	for _, task := range scenario.Tasks {
+		tasks = append(tasks, models.TaskSummary{
+			ID:               task.ID,
+			Title:            task.Title,
+			Description:      task.Description,
+			Objective:        task.Objective,
+			HintCount:        len(task.Hints),
+			EstimatedMinutes: (task.TimeEstimateSeconds + 59) / 60,
+		})
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// respondScenarioError maps scenario loading errors to HTTP statuses
+func respondScenarioError(c *gin.Context, err error) {
+	var invalidErr *scenarios.ScenarioInvalidError
+	if errors.As(err, &invalidErr) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "reason": invalidErr.Reason})
+		return
+	}
+
+	var notFoundErr *scenarios.ScenarioNotFoundError
+	if errors.As(err, &notFoundErr) {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, scenario)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// GetScenarioStats returns completion statistics for a scenario since the server started
+//
+// @Summary Get scenario statistics
+// @Tags scenarios
+// @Produce json
+// @Param id path string true "Scenario ID"
+// @Success 200 {object} models.ScenarioStats
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /scenarios/{id}/stats [get]
+func (sc *ScenarioController) GetScenarioStats(c *gin.Context) {
+	scenarioID := c.Param("id")
+
+	scenario, err := sc.scenarioService.GetScenario(scenarioID)
+	if err != nil {
+		respondScenarioError(c, err)
+		return
+	}
+
+	stats, ok := sc.scenarioService.GetStats()[scenarioID]
+	if !ok {
+		// Not attempted yet
+		stats = models.ScenarioStats{
+			ScenarioID:          scenarioID,
+			TaskCompletionRates: make(map[string]float64, len(scenario.Tasks)),
+		}
+		for _, task := range scenario.Tasks {
+			stats.TaskCompletionRates[task.ID] = 0
+		}
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// RateScenario records the difficulty a user who completed the scenario rates it
+//
+// @Summary Rate the difficulty of a scenario
+// @Tags scenarios
+// @Accept json
+// @Produce json
+// @Param id path string true "Scenario ID"
+// @Param rating body RateScenarioRequest true "Rating from 1 (easy) to 5 (hard)"
+// @Success 200 {object} models.DifficultyRating
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /scenarios/{id}/rate [post]
+func (sc *ScenarioController) RateScenario(c *gin.Context) {
+	scenarioID := c.Param("id")
+
+	var req RateScenarioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid request: %v", err)})
+		return
+	}
+
+	if err := sc.scenarioService.RateDifficulty(scenarioID, req.SessionID, req.Difficulty); err != nil {
+		switch {
+		case errors.Is(err, scenarios.ErrInvalidDifficultyRating):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, scenarios.ErrScenarioNotCompleted):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			respondScenarioError(c, err)
+		}
+		return
+	}
+
+	rating, err := sc.scenarioService.GetDifficultyRating(scenarioID)
+	if err != nil {
+		respondScenarioError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rating)
+}
+
+// GetDifficultyRating returns the author's difficulty of a scenario and the average user rating
+//
+// @Summary Get the difficulty rating of a scenario
+// @Tags scenarios
+// @Produce json
+// @Param id path string true "Scenario ID"
+// @Success 200 {object} models.DifficultyRating
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /scenarios/{id}/difficulty-rating [get]
+func (sc *ScenarioController) GetDifficultyRating(c *gin.Context) {
+	rating, err := sc.scenarioService.GetDifficultyRating(c.Param("id"))
+	if err != nil {
+		respondScenarioError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rating)
+}
+
+// ListTags returns every tag used by the loaded scenarios
+//
+// @Summary List scenario tags
+// @Tags scenarios
+// @Produce json
+// @Success 200 {array} string
+// @Router /scenarios/tags [get]
+func (sc *ScenarioController) ListTags(c *gin.Context) {
+	c.JSON(http.StatusOK, sc.scenarioService.GetTags())
+}
+
+// GetRecommendedScenarios returns up to five scenario IDs to try next. Recommendations are
+// personalised for the authenticated user (see middleware.UserIdentity); for anonymous
+// requests the selection is random.
+//
+// @Summary Recommend scenarios
+// @Tags scenarios
+// @Produce json
+// @Success 200 {object} map[string][]string
+// @Router /scenarios/recommended [get]
+func (sc *ScenarioController) GetRecommendedScenarios(c *gin.Context) {
+	ids, err := sc.sessionService.RecommendScenarios(middleware.AuthenticatedUser(c))
+	if err != nil {
+		sc.logger.WithError(err).Error("Failed to recommend scenarios")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"scenarioIds": ids})
 }
 
-// ListCategories returns all available scenario categories
+// ListCategories returns all available scenario categories as an ID to name mapping
+//
+// @Summary List categories
+// @Tags scenarios
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /scenarios/categories [get]
 func (sc *ScenarioController) ListCategories(c *gin.Context) {
-	categories, err := sc.scenarioService.GetCategories()
+	categories, err := sc.scenarioService.GetCategoryNames()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -76,7 +386,30 @@ func (sc *ScenarioController) ListCategories(c *gin.Context) {
 	c.JSON(http.StatusOK, categories)
 }
 
+// GetCategoryTree returns the scenario categories nested under their parents
+//
+// @Summary Get the category tree
+// @Tags scenarios
+// @Produce json
+// @Success 200 {array} models.CategoryNode
+// @Router /scenarios/categories/tree [get]
+func (sc *ScenarioController) GetCategoryTree(c *gin.Context) {
+	tree, err := sc.scenarioService.GetCategories()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
 // ReloadScenarios handles scenario reloading
+//
+// @Summary Reload scenarios from disk
+// @Tags scenarios
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /scenarios/reload [post]
 func (sc *ScenarioController) ReloadScenarios(c *gin.Context) {
 	err := sc.scenarioService.ReloadScenarios()
 	if err != nil {
@@ -86,14 +419,98 @@ func (sc *ScenarioController) ReloadScenarios(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Scenarios reloaded"})
 }
 
-// GetTaskValidation returns validation rules for a specific task
+// ExportScenario downloads a scenario directory as a ZIP archive
+//
+// @Summary Export a scenario as a ZIP archive
+// @Tags admin
+// @Produce application/zip
+// @Param id path string true "Scenario ID"
+// @Security AdminToken
+// @Success 200 {file} binary
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /admin/scenarios/{id}/export [get]
+func (sc *ScenarioController) ExportScenario(c *gin.Context) {
+	scenarioID := c.Param("id")
+
+	data, err := sc.scenarioService.ExportScenario(scenarioID)
+	if err != nil {
+		respondScenarioError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.zip", scenarioID))
+	c.Data(http.StatusOK, "application/zip", data)
+}
+
+// ImportScenarios extracts an uploaded ZIP archive (form field "file") into the scenarios directory
+//
+// @Summary Import scenarios from a ZIP archive
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "ZIP archive of scenario directories"
+// @Security AdminToken
+// @Success 200 {object} map[string][]string
+// @Failure 400 {object} map[string]string
+// @Router /admin/scenarios/import [post]
+func (sc *ScenarioController) ImportScenarios(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing ZIP upload in form field \"file\""})
+		return
+	}
+	if fileHeader.Size > scenarios.MaxImportSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Archive too large"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to read upload: %v", err)})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to read upload: %v", err)})
+		return
+	}
+
+	imported, err := sc.scenarioService.ImportScenarios(data)
+	if err != nil {
+		if errors.Is(err, scenarios.ErrInvalidArchive) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "imported": imported})
+		return
+	}
+
+	sc.logger.WithField("scenarios", imported).Info("Scenarios imported")
+	c.JSON(http.StatusOK, gin.H{"imported": imported})
+}
+
+// GetTaskValidation returns validation rules for a specific task. It requires the admin token,
+// as the rules give away the expected answers.
+//
+// @Summary Get the validation rules of a task
+// @Tags scenarios
+// @Produce json
+// @Param id path string true "Scenario ID"
+// @Param taskId path string true "Task ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /scenarios/{id}/tasks/{taskId}/validation [get]
 func (sc *ScenarioController) GetTaskValidation(c *gin.Context) {
 	scenarioID := c.Param("id")
 	taskID := c.Param("taskId")
 
 	scenario, err := sc.scenarioService.GetScenario(scenarioID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondScenarioError(c, err)
 		return
 	}
 
@@ -116,3 +533,87 @@ func (sc *ScenarioController) GetTaskValidation(c *gin.Context) {
 		"validation": task.Validation,
 	})
 }
+
+// TestScenario runs a task's validation rules against an existing running session
+// without recording the result, so authors can check validation YAML
+//
+// @Summary Test validation rules against a session
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Scenario ID"
+// @Param request body TestScenarioRequest true "Session and task"
+// @Security AdminToken
+// @Success 200 {object} validation.ValidationResponse
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /scenarios/{id}/test [post]
+func (sc *ScenarioController) TestScenario(c *gin.Context) {
+	scenarioID := c.Param("id")
+
+	var request TestScenarioRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := sc.sessionService.GetSession(request.SessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Session not found: %v", err)})
+		return
+	}
+
+	if session.Status != models.SessionStatusRunning {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Session %s is not running (status: %s)", session.ID, session.Status)})
+		return
+	}
+
+	scenario, err := sc.scenarioService.GetScenario(scenarioID)
+	if err != nil {
+		respondScenarioError(c, err)
+		return
+	}
+
+	var task *models.Task
+	for i := range scenario.Tasks {
+		if scenario.Tasks[i].ID == request.TaskID {
+			task = &scenario.Tasks[i]
+			break
+		}
+	}
+
+	if task == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	sc.logger.WithFields(logrus.Fields{
+		"scenarioID": scenarioID,
+		"taskID":     task.ID,
+		"sessionID":  session.ID,
+		"rules":      len(task.Validation),
+	}).Info("Testing scenario validation rules")
+
+	// The request context is bounded by ValidationRouteTimeout on the validation routes
+	ctx := c.Request.Context()
+	release, err := sc.sessionService.AcquireValidationSlot(ctx)
+	if err != nil {
+		if errors.Is(err, sessions.ErrValidationQueueFull) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": fmt.Sprintf("Validation cancelled: %v", err)})
+		return
+	}
+	defer release()
+	defer sc.sessionService.TrackValidation()()
+
+	validationResponse, err := sc.unifiedValidator.ValidateTask(ctx, session, task.Validation)
+	if err != nil {
+		sc.logger.WithError(err).Error("Scenario validation test failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Validation failed: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, validationResponse)
+}
@@ -4,50 +4,93 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/fullstack-pw/cks/backend/internal/middleware"
 	"github.com/fullstack-pw/cks/backend/internal/models"
 	"github.com/fullstack-pw/cks/backend/internal/services"
-	"github.com/fullstack-pw/cks/backend/internal/validation"
+	"github.com/fullstack-pw/cks/backend/internal/sessions"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
 
+// ValidationRouteTimeout bounds the requests of the routes registered by RegisterValidationRoutes,
+// which run validation rules in the session VMs
+const ValidationRouteTimeout = 60 * time.Second
+
 // SessionController handles HTTP requests related to sessions
 type SessionController struct {
-	sessionService   services.SessionService
-	scenarioService  services.ScenarioService
-	logger           *logrus.Logger
-	unifiedValidator *validation.UnifiedValidator
+	sessionService  services.SessionService
+	scenarioService services.ScenarioService
+	logger          *logrus.Logger
 }
 
 // NewSessionController creates a new session controller
-func NewSessionController(sessionService services.SessionService, scenarioService services.ScenarioService, logger *logrus.Logger, unifiedValidator *validation.UnifiedValidator) *SessionController {
+func NewSessionController(sessionService services.SessionService, scenarioService services.ScenarioService, logger *logrus.Logger) *SessionController {
 	return &SessionController{
-		sessionService:   sessionService,
-		scenarioService:  scenarioService,
-		logger:           logger,
-		unifiedValidator: unifiedValidator,
+		sessionService:  sessionService,
+		scenarioService: scenarioService,
+		logger:          logger,
 	}
 }
 
 // RegisterRoutes registers the session controller routes
-func (sc *SessionController) RegisterRoutes(router *gin.Engine) {
+func (sc *SessionController) RegisterRoutes(router gin.IRouter) {
 	sessions := router.Group("/api/v1/sessions")
 	{
 		sessions.POST("", sc.CreateSession)
 		sessions.GET("", sc.ListSessions)
 		sessions.GET("/:id", sc.GetSession)
+		sessions.GET("/:id/view", sc.GetSessionView)
+		sessions.PATCH("/:id", sc.PatchSession)
 		sessions.DELETE("/:id", sc.DeleteSession)
 		sessions.PUT("/:id/extend", sc.ExtendSession)
+		sessions.PUT("/:id/pause", sc.PauseSession)
+		sessions.PUT("/:id/resume", sc.ResumeSession)
+		sessions.POST("/:id/snapshot", sc.CreateSessionSnapshot)
+		sessions.POST("/:id/restore-snapshot", sc.RestoreSessionSnapshot)
+		sessions.GET("/:id/progress", sc.GetProgress)
+		sessions.GET("/:id/kubeconfig", sc.GetKubeconfig)
+		sessions.GET("/:id/score", sc.GetScore)
 		sessions.GET("/:id/tasks", sc.ListTasks)
-		sessions.POST("/:id/tasks/:taskId/validate", sc.ValidateTask)
+		sessions.GET("/:id/tasks/:taskId/hints", sc.GetTaskHints)
+		sessions.POST("/:id/tasks/:taskId/hint/:index", sc.GetHint)
+		sessions.GET("/:id/tasks/:taskId/solution", sc.GetTaskSolution)
+		sessions.POST("/:id/tasks/:taskId/reset", sc.ResetTask)
 	}
 }
 
-// CreateSession handles the creation of a new session
+// RegisterValidationRoutes registers the task validation routes, which run commands in
+// the session VMs and are given a longer timeout than the other session routes
+func (sc *SessionController) RegisterValidationRoutes(router gin.IRouter) {
+	router.POST("/api/v1/sessions/:id/tasks/:taskId/validate", sc.ValidateTask)
+}
+
+// RegisterEventRoutes registers the long-lived event stream route, which must not be
+// wrapped in a request timeout
+func (sc *SessionController) RegisterEventRoutes(router gin.IRouter) {
+	router.GET("/api/v1/sessions/:id/events", sc.StreamEvents)
+	router.GET("/api/v1/sessions/:id/logs", sc.StreamProvisioningLog)
+}
+
+// CreateSession handles the creation of a new session for the authenticated user (see
+// middleware.UserIdentity), whose completed scenarios decide the prerequisites
+//
+// @Summary Create a session
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param request body models.CreateSessionRequest true "Scenario and tags"
+// @Success 201 {object} models.CreateSessionResponse
+// @Success 202 {object} models.CreateSessionResponse "Session queued until a cluster is available"
+// @Failure 403 {object} map[string]interface{} "Scenario prerequisites not met"
+// @Failure 410 {object} map[string]interface{} "Scenario is deprecated"
+// @Router /sessions [post]
 func (sc *SessionController) CreateSession(c *gin.Context) {
 	var request models.CreateSessionRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -60,25 +103,186 @@ func (sc *SessionController) CreateSession(c *gin.Context) {
 	defer cancel()
 
 	// Create session
-	session, err := sc.sessionService.CreateSession(ctx, request.ScenarioID)
+	session, err := sc.sessionService.CreateSession(ctx, request.ScenarioID, models.SessionOptions{
+		UserID:    middleware.AuthenticatedUser(c),
+		ClientIP:  c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		Tags:      request.Tags,
+	})
 	if err != nil {
+		var prereqErr *sessions.PrerequisitesNotMetError
+		if errors.As(err, &prereqErr) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":              "Scenario prerequisites not met",
+				"unmetPrerequisites": prereqErr.Unmet,
+			})
+			return
+		}
+
+		var deprecatedErr *sessions.ScenarioDeprecatedError
+		if errors.As(err, &deprecatedErr) {
+			c.JSON(http.StatusGone, gin.H{
+				"error":              "Scenario is deprecated",
+				"deprecationMessage": deprecatedErr.Message,
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to create session: %v", err)})
 		return
 	}
 
-	c.JSON(http.StatusCreated, models.CreateSessionResponse{
+	// A queued session starts once a cluster is available
+	status := http.StatusCreated
+	if session.Status == models.SessionStatusQueued {
+		status = http.StatusAccepted
+	}
+	c.JSON(status, models.CreateSessionResponse{
 		SessionID: session.ID,
 		Status:    string(session.Status),
 	})
 }
 
+// StreamEvents streams session events to the client as server-sent events
+//
+// @Summary Stream session events
+// @Description Server-sent events such as expiry warnings and task resets; each event is a JSON models.SessionEvent
+// @Tags sessions
+// @Produce text/event-stream
+// @Param id path string true "Session ID"
+// @Success 200 {object} models.SessionEvent
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id}/events [get]
+func (sc *SessionController) StreamEvents(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	events, unsubscribe, err := sc.sessionService.SubscribeEvents(sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Session not found: %v", err)})
+		return
+	}
+	defer unsubscribe()
+
+	// The stream outlives the server write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		sc.logger.WithError(err).WithField("sessionID", sessionID).Debug("Failed to clear write deadline for event stream")
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// Session deleted
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				sc.logger.WithError(err).WithField("sessionID", sessionID).Error("Failed to encode session event")
+				continue
+			}
+			if _, err := fmt.Fprintf(c.Writer, "data: %s\n\n", data); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// StreamProvisioningLog streams the provisioning log of a session as plain text
+//
+// @Summary Stream session provisioning log
+// @Description The last log lines recorded while the session was provisioned, followed by new lines as they are logged. The response ends when provisioning finishes.
+// @Tags sessions
+// @Produce plain
+// @Param id path string true "Session ID"
+// @Success 200 {string} string
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id}/logs [get]
+func (sc *SessionController) StreamProvisioningLog(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	lines, follow, unsubscribe, err := sc.sessionService.SubscribeProvisioningLog(sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Session not found: %v", err)})
+		return
+	}
+	defer unsubscribe()
+
+	// The stream outlives the server write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		sc.logger.WithError(err).WithField("sessionID", sessionID).Debug("Failed to clear write deadline for provisioning log")
+	}
+
+	// Without a Content-Length the response is sent chunked, one flush per line
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(c.Writer, line); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	for {
+		select {
+		case line, ok := <-follow:
+			if !ok {
+				// Provisioning finished or session deleted
+				return
+			}
+			if _, err := fmt.Fprintln(c.Writer, line); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
 // ListSessions returns a list of all active sessions
+//
+// @Summary List sessions
+// @Tags sessions
+// @Produce json
+// @Success 200 {array} models.Session
+// @Router /sessions [get]
 func (sc *SessionController) ListSessions(c *gin.Context) {
 	sessions := sc.sessionService.ListSessions()
 	c.JSON(http.StatusOK, sessions)
 }
 
 // GetSession returns details for a specific session
+//
+// @Summary Get a session
+// @Tags sessions
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} models.Session
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id} [get]
 func (sc *SessionController) GetSession(c *gin.Context) {
 	sessionID := c.Param("id")
 
@@ -105,7 +309,33 @@ func (sc *SessionController) GetSession(c *gin.Context) {
 	c.JSON(http.StatusOK, session)
 }
 
+// GetSessionView returns a session with its scenario and the details and unlocked hints of its tasks
+//
+// @Summary Get a session with its scenario and tasks
+// @Tags sessions
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} models.SessionView
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id}/view [get]
+func (sc *SessionController) GetSessionView(c *gin.Context) {
+	view, err := sc.sessionService.GetSessionView(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Session not found: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
 // DeleteSession deletes a session and its resources
+//
+// @Summary Delete a session
+// @Tags sessions
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]string
+// @Router /sessions/{id} [delete]
 func (sc *SessionController) DeleteSession(c *gin.Context) {
 	sessionID := c.Param("id")
 
@@ -123,6 +353,15 @@ func (sc *SessionController) DeleteSession(c *gin.Context) {
 }
 
 // ExtendSession extends the expiration time of a session
+//
+// @Summary Extend a session
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param request body object false "{"minutes": 30}"
+// @Success 200 {object} map[string]string
+// @Router /sessions/{id}/extend [put]
 func (sc *SessionController) ExtendSession(c *gin.Context) {
 	sessionID := c.Param("id")
 
@@ -148,124 +387,398 @@ func (sc *SessionController) ExtendSession(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Session extended successfully"})
 }
 
-// ListTasks lists the tasks for a session
-func (sc *SessionController) ListTasks(c *gin.Context) {
+// PatchSession updates the tags and notes of a session and returns the updated session
+//
+// @Summary Update session tags and notes
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param patch body models.SessionPatch true "Fields to update"
+// @Success 200 {object} models.Session
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id} [patch]
+func (sc *SessionController) PatchSession(c *gin.Context) {
 	sessionID := c.Param("id")
 
-	// Get session to access its tasks
+	var patch models.SessionPatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if _, err := sc.sessionService.GetSession(sessionID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Session not found: %v", err)})
+		return
+	}
+
+	if err := sc.sessionService.PatchSession(sessionID, patch); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to update session: %v", err)})
+		return
+	}
+
 	session, err := sc.sessionService.GetSession(sessionID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Session not found: %v", err)})
 		return
 	}
 
-	c.JSON(http.StatusOK, session.Tasks)
+	c.JSON(http.StatusOK, session)
 }
 
-// ValidateTask validates a specific task in a session
-// ValidateTask validates a specific task in a session using unified validator
-func (sc *SessionController) ValidateTask(c *gin.Context) {
+// GetKubeconfig returns the admin kubeconfig of the session cluster, pointing at the
+// control plane VM's IP
+//
+// @Summary Download the session kubeconfig
+// @Tags sessions
+// @Produce application/yaml
+// @Param id path string true "Session ID"
+// @Success 200 {string} string "Kubeconfig"
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id}/kubeconfig [get]
+func (sc *SessionController) GetKubeconfig(c *gin.Context) {
 	sessionID := c.Param("id")
-	taskID := c.Param("taskId")
-
-	sc.logger.WithFields(logrus.Fields{
-		"sessionID": sessionID,
-		"taskID":    taskID,
-	}).Info("Starting unified task validation")
 
-	// Get session
-	session, err := sc.sessionService.GetSession(sessionID)
-	if err != nil {
+	if _, err := sc.sessionService.GetSession(sessionID); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Session not found: %v", err)})
 		return
 	}
 
-	// Get task validation rules
-	task, err := sc.getTaskWithValidationRules(session.ScenarioID, taskID)
+	kubeconfig, err := sc.sessionService.GetKubeconfig(c.Request.Context(), sessionID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		sc.logger.WithError(err).WithField("sessionID", sessionID).Error("Failed to get kubeconfig")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to get kubeconfig: %v", err)})
 		return
 	}
 
-	// Check if task has validation rules
-	if len(task.Validation) == 0 {
-		// Return success for tasks without validation rules
-		response := &validation.ValidationResponse{
-			Success:   true,
-			Message:   "No validation rules defined for this task",
-			Results:   []validation.ValidationResult{},
-			Timestamp: time.Now(),
+	c.Data(http.StatusOK, "application/yaml", kubeconfig)
+}
+
+// PauseSession pauses the session VMs and stops the expiration countdown
+//
+// @Summary Pause a session
+// @Tags sessions
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /sessions/{id}/pause [put]
+func (sc *SessionController) PauseSession(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	if err := sc.sessionService.PauseSession(c.Request.Context(), sessionID); err != nil {
+		if errors.Is(err, sessions.ErrInvalidSessionState) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
 		}
-		c.JSON(http.StatusOK, response)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to pause session: %v", err)})
 		return
 	}
 
-	// Use unified validator
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 300*time.Second)
-	defer cancel()
+	c.JSON(http.StatusOK, gin.H{"message": "Session paused successfully"})
+}
+
+// ResumeSession resumes a paused session
+//
+// @Summary Resume a paused session
+// @Tags sessions
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /sessions/{id}/resume [put]
+func (sc *SessionController) ResumeSession(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	if err := sc.sessionService.ResumeSession(c.Request.Context(), sessionID); err != nil {
+		if errors.Is(err, sessions.ErrInvalidSessionState) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to resume session: %v", err)})
+		return
+	}
 
-	validationResponse, err := sc.unifiedValidator.ValidateTask(ctx, session, task.Validation)
+	c.JSON(http.StatusOK, gin.H{"message": "Session resumed successfully"})
+}
+
+// CreateSessionSnapshot starts a snapshot of the session VMs
+//
+// @Summary Snapshot the session VMs
+// @Description The VMs are stopped while the snapshot is taken. Poll the session's userSnapshotStatus for completion.
+// @Tags sessions
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 202 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /sessions/{id}/snapshot [post]
+func (sc *SessionController) CreateSessionSnapshot(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	if err := sc.sessionService.CreateSessionSnapshot(c.Request.Context(), sessionID); err != nil {
+		if errors.Is(err, sessions.ErrInvalidSessionState) || errors.Is(err, sessions.ErrUserSnapshotExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to snapshot session: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Session snapshot started"})
+}
+
+// RestoreSessionSnapshot starts restoring the session VMs from their snapshot
+//
+// @Summary Restore the session VMs from their snapshot
+// @Description Open terminals are disconnected once the restore completes.
+// @Tags sessions
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 202 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /sessions/{id}/restore-snapshot [post]
+func (sc *SessionController) RestoreSessionSnapshot(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	if err := sc.sessionService.RestoreSessionSnapshot(c.Request.Context(), sessionID); err != nil {
+		if errors.Is(err, sessions.ErrNoUserSnapshot) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, sessions.ErrInvalidSessionState) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to restore session snapshot: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Session snapshot restore started"})
+}
+
+// GetProgress returns the completion progress of a session
+//
+// @Summary Get session progress
+// @Tags sessions
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} models.SessionProgress
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id}/progress [get]
+func (sc *SessionController) GetProgress(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	progress, err := sc.sessionService.GetSessionProgress(sessionID)
 	if err != nil {
-		sc.logger.WithError(err).Error("Unified validation failed")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Validation failed: %v", err)})
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Session not found: %v", err)})
 		return
 	}
 
-	// Update session with results (simplified)
-	sc.updateSessionTaskStatus(sessionID, taskID, validationResponse)
+	c.JSON(http.StatusOK, progress)
+}
 
-	sc.logger.WithFields(logrus.Fields{
-		"sessionID": sessionID,
-		"taskID":    taskID,
-		"success":   validationResponse.Success,
-		"results":   len(validationResponse.Results),
-	}).Info("Sending validation response")
+// GetScore returns the per-task score breakdown of a session
+//
+// @Summary Get the session score breakdown
+// @Tags sessions
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} models.ScoreBreakdown
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id}/score [get]
+func (sc *SessionController) GetScore(c *gin.Context) {
+	sessionID := c.Param("id")
 
-	c.JSON(http.StatusOK, validationResponse)
+	breakdown, err := sc.sessionService.GetScoreBreakdown(sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Session not found: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, breakdown)
 }
 
-// Helper method to get task with validation rules
-func (sc *SessionController) getTaskWithValidationRules(scenarioID, taskID string) (*models.Task, error) {
-	if scenarioID == "" {
-		return nil, fmt.Errorf("session has no associated scenario")
+// GetTaskHints returns the hints unlocked by the task's validation attempts
+//
+// @Summary Get the unlocked hints of a task
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param taskId path string true "Task ID"
+// @Success 200 {object} models.TaskHints
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id}/tasks/{taskId}/hints [get]
+func (sc *SessionController) GetTaskHints(c *gin.Context) {
+	sessionID := c.Param("id")
+	taskID := c.Param("taskId")
+
+	hints, err := sc.sessionService.GetTaskHints(sessionID, taskID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
 	}
 
-	// Get scenario using the scenario service
-	scenario, err := sc.scenarioService.GetScenario(scenarioID)
+	c.JSON(http.StatusOK, hints)
+}
+
+// GetHint reveals the hint at an index of a task if the task's validation attempts unlock it
+//
+// @Summary Reveal a task hint
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param taskId path string true "Task ID"
+// @Param index path int true "Hint index, starting at 0"
+// @Success 200 {object} models.HintResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id}/tasks/{taskId}/hint/{index} [post]
+func (sc *SessionController) GetHint(c *gin.Context) {
+	sessionID := c.Param("id")
+	taskID := c.Param("taskId")
+
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Hint index must be an integer"})
+		return
+	}
+
+	hint, err := sc.sessionService.GetHint(sessionID, taskID, index)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, hint)
+}
+
+// GetTaskSolution reveals the solution of a task once its validation attempts unlock it
+//
+// @Summary Reveal the solution of a task
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param taskId path string true "Task ID"
+// @Success 200 {object} models.SolutionResponse
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id}/tasks/{taskId}/solution [get]
+func (sc *SessionController) GetTaskSolution(c *gin.Context) {
+	sessionID := c.Param("id")
+	taskID := c.Param("taskId")
+
+	solution, err := sc.sessionService.GetSolution(sessionID, taskID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load scenario %s: %w", scenarioID, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
 	}
 
-	// Find the specific task
-	for i, task := range scenario.Tasks {
-		if task.ID == taskID {
-			sc.logger.WithFields(logrus.Fields{
-				"scenarioID":      scenarioID,
-				"taskID":          taskID,
-				"validationRules": len(task.Validation),
-				"taskTitle":       task.Title,
-			}).Debug("Found task with validation rules")
+	c.JSON(http.StatusOK, solution)
+}
+
+// ResetTask sets a task back to pending so it can be attempted again
+//
+// @Summary Reset a task
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param taskId path string true "Task ID"
+// @Success 200 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /sessions/{id}/tasks/{taskId}/reset [post]
+func (sc *SessionController) ResetTask(c *gin.Context) {
+	sessionID := c.Param("id")
+	taskID := c.Param("taskId")
 
-			return &scenario.Tasks[i], nil
+	if err := sc.sessionService.ResetTask(sessionID, taskID); err != nil {
+		switch {
+		case errors.Is(err, sessions.ErrTaskResetLimit):
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		case errors.Is(err, sessions.ErrInvalidSessionState):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		}
+		return
 	}
 
-	return nil, fmt.Errorf("task %s not found in scenario %s", taskID, scenarioID)
+	c.JSON(http.StatusOK, gin.H{"message": "Task reset successfully"})
 }
 
-// Helper method to update session task status
-func (sc *SessionController) updateSessionTaskStatus(sessionID, taskID string, response *validation.ValidationResponse) {
-	status := "failed"
-	if response.Success {
-		status = "completed"
+// ListTasks lists the tasks for a session
+//
+// @Summary List the tasks of a session
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {array} models.TaskStatus
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id}/tasks [get]
+func (sc *SessionController) ListTasks(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	// Get session to access its tasks
+	session, err := sc.sessionService.GetSession(sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Session not found: %v", err)})
+		return
 	}
 
-	// Use existing session service to update task status
-	err := sc.sessionService.UpdateTaskStatus(sessionID, taskID, status)
+	c.JSON(http.StatusOK, session.Tasks)
+}
+
+// ValidateTask validates a specific task in a session and records the result on the task
+//
+// @Summary Validate a task
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param taskId path string true "Task ID"
+// @Success 200 {object} validation.ValidationResponse
+// @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /sessions/{id}/tasks/{taskId}/validate [post]
+func (sc *SessionController) ValidateTask(c *gin.Context) {
+	sessionID := c.Param("id")
+	taskID := c.Param("taskId")
+
+	sc.logger.WithFields(logrus.Fields{
+		"sessionID": sessionID,
+		"taskID":    taskID,
+	}).Info("Starting unified task validation")
+
+	if _, err := sc.sessionService.GetSession(sessionID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Session not found: %v", err)})
+		return
+	}
+
+	// The request context is bounded by ValidationRouteTimeout on the validation routes
+	ctx := c.Request.Context()
+
+	// The session manager takes a validation slot, counts the attempt and stores the result
+	validationResponse, err := sc.sessionService.ValidateTask(ctx, sessionID, taskID)
 	if err != nil {
-		sc.logger.WithError(err).WithFields(logrus.Fields{
-			"sessionID": sessionID,
-			"taskID":    taskID,
-		}).Error("Failed to update task status")
+		switch {
+		case errors.Is(err, sessions.ErrValidationQueueFull):
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		case errors.Is(err, sessions.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": fmt.Sprintf("Validation cancelled: %v", err)})
+		default:
+			sc.logger.WithError(err).Error("Unified validation failed")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Validation failed: %v", err)})
+		}
+		return
 	}
+
+	sc.logger.WithFields(logrus.Fields{
+		"sessionID": sessionID,
+		"taskID":    taskID,
+		"success":   validationResponse.Success,
+		"results":   len(validationResponse.Results),
+	}).Info("Sending validation response")
+
+	c.JSON(http.StatusOK, validationResponse)
 }
@@ -5,38 +5,57 @@ package controllers
 import (
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 
+	"github.com/fullstack-pw/cks/backend/internal/audit"
+	"github.com/fullstack-pw/cks/backend/internal/middleware"
 	"github.com/fullstack-pw/cks/backend/internal/models"
 	"github.com/fullstack-pw/cks/backend/internal/services"
+	"github.com/fullstack-pw/cks/backend/internal/terminal"
 )
 
 // TerminalController handles HTTP requests related to terminal sessions
 type TerminalController struct {
 	terminalService services.TerminalService
 	sessionService  services.SessionService
+	adminSessions   *middleware.AdminSessions
+	auditLogger     audit.AuditLogger
 	logger          *logrus.Logger
+
+	// Open WebSockets per terminal ID, limited to maxConnections
+	maxConnections      int
+	terminalConnections map[string]int
+	connectionsLock     sync.Mutex
 }
 
 // NewTerminalController creates a new terminal controller
 func NewTerminalController(
 	terminalService services.TerminalService,
 	sessionService services.SessionService,
+	adminSessions *middleware.AdminSessions,
+	auditLogger audit.AuditLogger,
+	maxConnections int,
 	logger *logrus.Logger,
 ) *TerminalController {
 	return &TerminalController{
-		terminalService: terminalService,
-		sessionService:  sessionService,
-		logger:          logger,
+		terminalService:     terminalService,
+		sessionService:      sessionService,
+		adminSessions:       adminSessions,
+		auditLogger:         auditLogger,
+		logger:              logger,
+		maxConnections:      maxConnections,
+		terminalConnections: make(map[string]int),
 	}
 }
 
 // RegisterRoutes registers terminal-related routes
-func (tc *TerminalController) RegisterRoutes(router *gin.Engine) {
+func (tc *TerminalController) RegisterRoutes(router gin.IRouter) {
 	// Terminal routes
 	router.POST("/api/v1/sessions/:id/terminals", tc.CreateTerminal)
+	router.GET("/api/v1/sessions/:id/terminals", tc.ListTerminals)
 
 	terminals := router.Group("/api/v1/terminals")
 	{
@@ -46,7 +65,131 @@ func (tc *TerminalController) RegisterRoutes(router *gin.Engine) {
 	}
 }
 
+// RegisterAdminRoutes registers the terminal history route, which requires the admin token
+func (tc *TerminalController) RegisterAdminRoutes(router gin.IRouter) {
+	router.GET("/api/v1/sessions/:id/terminals/:terminalId/history", middleware.AdminAuth(tc.adminSessions), auditedHandler(tc.auditLogger, "get_terminal_history", "terminal", tc.GetTerminalHistory))
+	router.GET("/api/v1/admin/terminals/metrics", middleware.AdminAuth(tc.adminSessions), auditedHandler(tc.auditLogger, "get_terminal_metrics", "terminal", tc.GetTerminalMetrics))
+	router.DELETE("/api/v1/terminals/:id/history", middleware.AdminAuth(tc.adminSessions), auditedHandler(tc.auditLogger, "clear_terminal_history", "terminal", tc.ClearTerminalHistory))
+}
+
+// GetTerminalMetrics returns terminal usage statistics
+//
+// @Summary Get terminal usage statistics
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} terminal.TerminalMetrics
+// @Router /admin/terminals/metrics [get]
+func (tc *TerminalController) GetTerminalMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, tc.terminalService.GetMetrics())
+}
+
+// GetTerminalHistory returns the redacted commands entered in a terminal, oldest first
+//
+// @Summary Get the command history of a terminal
+// @Tags terminals
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param terminalId path string true "Terminal ID"
+// @Security AdminToken
+// @Success 200 {object} map[string][]string
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id}/terminals/{terminalId}/history [get]
+func (tc *TerminalController) GetTerminalHistory(c *gin.Context) {
+	sessionID := c.Param("id")
+	terminalID := c.Param("terminalId")
+
+	session, err := tc.sessionService.GetSession(sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Session not found: %v", err)})
+		return
+	}
+
+	terminalInfo, exists := session.ActiveTerminals[terminalID]
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Terminal not found: %s", terminalID)})
+		return
+	}
+
+	terminalInfo.CommandHistory = tc.terminalService.CommandHistory(sessionID, terminalInfo.Target)
+
+	c.JSON(http.StatusOK, gin.H{"commands": terminalInfo.CommandHistory})
+}
+
+// ClearTerminalHistory discards the commands recorded for a terminal
+//
+// @Summary Clear the command history of a terminal
+// @Tags terminals
+// @Param id path string true "Terminal ID"
+// @Security AdminToken
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Router /terminals/{id}/history [delete]
+func (tc *TerminalController) ClearTerminalHistory(c *gin.Context) {
+	terminalID := c.Param("id")
+
+	sessionID := ""
+	for _, session := range tc.sessionService.ListSessions() {
+		if _, exists := session.ActiveTerminals[terminalID]; exists {
+			sessionID = session.ID
+			break
+		}
+	}
+	if sessionID == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Terminal not found: %s", terminalID)})
+		return
+	}
+
+	if err := tc.terminalService.ClearTerminalHistory(sessionID, terminalID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	tc.logger.WithFields(logrus.Fields{
+		"sessionID":  sessionID,
+		"terminalID": terminalID,
+	}).Info("Terminal command history cleared")
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListTerminals lists the terminals of a session with their attached connection counts
+//
+// @Summary List the terminals of a session
+// @Tags terminals
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {array} models.TerminalInfo
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id}/terminals [get]
+func (tc *TerminalController) ListTerminals(c *gin.Context) {
+	sessionID := c.Param("id")
+
+	terminals, err := tc.sessionService.ListTerminals(sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Session not found: %v", err)})
+		return
+	}
+
+	for i := range terminals {
+		terminals[i].ActiveConns = tc.terminalService.ActiveConnections(sessionID, terminals[i].Target)
+	}
+
+	c.JSON(http.StatusOK, terminals)
+}
+
 // CreateTerminal creates a new terminal session or reuses existing one
+//
+// @Summary Create a terminal
+// @Tags terminals
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param request body models.CreateTerminalRequest true "Target VM"
+// @Param terminal_type query string false "ssh (default) or console; overrides the request's type"
+// @Success 200 {object} models.CreateTerminalResponse
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{id}/terminals [post]
 func (tc *TerminalController) CreateTerminal(c *gin.Context) {
 	sessionID := c.Param("id")
 
@@ -65,8 +208,22 @@ func (tc *TerminalController) CreateTerminal(c *gin.Context) {
 		return
 	}
 
-	// Check if session is in running state
-	if session.Status != models.SessionStatusRunning {
+	terminalType := request.Type
+	if queryType := c.Query("terminal_type"); queryType != "" {
+		terminalType = queryType
+	}
+	if terminalType == "" {
+		terminalType = terminal.TerminalTypeSSH
+	}
+	if terminalType != terminal.TerminalTypeSSH && terminalType != terminal.TerminalTypeConsole {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid terminal type: %s", terminalType)})
+		return
+	}
+
+	// Check if session is in running state; the serial console also works while provisioning
+	ready := session.Status == models.SessionStatusRunning ||
+		(terminalType == terminal.TerminalTypeConsole && session.Status == models.SessionStatusProvisioning)
+	if !ready {
 		tc.logger.WithFields(logrus.Fields{
 			"sessionID": sessionID,
 			"status":    session.Status,
@@ -91,7 +248,7 @@ func (tc *TerminalController) CreateTerminal(c *gin.Context) {
 	}
 
 	// Always create or get terminal session
-	terminalID, err := tc.terminalService.CreateSession(sessionID, session.Namespace, targetVM)
+	terminalID, err := tc.terminalService.CreateSession(sessionID, session.Namespace, targetVM, terminalType)
 	if err != nil {
 		tc.logger.WithError(err).Error("Failed to create terminal session")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to create terminal: %v", err)})
@@ -109,17 +266,41 @@ func (tc *TerminalController) CreateTerminal(c *gin.Context) {
 		"sessionID":  sessionID,
 		"terminalID": terminalID,
 		"target":     request.Target,
+		"type":       terminalType,
 	}).Info("Terminal session created/retrieved")
 
 	c.JSON(http.StatusOK, models.CreateTerminalResponse{
 		TerminalID: terminalID,
+		Type:       terminalType,
 	})
 }
 
 // AttachTerminal handles WebSocket connection to a terminal
+//
+// @Summary Attach to a terminal over WebSocket
+// @Tags terminals
+// @Param id path string true "Terminal ID"
+// @Param type query string false "console to attach to the serial console"
+// @Param mode query string false "observe to attach read-only"
+// @Success 101
+// @Failure 429 {object} map[string]string
+// @Router /terminals/{id}/attach [get]
 func (tc *TerminalController) AttachTerminal(c *gin.Context) {
 	terminalID := c.Param("id")
 
+	if !tc.acquireConnection(terminalID) {
+		tc.logger.WithFields(logrus.Fields{
+			"terminalID":     terminalID,
+			"maxConnections": tc.maxConnections,
+			"clientIP":       c.ClientIP(),
+		}).Info("Rejected terminal connection over the per-terminal limit")
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": fmt.Sprintf("Terminal already has %d connections", tc.maxConnections),
+		})
+		return
+	}
+	defer tc.releaseConnection(terminalID)
+
 	tc.logger.WithField("terminalID", terminalID).Info("Attaching to terminal session")
 
 	// Add CORS headers for WebSocket connections
@@ -132,7 +313,40 @@ func (tc *TerminalController) AttachTerminal(c *gin.Context) {
 	tc.terminalService.HandleTerminal(c.Writer, c.Request, terminalID)
 }
 
+// acquireConnection counts a new WebSocket for a terminal, reporting false if the terminal
+// already has maxConnections; a limit of 0 or less disables the check
+func (tc *TerminalController) acquireConnection(terminalID string) bool {
+	tc.connectionsLock.Lock()
+	defer tc.connectionsLock.Unlock()
+
+	if tc.maxConnections > 0 && tc.terminalConnections[terminalID] >= tc.maxConnections {
+		return false
+	}
+	tc.terminalConnections[terminalID]++
+	return true
+}
+
+// releaseConnection uncounts a WebSocket counted by acquireConnection
+func (tc *TerminalController) releaseConnection(terminalID string) {
+	tc.connectionsLock.Lock()
+	defer tc.connectionsLock.Unlock()
+
+	tc.terminalConnections[terminalID]--
+	if tc.terminalConnections[terminalID] <= 0 {
+		delete(tc.terminalConnections, terminalID)
+	}
+}
+
 // ResizeTerminal handles terminal resize events
+//
+// @Summary Resize a terminal
+// @Tags terminals
+// @Accept json
+// @Produce json
+// @Param id path string true "Terminal ID"
+// @Param request body models.ResizeTerminalRequest true "Terminal size"
+// @Success 200 {object} map[string]string
+// @Router /terminals/{id}/resize [post]
 func (tc *TerminalController) ResizeTerminal(c *gin.Context) {
 	terminalID := c.Param("id")
 
@@ -172,6 +386,13 @@ func (tc *TerminalController) ResizeTerminal(c *gin.Context) {
 }
 
 // CloseTerminal closes a terminal session
+//
+// @Summary Close a terminal
+// @Tags terminals
+// @Produce json
+// @Param id path string true "Terminal ID"
+// @Success 200 {object} map[string]string
+// @Router /terminals/{id} [delete]
 func (tc *TerminalController) CloseTerminal(c *gin.Context) {
 	terminalID := c.Param("id")
 
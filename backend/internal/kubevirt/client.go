@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -23,9 +24,14 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"kubevirt.io/client-go/kubecli"
+	kvcorev1 "kubevirt.io/client-go/kubevirt/typed/core/v1"
 
 	"github.com/fullstack-pw/cks/backend/internal/config"
+	"github.com/fullstack-pw/cks/backend/internal/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
+	kubevirtv1 "kubevirt.io/api/core/v1"
 	snapshotv1beta1 "kubevirt.io/api/snapshot/v1beta1"
 )
 
@@ -36,6 +42,7 @@ type Client struct {
 	config        *config.Config
 	restConfig    *rest.Config
 	templateCache map[string]*template.Template
+	sshMasters    *SSHMasterManager
 	logger        *logrus.Logger
 }
 
@@ -169,6 +176,7 @@ func NewClient(restConfig *rest.Config, logger *logrus.Logger) (*Client, error)
 		config:        cfg,
 		restConfig:    restConfig,
 		templateCache: templateCache,
+		sshMasters:    NewSSHMasterManager("suporte", logger),
 		logger:        logger,
 	}, nil
 }
@@ -202,7 +210,20 @@ func (c *Client) validateGoldenImage(ctx context.Context) error {
 	return nil
 }
 
-func (c *Client) CreateCluster(ctx context.Context, namespace, controlPlaneName, workerNodeName string) error {
+// CloudInitOptions customizes the cluster created from the cloud-init templates
+type CloudInitOptions struct {
+	K8sVersion string // Kubernetes version to install; empty means the configured default
+}
+
+// k8sVersion returns the Kubernetes version to install, falling back to defaultVersion
+func (o CloudInitOptions) k8sVersion(defaultVersion string) string {
+	if o.K8sVersion != "" {
+		return o.K8sVersion
+	}
+	return defaultVersion
+}
+
+func (c *Client) CreateCluster(ctx context.Context, namespace, controlPlaneName, workerNodeName string, opts CloudInitOptions) error {
 	// Validate golden image exists before proceeding
 	err := c.validateGoldenImage(ctx)
 	if err != nil {
@@ -213,27 +234,90 @@ func (c *Client) CreateCluster(ctx context.Context, namespace, controlPlaneName,
 		"namespace":    namespace,
 		"controlPlane": controlPlaneName,
 		"workerNode":   workerNodeName,
+		"k8sVersion":   opts.k8sVersion(c.config.KubernetesVersion),
 	}).Info("Starting VM cluster creation with enhanced error handling")
 
 	// Step 1: Create control plane cloud-init secret with retry
 	err = c.retryOperation(ctx, "create-control-plane-secret", func() error {
-		return c.createCloudInitSecret(ctx, namespace, controlPlaneName, "control-plane")
+		return c.createCloudInitSecret(ctx, namespace, controlPlaneName, "control-plane", opts)
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create control plane cloud-init secret: %w", err)
 	}
 	c.logger.Info("Control plane cloud-init secret created successfully")
 
-	// Step 2: Create control plane VM with retry
-	err = c.retryOperation(ctx, "create-control-plane-vm", func() error {
-		return c.createVM(ctx, namespace, controlPlaneName, "control-plane")
+	// Step 2: Create both VMs concurrently, so that their disks are cloned in parallel. The
+	// worker VM does not boot until its cloud-init secret exists, which needs the join command
+	// of the ready control plane.
+	var (
+		joinCommand    string
+		controlPlaneIP string
+		workerTemplate *cloudInitTemplate
+	)
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var err error
+		joinCommand, controlPlaneIP, err = c.provisionControlPlane(gctx, namespace, controlPlaneName, opts)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		if workerTemplate, err = c.prepareCloudInitTemplate(namespace, "worker", opts); err != nil {
+			return fmt.Errorf("failed to prepare worker node cloud-init: %w", err)
+		}
+		err = c.retryOperation(gctx, "create-worker-vm", func() error {
+			return c.createVM(gctx, namespace, workerNodeName, "worker", opts)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create worker node VM: %w", err)
+		}
+		c.logger.Info("Worker node VM created, waiting for the control plane join command")
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		// Either VM may have been created before the other step failed
+		c.cleanupFailedCluster(ctx, namespace, controlPlaneName, workerNodeName)
+		return err
+	}
+
+	// Step 3: Create worker node cloud-init secret with join command, which lets the worker boot
+	err = c.retryOperation(ctx, "create-worker-secret", func() error {
+		return c.applyCloudInitSecret(ctx, workerTemplate, map[string]string{
+			"JOIN_COMMAND":           joinCommand,
+			"JOIN":                   joinCommand,
+			"CONTROL_PLANE_ENDPOINT": fmt.Sprintf("%s.%s.pod.cluster.local", strings.ReplaceAll(controlPlaneIP, ".", "-"), namespace),
+			"CONTROL_PLANE_IP":       controlPlaneIP,
+			"CONTROL_PLANE_VM_NAME":  controlPlaneName,
+		})
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create control plane VM: %w", err)
+		c.cleanupFailedCluster(ctx, namespace, controlPlaneName, workerNodeName)
+		return fmt.Errorf("failed to create worker node cloud-init secret: %w", err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"namespace":    namespace,
+		"controlPlane": controlPlaneName,
+		"workerNode":   workerNodeName,
+	}).Info("VM cluster creation completed successfully")
+
+	return nil
+}
+
+// provisionControlPlane creates the control plane VM, waits for it to be ready and
+// returns the join command and IP address needed by the worker node
+func (c *Client) provisionControlPlane(ctx context.Context, namespace, controlPlaneName string, opts CloudInitOptions) (string, string, error) {
+	// Create control plane VM with retry
+	err := c.retryOperation(ctx, "create-control-plane-vm", func() error {
+		return c.createVM(ctx, namespace, controlPlaneName, "control-plane", opts)
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create control plane VM: %w", err)
 	}
 	c.logger.Info("Control plane VM created successfully")
 
-	// Step 3: Wait for control plane to be ready with timeout
+	// Wait for control plane to be ready with timeout
 	controlPlaneCtx, cancelCP := context.WithTimeout(ctx, VMReadyTimeout)
 	defer cancelCP()
 
@@ -244,11 +328,11 @@ func (c *Client) CreateCluster(ctx context.Context, namespace, controlPlaneName,
 		if cleanupErr != nil {
 			c.logger.WithError(cleanupErr).Error("Failed to cleanup control plane VM after creation failure")
 		}
-		return fmt.Errorf("control plane VM failed to become ready: %w", err)
+		return "", "", fmt.Errorf("control plane VM failed to become ready: %w", err)
 	}
 	c.logger.Info("Control plane VM is ready")
 
-	// Step 4: Get join command with retry
+	// Get join command with retry
 	var joinCommand string
 	err = c.retryOperation(ctx, "get-join-command", func() error {
 		var cmdErr error
@@ -256,38 +340,24 @@ func (c *Client) CreateCluster(ctx context.Context, namespace, controlPlaneName,
 		return cmdErr
 	})
 	if err != nil {
-		return fmt.Errorf("failed to get join command: %w", err)
+		return "", "", fmt.Errorf("failed to get join command: %w", err)
 	}
 
-	// Step 5: Create worker node cloud-init secret with join command
-	err = c.retryOperation(ctx, "create-worker-secret", func() error {
-		return c.createCloudInitSecret(ctx, namespace, workerNodeName, "worker", map[string]string{
-			"JOIN_COMMAND":           joinCommand,
-			"JOIN":                   joinCommand,
-			"CONTROL_PLANE_ENDPOINT": fmt.Sprintf("%s.%s.pod.cluster.local", strings.ReplaceAll(c.getVMIP(ctx, namespace, controlPlaneName), ".", "-"), namespace),
-			"CONTROL_PLANE_IP":       c.getVMIP(ctx, namespace, controlPlaneName),
-			"CONTROL_PLANE_VM_NAME":  controlPlaneName,
-		})
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create worker node cloud-init secret: %w", err)
-	}
+	return joinCommand, c.getVMIP(ctx, namespace, controlPlaneName), nil
+}
 
-	// Step 6: Create worker node VM with retry
-	err = c.retryOperation(ctx, "create-worker-vm", func() error {
-		return c.createVM(ctx, namespace, workerNodeName, "worker")
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create worker node VM: %w", err)
+// cleanupFailedCluster deletes the VMs of a cluster whose creation failed, logging the VMs
+// that could not be deleted. The cleanup runs even if ctx was cancelled by the failure.
+func (c *Client) cleanupFailedCluster(ctx context.Context, namespace string, vmNames ...string) {
+	ctx = context.WithoutCancel(ctx)
+	for _, vmName := range vmNames {
+		if err := c.cleanupFailedVM(ctx, namespace, vmName); err != nil {
+			c.logger.WithError(err).WithFields(logrus.Fields{
+				"namespace": namespace,
+				"vmName":    vmName,
+			}).Error("Failed to cleanup VM after cluster creation failure")
+		}
 	}
-
-	c.logger.WithFields(logrus.Fields{
-		"namespace":    namespace,
-		"controlPlane": controlPlaneName,
-		"workerNode":   workerNodeName,
-	}).Info("VM cluster creation completed successfully")
-
-	return nil
 }
 
 // cleanupFailedVM cleans up a failed VM and its resources
@@ -330,7 +400,31 @@ func (c *Client) cleanupFailedVM(ctx context.Context, namespace, vmName string)
 	return nil
 }
 
-func (c *Client) createCloudInitSecret(ctx context.Context, namespace, vmName, vmType string, extraVars ...map[string]string) error {
+// cloudInitTemplate holds a cloud-init config rendered with the base variables,
+// ready to be completed with late-bound values such as the join command
+type cloudInitTemplate struct {
+	vmType string
+	config string
+	secret string
+	data   map[string]string
+}
+
+func (c *Client) createCloudInitSecret(ctx context.Context, namespace, vmName, vmType string, opts CloudInitOptions, extraVars ...map[string]string) error {
+	tmpl, err := c.prepareCloudInitTemplate(namespace, vmType, opts)
+	if err != nil {
+		return err
+	}
+
+	var vars map[string]string
+	if len(extraVars) > 0 {
+		vars = extraVars[0]
+	}
+
+	return c.applyCloudInitSecret(ctx, tmpl, vars)
+}
+
+// prepareCloudInitTemplate reads the cloud-init templates for a VM type and substitutes the base variables
+func (c *Client) prepareCloudInitTemplate(namespace, vmType string, opts CloudInitOptions) (*cloudInitTemplate, error) {
 	// Load cloud-init template
 	var templateName string
 	if vmType == "control-plane" {
@@ -344,30 +438,17 @@ func (c *Client) createCloudInitSecret(ctx context.Context, namespace, vmName, v
 		"CONTROL_PLANE_VM_NAME": fmt.Sprintf("cp-%s", namespace),
 		"WORKER_VM_NAME":        fmt.Sprintf("wk-%s", namespace),
 		"SESSION_NAMESPACE":     namespace,
-		"SESSION_ID":            strings.TrimPrefix(namespace, "cluster"),
-		"K8S_VERSION":           c.config.KubernetesVersion,
+		"SESSION_ID":            strings.TrimPrefix(namespace, c.config.ClusterPoolNamespacePrefix),
+		"K8S_VERSION":           opts.k8sVersion(c.config.KubernetesVersion),
 		"POD_CIDR":              c.config.PodCIDR,
 	}
 
-	// Add extra variables if provided
-	if len(extraVars) > 0 {
-		for k, v := range extraVars[0] {
-			data[k] = v
-		}
-	}
-
 	// Read template file
 	templateContent, err := os.ReadFile(filepath.Join(c.config.TemplatePath, templateName))
 	if err != nil {
-		return fmt.Errorf("failed to read template file: %w", err)
+		return nil, fmt.Errorf("failed to read template file: %w", err)
 	}
 
-	// Substitute environment variables
-	renderedConfig := substituteEnvVars(string(templateContent), data)
-
-	// Properly encode cloud-init data in base64
-	encodedConfig := base64Encode(renderedConfig)
-
 	// Create secret
 	var secretTemplate string
 	if vmType == "control-plane" {
@@ -376,27 +457,53 @@ func (c *Client) createCloudInitSecret(ctx context.Context, namespace, vmName, v
 		secretTemplate = "worker-node-cloud-config-secret.yaml"
 	}
 
+	// Read the secret template file
+	secretContent, err := os.ReadFile(filepath.Join(c.config.TemplatePath, secretTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret template file: %w", err)
+	}
+
+	return &cloudInitTemplate{
+		vmType: vmType,
+		config: string(templateContent),
+		secret: string(secretContent),
+		data:   data,
+	}, nil
+}
+
+// applyCloudInitSecret renders the cloud-init secret with the extra variables and applies it
+func (c *Client) applyCloudInitSecret(ctx context.Context, tmpl *cloudInitTemplate, extraVars map[string]string) error {
+	data := make(map[string]string, len(tmpl.data)+len(extraVars))
+	for k, v := range tmpl.data {
+		data[k] = v
+	}
+
+	// Add extra variables if provided
+	for k, v := range extraVars {
+		data[k] = v
+	}
+
+	// Substitute environment variables
+	renderedConfig := substituteEnvVars(tmpl.config, data)
+
+	// Properly encode cloud-init data in base64
+	encodedConfig := base64Encode(renderedConfig)
+
 	// Set userdata in template data
-	if vmType == "control-plane" {
+	if tmpl.vmType == "control-plane" {
 		data["CONTROL_PLANE_USERDATA"] = encodedConfig
 	} else {
 		data["WORKER_USERDATA"] = encodedConfig
 	}
 
-	// Read the secret template file
-	secretContent, err := os.ReadFile(filepath.Join(c.config.TemplatePath, secretTemplate))
-	if err != nil {
-		return fmt.Errorf("failed to read secret template file: %w", err)
-	}
-
 	// Substitute variables in the secret template
-	renderedSecret := substituteEnvVars(string(secretContent), data)
+	renderedSecret := substituteEnvVars(tmpl.secret, data)
 
 	// Apply secret using kubectl
 	return applyYAML(ctx, renderedSecret)
 }
 
-func (c *Client) createVM(ctx context.Context, namespace, vmName, vmType string) error {
+func (c *Client) createVM(ctx context.Context, namespace, vmName, vmType string, opts CloudInitOptions) error {
 	// Load VM template
 	var templateName string
 	if vmType == "control-plane" {
@@ -411,7 +518,7 @@ func (c *Client) createVM(ctx context.Context, namespace, vmName, vmType string)
 		"WORKER_VM_NAME":         fmt.Sprintf("wk-%s", namespace),
 		"SESSION_NAMESPACE":      namespace,
 		"SESSION_ID":             namespace,
-		"K8S_VERSION":            c.config.KubernetesVersion,
+		"K8S_VERSION":            opts.k8sVersion(c.config.KubernetesVersion),
 		"CPU_CORES":              c.config.VMCPUCores,
 		"MEMORY":                 c.config.VMMemory,
 		"STORAGE_SIZE":           c.config.VMStorageSize,
@@ -573,12 +680,16 @@ func (c *Client) getJoinCommand(ctx context.Context, namespace, controlPlaneName
 	c.logger.WithField("actualVMName", actualVMName).Info("Adjusted VM name for join command")
 
 	// Wait for the VM to be fully ready with kubelet initialized
-	time.Sleep(60 * time.Second)
+	select {
+	case <-time.After(60 * time.Second):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
 
 	// Simple direct attempt without polling first
 	c.logger.Info("Attempting direct join command retrieval...")
 
-	cmd := exec.Command(
+	cmd := exec.CommandContext(ctx,
 		"virtctl", "ssh",
 		fmt.Sprintf("vmi/%s", actualVMName),
 		"-n", namespace,
@@ -611,6 +722,16 @@ func (c *Client) getJoinCommand(ctx context.Context, namespace, controlPlaneName
 	return joinCommand, nil
 }
 
+// GetVMIP returns the IP address of a VM, waiting up to two minutes for it to be assigned.
+// It returns an empty string when the VM has no IP.
+func (c *Client) GetVMIP(ctx context.Context, namespace, vmName string) string {
+	ip := c.getVMIP(ctx, namespace, vmName)
+	if ip == "0.0.0.0" {
+		return ""
+	}
+	return ip
+}
+
 // getVMIP gets the IP address of a VM
 func (c *Client) getVMIP(ctx context.Context, namespace, vmName string) string {
 	var ip string
@@ -669,8 +790,18 @@ func (c *Client) DeleteVMs(ctx context.Context, namespace string, vmNames ...str
 	return nil
 }
 
-// Update the method signature to include retry parameter
+// ExecuteCommandInVM runs a command in a VM over SSH, retrying failures unless retry is false
 func (c *Client) ExecuteCommandInVM(ctx context.Context, namespace, vmName, command string, retry ...bool) (string, error) {
+	ctx, span := tracing.Start(ctx, "kubevirt.ExecuteCommandInVM",
+		attribute.String("namespace", namespace),
+		attribute.String("vm.name", vmName),
+	)
+	output, err := c.executeCommandInVM(ctx, namespace, vmName, command, retry...)
+	tracing.End(span, err)
+	return output, err
+}
+
+func (c *Client) executeCommandInVM(ctx context.Context, namespace, vmName, command string, retry ...bool) (string, error) {
 	// Default retry to true for backward compatibility
 	shouldRetry := true
 	if len(retry) > 0 {
@@ -690,23 +821,54 @@ func (c *Client) ExecuteCommandInVM(ctx context.Context, namespace, vmName, comm
 		var output string
 		err := c.retryOperation(ctx, fmt.Sprintf("ssh-execute-%s", vmName), func() error {
 			var cmdErr error
-			output, cmdErr = c.executeCommandDirect(ctx, namespace, vmName, command)
+			output, cmdErr = c.executeCommandDirect(ctx, namespace, vmName, command, nil)
 			return cmdErr
 		})
 		return output, err
 	} else {
 		// Execute directly without retry
-		return c.executeCommandDirect(ctx, namespace, vmName, command)
+		return c.executeCommandDirect(ctx, namespace, vmName, command, nil)
 	}
 }
 
-// Extract the actual command execution logic into a separate method
-func (c *Client) executeCommandDirect(ctx context.Context, namespace, vmName, command string) (string, error) {
-	// Move the existing command execution logic here
-	args := c.buildVirtctlSSHArgs(namespace, vmName, "suporte", command)
+// ExecuteCommandInVMWithInput runs a non-interactive command in a VM over SSH with stdin
+// piped to the command, e.g. a certificate for "openssl verify -CAfile ca.crt -". It is
+// not retried, since stdin cannot be replayed.
+func (c *Client) ExecuteCommandInVMWithInput(ctx context.Context, namespace, vmName, command string, stdin io.Reader) (string, error) {
+	ctx, span := tracing.Start(ctx, "kubevirt.ExecuteCommandInVMWithInput",
+		attribute.String("namespace", namespace),
+		attribute.String("vm.name", vmName),
+	)
 
+	c.logger.WithFields(logrus.Fields{
+		"vmName":    vmName,
+		"namespace": namespace,
+		"command":   command,
+	}).Debug("Executing command with input in VM")
+
+	output, err := c.executeCommandDirect(ctx, namespace, vmName, command, stdin)
+	tracing.End(span, err)
+	return output, err
+}
+
+// executeCommandDirect runs a command over the VM's SSH master connection when one is open
+// and answers, and otherwise over a fresh connection while a master is started for later commands.
+// stdin, when not nil, is piped to the command.
+func (c *Client) executeCommandDirect(ctx context.Context, namespace, vmName, command string, stdin io.Reader) (string, error) {
+	args := c.buildVirtctlSSHArgs(namespace, vmName, "suporte", "")
+	if c.sshMasters.UsableMaster(ctx, namespace, vmName) {
+		args = append(args, c.sshMasters.ClientSSHOpts(namespace, vmName)...)
+	} else {
+		c.sshMasters.EnsureMaster(namespace, vmName, args)
+	}
+	args = append(args, "--command="+command)
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
 	cmd := exec.CommandContext(ctx, "virtctl", args...)
 	var stdout, stderr bytes.Buffer
+	cmd.Stdin = stdin
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
@@ -789,6 +951,10 @@ func base64Encode(input string) string {
 
 // applyYAML applies YAML to the cluster
 func applyYAML(ctx context.Context, yaml string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Create a kubectl apply command with stdin for the YAML content
 	cmd := exec.CommandContext(ctx, "kubectl", "apply", "-f", "-")
 
@@ -837,6 +1003,54 @@ func (c *Client) GetVMStatus(ctx context.Context, namespace, vmName string) (str
 	return "Pending", nil
 }
 
+// VMInfo summarizes a VM and its running instance
+type VMInfo struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	Status    string    `json:"status"`
+	Ready     bool      `json:"ready"`
+	IP        string    `json:"ip,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ListVMs lists the VMs of a namespace, or of all namespaces when namespace is empty,
+// optionally filtered by a label selector
+func (c *Client) ListVMs(ctx context.Context, namespace string, labelSelector string) ([]*VMInfo, error) {
+	opts := metav1.ListOptions{LabelSelector: labelSelector}
+
+	vms, err := c.virtClient.VirtualMachine(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VMs in namespace %q: %w", namespace, err)
+	}
+
+	// VMIs carry the IPs and are named after their VM
+	ips := make(map[string]string)
+	vmis, err := c.virtClient.VirtualMachineInstance(namespace).List(ctx, opts)
+	if err != nil {
+		c.logger.WithError(err).WithField("namespace", namespace).Warn("Failed to list VM instances, VM IPs unavailable")
+	} else {
+		for _, vmi := range vmis.Items {
+			if len(vmi.Status.Interfaces) > 0 {
+				ips[vmi.Namespace+"/"+vmi.Name] = vmi.Status.Interfaces[0].IP
+			}
+		}
+	}
+
+	infos := make([]*VMInfo, 0, len(vms.Items))
+	for _, vm := range vms.Items {
+		infos = append(infos, &VMInfo{
+			Name:      vm.Name,
+			Namespace: vm.Namespace,
+			Status:    string(vm.Status.PrintableStatus),
+			Ready:     vm.Status.Ready,
+			IP:        ips[vm.Namespace+"/"+vm.Name],
+			CreatedAt: vm.CreationTimestamp.Time,
+		})
+	}
+
+	return infos, nil
+}
+
 // CreateVMSnapshot creates a snapshot of a virtual machine
 func (c *Client) CreateVMSnapshot(ctx context.Context, namespace, vmName, snapshotName string) error {
 	c.logger.WithFields(logrus.Fields{
@@ -941,6 +1155,38 @@ func (c *Client) CheckSnapshotExists(ctx context.Context, namespace, snapshotNam
 	return snapshot.Status != nil && snapshot.Status.ReadyToUse != nil && *snapshot.Status.ReadyToUse
 }
 
+// SnapshotInfo summarizes a VM snapshot
+type SnapshotInfo struct {
+	Name       string    `json:"name"`
+	VMName     string    `json:"vmName"`
+	Phase      string    `json:"phase"`
+	ReadyToUse bool      `json:"readyToUse"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// GetSnapshotInfo returns a summary of a VM snapshot, or nil when it does not exist
+func (c *Client) GetSnapshotInfo(ctx context.Context, namespace, snapshotName string) (*SnapshotInfo, error) {
+	snapshot, err := c.virtClient.VirtualMachineSnapshot(namespace).Get(ctx, snapshotName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get snapshot %s: %w", snapshotName, err)
+	}
+
+	info := &SnapshotInfo{
+		Name:      snapshot.Name,
+		VMName:    snapshot.Spec.Source.Name,
+		CreatedAt: snapshot.CreationTimestamp.Time,
+	}
+	if snapshot.Status != nil {
+		info.Phase = string(snapshot.Status.Phase)
+		info.ReadyToUse = snapshot.Status.ReadyToUse != nil && *snapshot.Status.ReadyToUse
+	}
+
+	return info, nil
+}
+
 // DeleteVMSnapshot deletes a VM snapshot
 func (c *Client) DeleteVMSnapshot(ctx context.Context, namespace, snapshotName string) error {
 	c.logger.WithFields(logrus.Fields{
@@ -980,6 +1226,51 @@ func (c *Client) StartVM(ctx context.Context, namespace, vmName string) error {
 	return nil
 }
 
+// PauseVM pauses a running VM instance, keeping its memory but releasing its CPU
+func (c *Client) PauseVM(ctx context.Context, namespace, vmName string) error {
+	c.logger.WithFields(logrus.Fields{
+		"namespace": namespace,
+		"vmName":    vmName,
+	}).Info("Pausing VM")
+
+	err := c.virtClient.VirtualMachineInstance(namespace).Pause(ctx, vmName, &kubevirtv1.PauseOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pause VM %s: %w", vmName, err)
+	}
+
+	return nil
+}
+
+// ResumeVM unpauses a VM instance paused with PauseVM
+func (c *Client) ResumeVM(ctx context.Context, namespace, vmName string) error {
+	c.logger.WithFields(logrus.Fields{
+		"namespace": namespace,
+		"vmName":    vmName,
+	}).Info("Resuming VM")
+
+	err := c.virtClient.VirtualMachineInstance(namespace).Unpause(ctx, vmName, &kubevirtv1.UnpauseOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to resume VM %s: %w", vmName, err)
+	}
+
+	return nil
+}
+
+// serialConsoleTimeout bounds waiting for a VM's serial console to accept a connection
+const serialConsoleTimeout = 30 * time.Second
+
+// SerialConsole connects to the serial console of a running VM. Unlike SSH it does not
+// depend on the guest's network or SSH daemon, so it also works while the VM boots.
+func (c *Client) SerialConsole(namespace, vmName string) (net.Conn, error) {
+	stream, err := c.virtClient.VirtualMachineInstance(namespace).SerialConsole(vmName, &kvcorev1.SerialConsoleOptions{
+		ConnectionTimeout: serialConsoleTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to serial console of VM %s: %w", vmName, err)
+	}
+	return stream.AsConn(), nil
+}
+
 // VirtClient returns the KubeVirt client for direct API access
 func (c *Client) VirtClient() kubecli.KubevirtClient {
 	return c.virtClient
@@ -1162,6 +1453,9 @@ func (c *Client) IsVMSSHReady(ctx context.Context, namespace, vmName string) (bo
 	testCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 
+	if err := testCtx.Err(); err != nil {
+		return false, err
+	}
 	args := c.buildVirtctlSSHArgs(namespace, vmName, "suporte", "echo 'ssh-ready-test'")
 	cmd := exec.CommandContext(testCtx, "virtctl", args...)
 
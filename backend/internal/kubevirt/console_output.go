@@ -0,0 +1,118 @@
+// backend/internal/kubevirt/console_output.go - Capturing VM serial console output
+
+package kubevirt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// consoleOutputReadTimeout ends a console capture once the VM has printed nothing for this long
+const consoleOutputReadTimeout = 5 * time.Second
+
+// GetVMConsoleOutput connects to the serial console of a VM, the endpoint `virtctl console`
+// uses, and returns the last lines printed until the console stays silent for
+// consoleOutputReadTimeout. The serial console does not replay earlier output, so this shows
+// what a booting or hanging VM is printing now. Connecting takes over the console from any
+// console terminal attached to the VM.
+func (c *Client) GetVMConsoleOutput(ctx context.Context, namespace, vmName string, lines int) (string, error) {
+	if lines <= 0 {
+		return "", fmt.Errorf("lines must be positive, got %d", lines)
+	}
+
+	conn, err := c.SerialConsole(namespace, vmName)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	output := newConsoleLineRing(lines)
+	buffer := make([]byte, 4096)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(consoleOutputReadTimeout)); err != nil {
+			return "", fmt.Errorf("failed to set console read deadline: %w", err)
+		}
+		n, err := conn.Read(buffer)
+		output.Write(buffer[:n])
+		if err == nil {
+			continue
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", fmt.Errorf("console capture of VM %s interrupted: %w", vmName, ctxErr)
+		}
+		var netErr net.Error
+		if !errors.Is(err, io.EOF) && !(errors.As(err, &netErr) && netErr.Timeout()) {
+			return "", fmt.Errorf("failed to read serial console of VM %s: %w", vmName, err)
+		}
+		break
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"namespace": namespace,
+		"vmName":    vmName,
+		"lines":     output.count,
+	}).Debug("Captured VM console output")
+
+	return output.String(), nil
+}
+
+// consoleLineRing is a circular buffer keeping the last lines written to it
+type consoleLineRing struct {
+	lines   []string
+	next    int // Slot of the next line
+	count   int // Lines held, at most len(lines)
+	partial strings.Builder
+}
+
+func newConsoleLineRing(size int) *consoleLineRing {
+	return &consoleLineRing{lines: make([]string, size)}
+}
+
+// Write splits console output into lines, overwriting the oldest line when the buffer is full
+func (r *consoleLineRing) Write(p []byte) {
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			r.partial.Write(p)
+			return
+		}
+		r.partial.Write(p[:i])
+		r.push(strings.TrimRight(r.partial.String(), "\r"))
+		r.partial.Reset()
+		p = p[i+1:]
+	}
+}
+
+func (r *consoleLineRing) push(line string) {
+	r.lines[r.next] = line
+	r.next = (r.next + 1) % len(r.lines)
+	if r.count < len(r.lines) {
+		r.count++
+	}
+}
+
+// String returns the buffered lines oldest first, including an unterminated last line
+func (r *consoleLineRing) String() string {
+	if r.partial.Len() > 0 {
+		r.push(strings.TrimRight(r.partial.String(), "\r"))
+		r.partial.Reset()
+	}
+
+	ordered := make([]string, 0, r.count)
+	start := (r.next - r.count + len(r.lines)) % len(r.lines)
+	for i := 0; i < r.count; i++ {
+		ordered = append(ordered, r.lines[(start+i)%len(r.lines)])
+	}
+	return strings.Join(ordered, "\n")
+}
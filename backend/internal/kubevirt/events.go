@@ -0,0 +1,65 @@
+// backend/internal/kubevirt/events.go - Kubernetes events of VMs
+
+package kubevirt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// vmEventBufferSize is the number of VM events queued before the watcher waits for the reader
+const vmEventBufferSize = 50
+
+// WatchVMEvents streams the Kubernetes events of a VM and its instance, which share the VM's
+// name. The channel is closed when ctx is cancelled or the API server ends the watch.
+func (c *Client) WatchVMEvents(ctx context.Context, namespace, vmName string) (<-chan corev1.Event, error) {
+	watcher, err := c.kubeClient.CoreV1().Events(namespace).Watch(ctx, metav1.ListOptions{
+		FieldSelector: "involvedObject.name=" + vmName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch events of VM %s: %w", vmName, err)
+	}
+
+	events := make(chan corev1.Event, vmEventBufferSize)
+
+	go func() {
+		defer close(events)
+		defer watcher.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case result, ok := <-watcher.ResultChan():
+				if !ok {
+					return
+				}
+				if result.Type == watch.Error {
+					c.logger.WithFields(logrus.Fields{
+						"namespace": namespace,
+						"vmName":    vmName,
+					}).Warn("VM event watch returned an error")
+					return
+				}
+
+				event, ok := result.Object.(*corev1.Event)
+				if !ok || result.Type == watch.Deleted {
+					continue
+				}
+
+				select {
+				case events <- *event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
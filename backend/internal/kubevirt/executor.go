@@ -0,0 +1,13 @@
+// backend/internal/kubevirt/executor.go - Command execution interface for VM consumers
+
+package kubevirt
+
+import "context"
+
+// VMExecutor runs shell commands inside session VMs. It is satisfied by *Client and,
+// in builds with the mock_kubevirt tag, by *MockClient.
+type VMExecutor interface {
+	ExecuteCommandInVM(ctx context.Context, namespace, vmName, command string, retry ...bool) (string, error)
+}
+
+var _ VMExecutor = (*Client)(nil)
@@ -0,0 +1,96 @@
+// backend/internal/kubevirt/metrics.go - Resource usage of VMs from the Kubernetes metrics API
+
+package kubevirt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VMResourceUsage is the CPU and memory used by a VM's virt-launcher pod
+type VMResourceUsage struct {
+	VMName            string    `json:"vmName"`
+	PodName           string    `json:"podName"`
+	CPUUsageNanoCores int64     `json:"cpuUsageNanoCores"`
+	MemoryUsageBytes  int64     `json:"memoryUsageBytes"`
+	Timestamp         time.Time `json:"timestamp"`
+}
+
+// podMetrics is the subset of a metrics.k8s.io/v1beta1 PodMetrics object used here
+type podMetrics struct {
+	Timestamp  metav1.Time `json:"timestamp"`
+	Containers []struct {
+		Name  string              `json:"name"`
+		Usage corev1.ResourceList `json:"usage"`
+	} `json:"containers"`
+}
+
+// GetVMResourceUsage returns the current resource usage of a running VM. The metrics API is
+// queried directly through the core REST client, which avoids depending on k8s.io/metrics.
+func (c *Client) GetVMResourceUsage(ctx context.Context, namespace, vmName string) (*VMResourceUsage, error) {
+	podName, err := c.getVirtLauncherPod(ctx, namespace, vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/apis/metrics.k8s.io/v1beta1/namespaces/%s/pods/%s", namespace, podName)
+	data, err := c.kubeClient.CoreV1().RESTClient().Get().AbsPath(path).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics for pod %s: %w", podName, err)
+	}
+
+	var metrics podMetrics
+	if err := json.Unmarshal(data, &metrics); err != nil {
+		return nil, fmt.Errorf("failed to decode metrics for pod %s: %w", podName, err)
+	}
+
+	usage := &VMResourceUsage{
+		VMName:    vmName,
+		PodName:   podName,
+		Timestamp: metrics.Timestamp.Time,
+	}
+	for _, container := range metrics.Containers {
+		if cpu, ok := container.Usage[corev1.ResourceCPU]; ok {
+			usage.CPUUsageNanoCores += cpu.ScaledValue(resource.Nano)
+		}
+		if memory, ok := container.Usage[corev1.ResourceMemory]; ok {
+			usage.MemoryUsageBytes += memory.Value()
+		}
+	}
+
+	return usage, nil
+}
+
+// getVirtLauncherPod returns the name of the running virt-launcher pod of a VM instance
+func (c *Client) getVirtLauncherPod(ctx context.Context, namespace, vmName string) (string, error) {
+	vmi, err := c.virtClient.VirtualMachineInstance(namespace).Get(ctx, vmName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get VMI %s: %w", vmName, err)
+	}
+
+	// virt-launcher pods are labelled with the UID of the VMI that created them
+	pods, err := c.kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("kubevirt.io/created-by=%s", vmi.UID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods of VMI %s: %w", vmName, err)
+	}
+
+	// During a migration both the source and target pods exist; use the one running the VM
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if vmi.Status.MigrationState == nil || pod.Spec.NodeName == vmi.Status.NodeName {
+			return pod.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no running virt-launcher pod found for VMI %s", vmName)
+}
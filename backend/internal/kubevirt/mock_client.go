@@ -0,0 +1,87 @@
+//go:build mock_kubevirt
+
+// backend/internal/kubevirt/mock_client.go - Canned VM command responses for offline testing
+
+package kubevirt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MockClient is a VMExecutor that answers commands from pre-configured responses
+// instead of connecting to a VM
+type MockClient struct {
+	// Responses maps a command substring to the output returned for matching commands
+	Responses map[string]string
+	// Errors maps a command substring to the error returned for matching commands
+	Errors map[string]error
+
+	commands []string
+	lock     sync.Mutex
+}
+
+var _ VMExecutor = (*MockClient)(nil)
+
+// NewMockClient creates a mock client with the given canned responses
+func NewMockClient(responses map[string]string) *MockClient {
+	return &MockClient{
+		Responses: responses,
+		Errors:    make(map[string]error),
+	}
+}
+
+// ExecuteCommandInVM returns the response of the longest key contained in the command.
+// Commands with a configured error fail with no output, as do commands without a response.
+func (m *MockClient) ExecuteCommandInVM(ctx context.Context, namespace, vmName, command string, retry ...bool) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.commands = append(m.commands, command)
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	if key, ok := longestMatch(m.Errors, command); ok {
+		return "", m.Errors[key]
+	}
+
+	if key, ok := longestMatch(m.Responses, command); ok {
+		return m.Responses[key], nil
+	}
+
+	return "", fmt.Errorf("no mock response for command: %s", command)
+}
+
+// Commands returns the commands executed so far in order
+func (m *MockClient) Commands() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return append([]string(nil), m.commands...)
+}
+
+// longestMatch returns the longest key of m that is a substring of command
+func longestMatch[V any](m map[string]V, command string) (string, bool) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		if strings.Contains(command, key) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return "", false
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys[0], true
+}
@@ -0,0 +1,152 @@
+// backend/internal/kubevirt/ssh_master.go - Shared SSH connections to VMs
+
+package kubevirt
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// sshControlPersist is how long an idle master connection stays open
+	sshControlPersist = "60s"
+
+	// sshMasterStartTimeout bounds the handshake of a new master connection
+	sshMasterStartTimeout = 30 * time.Second
+
+	// sshMasterCheckTimeout bounds the check that a master connection still answers
+	sshMasterCheckTimeout = 5 * time.Second
+)
+
+// SSHMasterManager keeps one OpenSSH ControlMaster connection per VM so that commands
+// reuse it instead of paying for a full SSH handshake each time
+type SSHMasterManager struct {
+	username string
+	starting map[string]bool
+	lock     sync.Mutex
+	logger   *logrus.Logger
+}
+
+// NewSSHMasterManager creates a manager for master connections logged in as username
+func NewSSHMasterManager(username string, logger *logrus.Logger) *SSHMasterManager {
+	return &SSHMasterManager{
+		username: username,
+		starting: make(map[string]bool),
+		logger:   logger,
+	}
+}
+
+// ControlPath returns the socket of the master connection to a VM
+func (m *SSHMasterManager) ControlPath(namespace, vmName string) string {
+	return fmt.Sprintf("/tmp/cks-%s-%s.sock", namespace, vmName)
+}
+
+// HasMaster reports whether a master connection to the VM is open
+func (m *SSHMasterManager) HasMaster(namespace, vmName string) bool {
+	info, err := os.Stat(m.ControlPath(namespace, vmName))
+	return err == nil && info.Mode()&os.ModeSocket != 0
+}
+
+// UsableMaster reports whether the master connection to the VM is open and answers
+// "ssh -O check". The socket of a master that does not answer, e.g. left behind by a killed
+// ssh process, is removed so that commands fall back to a plain connection and a new master
+// can be started.
+func (m *SSHMasterManager) UsableMaster(ctx context.Context, namespace, vmName string) bool {
+	if !m.HasMaster(namespace, vmName) {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sshMasterCheckTimeout)
+	defer cancel()
+
+	controlPath := m.ControlPath(namespace, vmName)
+	output, err := exec.CommandContext(ctx, "ssh", "-O", "check", "-o", "ControlPath="+controlPath, vmName).CombinedOutput()
+	if err == nil {
+		return true
+	}
+
+	m.logger.WithError(err).WithFields(logrus.Fields{
+		"namespace": namespace,
+		"vmName":    vmName,
+		"output":    string(output),
+	}).Debug("SSH master connection check failed, removing its socket")
+	if err := os.Remove(controlPath); err != nil && !os.IsNotExist(err) {
+		m.logger.WithError(err).WithField("controlPath", controlPath).Warn("Failed to remove stale SSH master socket")
+	}
+	return false
+}
+
+// ClientSSHOpts returns the virtctl options that route a command through the VM's master connection
+func (m *SSHMasterManager) ClientSSHOpts(namespace, vmName string) []string {
+	return []string{
+		"--local-ssh-opts=-o ControlMaster=no",
+		"--local-ssh-opts=-o ControlPath=" + m.ControlPath(namespace, vmName),
+	}
+}
+
+// EnsureMaster starts a master connection to the VM in the background unless one is open
+// or already being started. OpenSSH keeps it alive for sshControlPersist after the last use.
+//
+// The master is detached from any request: it is not bound to a context, and its output goes
+// to /dev/null, so waiting for virtctl does not also wait for the backgrounded master to close
+// an inherited pipe.
+func (m *SSHMasterManager) EnsureMaster(namespace, vmName string, baseArgs []string) {
+	key := namespace + "/" + vmName
+
+	m.lock.Lock()
+	if m.starting[key] || m.HasMaster(namespace, vmName) {
+		m.lock.Unlock()
+		return
+	}
+	m.starting[key] = true
+	m.lock.Unlock()
+
+	args := append(append([]string(nil), baseArgs...),
+		"--local-ssh-opts=-o ControlMaster=yes",
+		"--local-ssh-opts=-o ControlPath="+m.ControlPath(namespace, vmName),
+		"--local-ssh-opts=-o ControlPersist="+sshControlPersist,
+		"--command=true",
+	)
+
+	go func() {
+		defer func() {
+			m.lock.Lock()
+			delete(m.starting, key)
+			m.lock.Unlock()
+		}()
+
+		// Stdin, Stdout and Stderr are left nil, which connects them to /dev/null
+		cmd := exec.Command("virtctl", args...)
+		if err := cmd.Start(); err != nil {
+			m.logger.WithError(err).WithFields(logrus.Fields{
+				"namespace": namespace,
+				"vmName":    vmName,
+			}).Debug("Failed to start SSH master connection")
+			return
+		}
+
+		// Give up on a handshake that hangs; a master that already backgrounded itself is
+		// not affected
+		timer := time.AfterFunc(sshMasterStartTimeout, func() { cmd.Process.Kill() })
+		err := cmd.Wait()
+		timer.Stop()
+		if err != nil {
+			m.logger.WithError(err).WithFields(logrus.Fields{
+				"namespace": namespace,
+				"vmName":    vmName,
+			}).Debug("Failed to start SSH master connection")
+			return
+		}
+
+		m.logger.WithFields(logrus.Fields{
+			"namespace": namespace,
+			"vmName":    vmName,
+		}).Debug("SSH master connection started")
+	}()
+}
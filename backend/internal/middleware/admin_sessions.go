@@ -0,0 +1,79 @@
+// backend/internal/middleware/admin_sessions.go - Short-lived admin session tokens
+
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrInvalidAdminToken is returned by AdminSessions.Exchange for a token that does not match
+// the admin token hash, or when no admin token is configured
+var ErrInvalidAdminToken = errors.New("invalid admin token")
+
+// AdminSessions issues the session tokens accepted by AdminAuth. Checking the admin token
+// against its bcrypt hash is deliberately slow, so it only happens once per session in
+// Exchange; session tokens are checked with a map lookup.
+type AdminSessions struct {
+	adminTokenHash string
+	ttl            time.Duration
+
+	lock   sync.Mutex
+	tokens map[[sha256.Size]byte]time.Time // SHA-256 of the session token -> expiry
+}
+
+// NewAdminSessions creates an AdminSessions exchanging the admin token matching adminTokenHash
+// for session tokens valid for ttl. An empty hash rejects every exchange.
+func NewAdminSessions(adminTokenHash string, ttl time.Duration) *AdminSessions {
+	return &AdminSessions{
+		adminTokenHash: adminTokenHash,
+		ttl:            ttl,
+		tokens:         make(map[[sha256.Size]byte]time.Time),
+	}
+}
+
+// Exchange returns a new session token and its expiry if adminToken is the admin token
+func (s *AdminSessions) Exchange(adminToken string) (string, time.Time, error) {
+	if s.adminTokenHash == "" || bcrypt.CompareHashAndPassword([]byte(s.adminTokenHash), []byte(adminToken)) != nil {
+		return "", time.Time{}, ErrInvalidAdminToken
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, err
+	}
+	token := hex.EncodeToString(b)
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for key, expiry := range s.tokens {
+		if !now.Before(expiry) {
+			delete(s.tokens, key)
+		}
+	}
+	s.tokens[sha256.Sum256([]byte(token))] = expiresAt
+
+	return token, expiresAt, nil
+}
+
+// Valid reports whether token is an unexpired session token
+func (s *AdminSessions) Valid(token string) bool {
+	if token == "" {
+		return false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	expiresAt, ok := s.tokens[sha256.Sum256([]byte(token))]
+	return ok && time.Now().Before(expiresAt)
+}
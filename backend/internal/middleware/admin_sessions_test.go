@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestAdminSessions(t *testing.T, ttl time.Duration) *AdminSessions {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("admin-secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword: %v", err)
+	}
+	return NewAdminSessions(string(hash), ttl)
+}
+
+func TestAdminSessionsExchange(t *testing.T) {
+	sessions := newTestAdminSessions(t, time.Hour)
+
+	token, expiresAt, err := sessions.Exchange("admin-secret")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if token == "" || token == "admin-secret" {
+		t.Fatalf("token = %q, want a new session token", token)
+	}
+	if until := time.Until(expiresAt); until <= 0 || until > time.Hour {
+		t.Errorf("token expires in %v, want within an hour", until)
+	}
+	if !sessions.Valid(token) {
+		t.Error("issued token is not valid")
+	}
+	if sessions.Valid("admin-secret") {
+		t.Error("admin token is accepted as a session token")
+	}
+	if sessions.Valid("") {
+		t.Error("empty token is valid")
+	}
+}
+
+func TestAdminSessionsRejectsWrongToken(t *testing.T) {
+	sessions := newTestAdminSessions(t, time.Hour)
+
+	if _, _, err := sessions.Exchange("wrong"); !errors.Is(err, ErrInvalidAdminToken) {
+		t.Errorf("wrong token: err = %v, want ErrInvalidAdminToken", err)
+	}
+
+	unconfigured := NewAdminSessions("", time.Hour)
+	if _, _, err := unconfigured.Exchange(""); !errors.Is(err, ErrInvalidAdminToken) {
+		t.Errorf("no admin token configured: err = %v, want ErrInvalidAdminToken", err)
+	}
+}
+
+func TestAdminSessionsExpire(t *testing.T) {
+	sessions := newTestAdminSessions(t, 10*time.Millisecond)
+
+	token, _, err := sessions.Exchange("admin-secret")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if sessions.Valid(token) {
+		t.Error("expired token is still valid")
+	}
+
+	// Expired tokens are dropped on the next exchange
+	if _, _, err := sessions.Exchange("admin-secret"); err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if got := len(sessions.tokens); got != 1 {
+		t.Errorf("%d tokens stored, want 1", got)
+	}
+}
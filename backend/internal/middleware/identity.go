@@ -0,0 +1,81 @@
+// backend/internal/middleware/identity.go - User identity asserted by an authenticating proxy
+
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// userIDKey is the context key holding the authenticated user ID
+const userIDKey = "UserID"
+
+// UserIdentity takes the user ID from header on requests that come straight from one of the
+// trusted proxies (IPs or CIDRs), which is how an authenticating proxy such as oauth2-proxy
+// passes on the user it signed in. Without a header, or from any other peer, the request is
+// anonymous, so clients cannot claim a user by setting the header themselves.
+func UserIdentity(header string, trustedProxies []string) (gin.HandlerFunc, error) {
+	networks, err := parseNetworks(trustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		if header != "" && containsIP(networks, net.ParseIP(c.RemoteIP())) {
+			if userID := strings.TrimSpace(c.GetHeader(header)); userID != "" {
+				c.Set(userIDKey, userID)
+			}
+		}
+		c.Next()
+	}, nil
+}
+
+// AuthenticatedUser returns the user ID set by UserIdentity, or "" for an anonymous request
+func AuthenticatedUser(c *gin.Context) string {
+	return c.GetString(userIDKey)
+}
+
+// parseNetworks parses IPs and CIDRs, treating a single IP as a network of one address
+func parseNetworks(values []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP %q", value)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", value, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// containsIP reports whether ip is in any of networks
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
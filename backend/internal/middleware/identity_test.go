@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// authenticatedUser runs a request from remoteAddr with the given headers through
+// UserIdentity and returns the user it authenticated
+func authenticatedUser(t *testing.T, header string, trustedProxies []string, remoteAddr string, headers map[string]string) string {
+	t.Helper()
+
+	identity, err := UserIdentity(header, trustedProxies)
+	if err != nil {
+		t.Fatalf("UserIdentity: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var userID string
+	router.GET("/", identity, func(c *gin.Context) {
+		userID = AuthenticatedUser(c)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	return userID
+}
+
+func TestUserIdentity(t *testing.T) {
+	proxies := []string{"10.0.0.0/24", "192.168.1.10"}
+	headers := map[string]string{"X-Forwarded-User": "alice"}
+
+	tests := []struct {
+		name       string
+		header     string
+		remoteAddr string
+		want       string
+	}{
+		{name: "proxy in CIDR", header: "X-Forwarded-User", remoteAddr: "10.0.0.7:4000", want: "alice"},
+		{name: "proxy IP", header: "X-Forwarded-User", remoteAddr: "192.168.1.10:4000", want: "alice"},
+		{name: "untrusted peer", header: "X-Forwarded-User", remoteAddr: "192.168.1.11:4000"},
+		{name: "no header configured", remoteAddr: "10.0.0.7:4000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := authenticatedUser(t, tt.header, proxies, tt.remoteAddr, headers); got != tt.want {
+				t.Errorf("user = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserIdentityWithoutHeaderValue(t *testing.T) {
+	if got := authenticatedUser(t, "X-Forwarded-User", []string{"10.0.0.0/24"}, "10.0.0.7:4000", nil); got != "" {
+		t.Errorf("user = %q, want anonymous", got)
+	}
+}
+
+func TestUserIdentityRejectsInvalidProxy(t *testing.T) {
+	if _, err := UserIdentity("X-Forwarded-User", []string{"not-an-ip"}); err == nil {
+		t.Error("invalid trusted proxy accepted")
+	}
+}
@@ -4,12 +4,22 @@ package middleware
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // RequestID adds a unique request ID to each request
@@ -22,6 +32,188 @@ func RequestID() gin.HandlerFunc {
 	}
 }
 
+// Tracing starts a server span for each request, continuing the trace of an incoming
+// traceparent header. Spans started from the request context become its children.
+func Tracing() gin.HandlerFunc {
+	tracer := otel.Tracer("github.com/fullstack-pw/cks/backend/middleware")
+
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx, span := tracer.Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("http.route", route),
+			),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
+
+// AllowOrigins returns a CORS origin check for a list of patterns. "*" allows every origin,
+// and a pattern containing "*" such as "https://*.example.com" allows any subdomain in place
+// of the wildcard; other patterns must match the origin exactly.
+func AllowOrigins(patterns []string) func(origin string) bool {
+	return func(origin string) bool {
+		origin = strings.ToLower(origin)
+		for _, pattern := range patterns {
+			pattern = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(pattern), "/"))
+			if pattern == "*" || pattern == origin {
+				return true
+			}
+
+			prefix, suffix, wildcard := strings.Cut(pattern, "*")
+			if !wildcard || !strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+				continue
+			}
+
+			// The wildcard stands for subdomain labels, never for a scheme separator or path
+			if len(origin) < len(prefix)+len(suffix) {
+				continue
+			}
+			subdomain := origin[len(prefix) : len(origin)-len(suffix)]
+			if prefix == "" {
+				_, subdomain, _ = strings.Cut(subdomain, "://")
+			}
+			if subdomain != "" && !strings.ContainsAny(subdomain, "/:@") {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AllowsAnyOrigin reports whether patterns contain "*", which allows every origin
+func AllowsAnyOrigin(patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// AdminAuth requires requests to carry a bearer session token issued by sessions in exchange
+// for the admin token
+func AdminAuth(sessions *AdminSessions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !sessions.Valid(token) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Admin authorization required"})
+			return
+		}
+		c.Set("Actor", "admin")
+		c.Next()
+	}
+}
+
+// Timeout bounds the request context to d and responds with 503 if the handler has not
+// started writing a response by the deadline. WebSocket upgrades are not affected.
+func Timeout(d time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.IsWebsocket() {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		tw := &timeoutWriter{ResponseWriter: c.Writer}
+		c.Writer = tw
+
+		done := make(chan struct{})
+		var panicValue interface{}
+		go func() {
+			defer close(done)
+			defer func() {
+				panicValue = recover()
+			}()
+			c.Next()
+		}()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				tw.timeout()
+			}
+			// Wait for the handler so the context is not reused while it still runs
+			<-done
+		}
+
+		c.Writer = tw.ResponseWriter
+
+		// Re-raise handler panics on the request goroutine so Recovery handles them
+		if panicValue != nil {
+			panic(panicValue)
+		}
+	}
+}
+
+// timeoutWriter discards handler output once the timeout response has been sent
+type timeoutWriter struct {
+	gin.ResponseWriter
+	mu       sync.Mutex
+	timedOut bool
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// timeout sends the 503 response unless the handler has already started its own
+func (w *timeoutWriter) timeout() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.ResponseWriter.Written() {
+		return
+	}
+	w.timedOut = true
+	w.ResponseWriter.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.ResponseWriter.WriteHeader(http.StatusServiceUnavailable)
+	w.ResponseWriter.Write([]byte(`{"error":"Request timed out"}`))
+}
+
 // Logger logs request details using logrus
 func Logger() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -0,0 +1,85 @@
+// backend/internal/middleware/rate_limit.go - Per-client request rate limiting
+
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// rateLimiterIdleTTL is how long a client's limiter is kept after its last request
+	rateLimiterIdleTTL = 10 * time.Minute
+	// rateLimiterEvictInterval is how often idle limiters are evicted
+	rateLimiterEvictInterval = time.Minute
+)
+
+// clientLimiter is the token bucket of one client and the time of its last request
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen atomic.Int64 // Unix nanoseconds
+}
+
+// RateLimiter limits requests per client IP with a token bucket refilled at rps tokens per
+// second and holding up to burst tokens. The client IP is gin's ClientIP: the X-Forwarded-For
+// address seen by the nearest trusted proxy (TRUSTED_PROXIES, none by default) when the
+// request comes through one, otherwise the RemoteAddr host. Rejected requests get HTTP 429
+// with the number of seconds until a token is available.
+// Each call keeps its own buckets, so handlers sharing a limit must share the handler.
+func RateLimiter(rps float64, burst int) gin.HandlerFunc {
+	var limiters sync.Map // client IP -> *clientLimiter
+
+	go func() {
+		ticker := time.NewTicker(rateLimiterEvictInterval)
+		defer ticker.Stop()
+
+		for now := range ticker.C {
+			cutoff := now.Add(-rateLimiterIdleTTL).UnixNano()
+			limiters.Range(func(key, value any) bool {
+				if value.(*clientLimiter).lastSeen.Load() < cutoff {
+					limiters.Delete(key)
+				}
+				return true
+			})
+		}
+	}()
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		clientIP := c.ClientIP()
+		value, ok := limiters.Load(clientIP)
+		if !ok {
+			value, _ = limiters.LoadOrStore(clientIP, &clientLimiter{
+				limiter: rate.NewLimiter(rate.Limit(rps), burst),
+			})
+		}
+		client := value.(*clientLimiter)
+		client.lastSeen.Store(now.UnixNano())
+
+		reservation := client.limiter.ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); !reservation.OK() || delay > 0 {
+			reservation.CancelAt(now)
+
+			// A burst of 0 never admits a request; clients are told to retry in a second
+			retryAfter := 1
+			if reservation.OK() {
+				retryAfter = max(int(math.Ceil(delay.Seconds())), 1)
+			}
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":             "rate limit exceeded",
+				"retryAfterSeconds": retryAfter,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
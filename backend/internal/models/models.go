@@ -11,6 +11,7 @@ type Session struct {
 	ID               string                  `json:"id"`
 	Namespace        string                  `json:"namespace"`
 	ScenarioID       string                  `json:"scenarioId"`
+	UserID           string                  `json:"userId,omitempty"`
 	Status           SessionStatus           `json:"status"`
 	StatusMessage    string                  `json:"statusMessage,omitempty"`
 	StartTime        time.Time               `json:"startTime"`
@@ -20,8 +21,50 @@ type Session struct {
 	Tasks            []TaskStatus            `json:"tasks"`
 	TerminalSessions map[string]string       `json:"terminalSessions"`          // Keep existing
 	ActiveTerminals  map[string]TerminalInfo `json:"activeTerminals"`           // NEW: Persistent terminal info
-	AssignedCluster  string                  `json:"assignedCluster,omitempty"` // "cluster1", "cluster2", "cluster3"
+	AssignedCluster  string                  `json:"assignedCluster,omitempty"` // e.g. "cluster1"
 	ClusterLockTime  time.Time               `json:"clusterLockTime,omitempty"`
+	Score            int                     `json:"score"`
+	CompletedAt      time.Time               `json:"completedAt,omitempty"` // Set when every task has been completed
+	PausedAt         time.Time               `json:"pausedAt,omitempty"`    // Set while the session is paused
+	ClientIP         string                  `json:"clientIp,omitempty"`
+	UserAgent        string                  `json:"userAgent,omitempty"`
+	CreatedBy        string                  `json:"createdBy,omitempty"` // User ID or "anonymous"
+	Tags             map[string]string       `json:"tags,omitempty"`
+	Notes            string                  `json:"notes,omitempty"`
+	NetworkInfo      *NetworkInfo            `json:"networkInfo,omitempty"` // Set once the VM IPs are known
+	SetupProgress    *SetupProgress          `json:"setupProgress,omitempty"`
+
+	UserSnapshotName   string `json:"userSnapshotName,omitempty"`   // Prefix of the user snapshot of both VMs, if any
+	UserSnapshotStatus string `json:"userSnapshotStatus,omitempty"` // creating, ready, restoring or failed
+}
+
+// SetupProgress reports the scenario setup step a session is running
+type SetupProgress struct {
+	CurrentStep            int    `json:"currentStep"`
+	TotalSteps             int    `json:"totalSteps"`
+	CurrentStepDescription string `json:"currentStepDescription"`
+}
+
+// NetworkInfo describes how to reach a session's cluster directly
+type NetworkInfo struct {
+	ControlPlaneIP string `json:"controlPlaneIP,omitempty"`
+	WorkerNodeIP   string `json:"workerNodeIP,omitempty"`
+	KubeconfigPath string `json:"kubeconfigPath"` // Admin kubeconfig inside the control plane VM
+}
+
+// SessionPatch holds the user-defined session metadata to update; nil fields are left unchanged.
+// Tags are merged into the existing tags, and a tag with an empty value is removed.
+type SessionPatch struct {
+	Tags  map[string]string `json:"tags,omitempty"`
+	Notes *string           `json:"notes,omitempty"`
+}
+
+// SessionOptions carries caller information recorded on a new session
+type SessionOptions struct {
+	UserID    string
+	ClientIP  string
+	UserAgent string
+	Tags      map[string]string
 }
 
 type TerminalInfo struct {
@@ -30,6 +73,20 @@ type TerminalInfo struct {
 	Status     string    `json:"status"` // "active", "disconnected"
 	CreatedAt  time.Time `json:"createdAt"`
 	LastUsedAt time.Time `json:"lastUsedAt"`
+
+	// ActiveConns is the number of WebSockets attached to the terminal, filled in when listing terminals
+	ActiveConns int `json:"activeConns"`
+
+	// CommandHistory is the redacted command history, filled in only for admin requests
+	CommandHistory []string `json:"commandHistory,omitempty"`
+}
+
+// SessionEvent is pushed to clients subscribed to a session's event stream
+type SessionEvent struct {
+	Type             string         `json:"type"`
+	RemainingSeconds int            `json:"remainingSeconds,omitempty"`
+	TaskID           string         `json:"taskId,omitempty"`
+	SetupProgress    *SetupProgress `json:"setupProgress,omitempty"`
 }
 
 // SessionStatus represents the status of a session
@@ -39,12 +96,18 @@ const (
 	// SessionStatusPending indicates the session is being created
 	SessionStatusPending SessionStatus = "pending"
 
+	// SessionStatusQueued indicates the session is waiting for a cluster to become available
+	SessionStatusQueued SessionStatus = "queued"
+
 	// SessionStatusProvisioning indicates the session is provisioning resources
 	SessionStatusProvisioning SessionStatus = "provisioning"
 
 	// SessionStatusRunning indicates the session is active and running
 	SessionStatusRunning SessionStatus = "running"
 
+	// SessionStatusPaused indicates the session VMs are paused and the expiration countdown is stopped
+	SessionStatusPaused SessionStatus = "paused"
+
 	// SessionStatusCompleted indicates the session has been completed
 	SessionStatusCompleted SessionStatus = "completed"
 
@@ -53,11 +116,132 @@ const (
 )
 
 type TaskStatus struct {
-	ID               string                 `json:"id"`
-	Status           string                 `json:"status"` // "pending", "completed", "failed"
-	ValidationTime   time.Time              `json:"validationTime,omitempty"`
-	Message          string                 `json:"message,omitempty"`
-	ValidationResult *ValidationResponseRef `json:"validationResult,omitempty"`
+	ID                 string                 `json:"id"`
+	Status             string                 `json:"status"` // "pending", "completed", "failed"
+	ValidationTime     time.Time              `json:"validationTime,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+	ValidationResult   *ValidationResponseRef `json:"validationResult,omitempty"`
+	CompletedAt        time.Time              `json:"completedAt,omitempty"`
+	Points             int                    `json:"points,omitempty"`
+	TimeBonus          int                    `json:"timeBonus,omitempty"`
+	AttemptCount       int                    `json:"attemptCount"`              // Number of validations run for the task
+	ResetCount         int                    `json:"resetCount,omitempty"`      // Number of times the task was reset to pending
+	StartedAt          time.Time              `json:"startedAt,omitempty"`       // First validation of the task
+	DurationSeconds    float64                `json:"durationSeconds,omitempty"` // From StartedAt to the validation that completed the task
+	HintsUsed          int                    `json:"hintsUsed,omitempty"`       // Number of distinct hints revealed one at a time
+	RevealedHints      []int                  `json:"revealedHints,omitempty"`   // Indexes of the revealed hints
+	HintRequestedAt    time.Time              `json:"hintRequestedAt,omitempty"` // Last hint revealed
+	DeadlineAt         time.Time              `json:"deadlineAt,omitempty"`      // StartedAt plus the task's TimeEstimateSeconds
+	TimedOut           bool                   `json:"timedOut,omitempty"`        // DeadlineAt passed before the task was completed
+	SolutionRevealed   bool                   `json:"solutionRevealed,omitempty"`
+	SolutionRevealedAt time.Time              `json:"solutionRevealedAt,omitempty"` // First time the solution was shown
+}
+
+// SessionView is a session with the scenario and task details clients display with it
+type SessionView struct {
+	Session  *Session             `json:"session"`
+	Scenario *SessionViewScenario `json:"scenario,omitempty"`
+	Tasks    []SessionTaskView    `json:"tasks"`
+}
+
+// SessionViewScenario describes the scenario of a SessionView
+type SessionViewScenario struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Difficulty  string `json:"difficulty"`
+}
+
+// SessionTaskView is the status of a task together with its details and unlocked hints
+type SessionTaskView struct {
+	TaskStatus
+	Title                        string   `json:"title,omitempty"`
+	Description                  string   `json:"description,omitempty"`
+	Hints                        []string `json:"hints,omitempty"`
+	NextHintUnlocksAfterAttempts *int     `json:"nextHintUnlocksAfterAttempts,omitempty"`
+}
+
+// TaskHints are the hints of a task unlocked by the attempts made so far
+type TaskHints struct {
+	Hints        []string `json:"hints"`
+	AttemptCount int      `json:"attemptCount"`
+
+	// Attempt count at which the next hint is shown, or nil when every hint is unlocked
+	NextHintUnlocksAfterAttempts *int `json:"nextHintUnlocksAfterAttempts"`
+}
+
+// HintResponse is a single task hint requested by index. Hint is empty while the hint is locked.
+type HintResponse struct {
+	Hint                 string `json:"hint,omitempty"`
+	Index                int    `json:"index"`
+	Unlocked             bool   `json:"unlocked"`
+	UnlocksAfterAttempts *int   `json:"unlocksAfterAttempts,omitempty"` // Attempts needed to unlock a locked hint
+
+	// Attempt count at which the next locked hint of the task is shown, or nil when every hint is unlocked
+	NextHintUnlocksAfterAttempts *int `json:"nextHintUnlocksAfterAttempts"`
+}
+
+// SolutionResponse is the solution of a task. Solution is empty while it is locked.
+type SolutionResponse struct {
+	Solution             string    `json:"solution,omitempty"`
+	Unlocked             bool      `json:"unlocked"`
+	AttemptCount         int       `json:"attemptCount"`
+	UnlocksAfterAttempts int       `json:"unlocksAfterAttempts"`
+	PenaltyPercent       float64   `json:"penaltyPercent"` // Share of the task points lost when it is completed after the reveal
+	RevealedAt           time.Time `json:"revealedAt,omitempty"`
+}
+
+// ScenarioStats summarises the sessions run for a scenario
+type ScenarioStats struct {
+	ScenarioID               string             `json:"scenarioId"`
+	TotalAttempts            int                `json:"totalAttempts"`
+	CompletedAttempts        int                `json:"completedAttempts"`
+	AverageCompletionSeconds float64            `json:"averageCompletionSeconds"`
+	TaskCompletionRates      map[string]float64 `json:"taskCompletionRates"` // Task ID -> percentage of attempts that completed it
+	AverageTaskSeconds       map[string]float64 `json:"averageTaskSeconds"`  // Task ID -> average DurationSeconds of completions
+}
+
+// DifficultyRating compares the difficulty given by a scenario's author with the
+// ratings of users who completed it
+type DifficultyRating struct {
+	ScenarioID       string  `json:"scenarioId"`
+	AuthorDifficulty string  `json:"authorDifficulty"`
+	CommunityAverage float64 `json:"communityAverage"` // 1 (easy) to 5 (hard), 0 without votes
+	VoteCount        int     `json:"voteCount"`
+}
+
+// ScoreBreakdown reports the points earned in a session
+type ScoreBreakdown struct {
+	SessionID string      `json:"sessionId"`
+	Tasks     []TaskScore `json:"tasks"`
+	Total     int         `json:"total"`
+}
+
+// TaskScore is the per-task entry of a ScoreBreakdown
+type TaskScore struct {
+	TaskID    string `json:"taskId"`
+	Status    string `json:"status"`
+	Points    int    `json:"points"`
+	TimeBonus int    `json:"timeBonus"`
+	Total     int    `json:"total"`
+}
+
+// SessionProgress summarizes how far a session has progressed through its scenario
+type SessionProgress struct {
+	SessionID        string             `json:"sessionId"`
+	PercentComplete  float64            `json:"percentComplete"`
+	CompletedTasks   int                `json:"completedTasks"`
+	TotalTasks       int                `json:"totalTasks"`
+	ElapsedSeconds   int64              `json:"elapsedSeconds"`
+	RemainingSeconds int64              `json:"remainingSeconds"`
+	TaskSummary      []TaskProgressItem `json:"taskSummary"`
+}
+
+// TaskProgressItem is the per-task entry of a SessionProgress
+type TaskProgressItem struct {
+	ID             string    `json:"id"`
+	Status         string    `json:"status"`
+	ValidationTime time.Time `json:"validationTime,omitempty"`
 }
 
 // ValidationResponseRef stores a reference to validation results
@@ -68,54 +252,124 @@ type ValidationResponseRef struct {
 	// Note: Detailed results are returned via API, not stored in session
 }
 
+// CategoryNode is a scenario category and its subcategories
+type CategoryNode struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	ParentID    string          `json:"parentId,omitempty"`
+	Children    []*CategoryNode `json:"children,omitempty"`
+}
+
 // Scenario represents a CKS practice scenario
 type Scenario struct {
-	ID           string               `json:"id"`
-	Title        string               `json:"title"`
-	Description  string               `json:"description"`
-	Difficulty   string               `json:"difficulty"` // "beginner", "intermediate", "advanced"
-	TimeEstimate string               `json:"timeEstimate"`
-	Topics       []string             `json:"topics"`
-	Tasks        []Task               `json:"tasks"`
-	Requirements ScenarioRequirements `json:"requirements"`
-	SetupSteps   []SetupStep          `json:"setupSteps"`
-	Author       string               `json:"author,omitempty"`
-	Version      string               `json:"version"`
-	InitScript   string               `json:"initScript,omitempty"` // Path to init script
+	ID            string               `json:"id"`
+	Title         string               `json:"title"`
+	Description   string               `json:"description"`
+	Difficulty    string               `json:"difficulty"` // "beginner", "intermediate", "advanced"
+	TimeEstimate  string               `json:"timeEstimate" yaml:"timeEstimate"`
+	Topics        []string             `json:"topics"`
+	Tags          []string             `json:"tags,omitempty"` // Free-form labels for filtering
+	Tasks         []Task               `json:"tasks" yaml:"tasks,omitempty"`
+	Requirements  ScenarioRequirements `json:"requirements"`
+	SetupSteps    []SetupStep          `json:"setupSteps" yaml:"setupSteps,omitempty"`
+	Rollback      []SetupStep          `json:"rollback,omitempty" yaml:"rollback,omitempty"` // Cleanup steps run in reverse order when setup fails
+	Author        string               `json:"author,omitempty"`
+	Version       string               `json:"version" yaml:"version"`
+	InitScript    string               `json:"initScript,omitempty" yaml:"initScript"` // Path to init script
+	Prerequisites []string             `json:"prerequisites,omitempty"`                // Scenario IDs that must be completed first
+	ScoreConfig   ScoreConfig          `json:"scoreConfig" yaml:"scoreConfig"`
+
+	MinBackendVersion  string `json:"minBackendVersion,omitempty" yaml:"minBackendVersion"` // Oldest backend release the scenario works with
+	Deprecated         bool   `json:"deprecated,omitempty" yaml:"deprecated"`               // Deprecated scenarios cannot start sessions unless allowed by config
+	DeprecationMessage string `json:"deprecationMessage,omitempty" yaml:"deprecationMessage"`
+}
+
+// ScenarioVersion reports the version and deprecation status of a scenario
+type ScenarioVersion struct {
+	Version           string `json:"version"`
+	Deprecated        bool   `json:"deprecated"`
+	MinBackendVersion string `json:"minBackendVersion"`
+}
+
+// ScoreConfig defines how tasks in a scenario are scored
+type ScoreConfig struct {
+	PointsPerTask          int     `json:"pointsPerTask" yaml:"pointsPerTask"`
+	TimeBonusEnabled       bool    `json:"timeBonusEnabled" yaml:"timeBonusEnabled"`
+	MaxTimeBonusPercent    float64 `json:"maxTimeBonusPercent" yaml:"maxTimeBonusPercent"`
+	SolutionPenaltyPercent float64 `json:"solutionPenaltyPercent" yaml:"solutionPenaltyPercent"` // Points lost on tasks completed after revealing their solution; 0 means 50
 }
 
 // ScenarioRequirements defines the requirements for a scenario
 type ScenarioRequirements struct {
-	K8sVersion string `json:"k8sVersion"`
+	K8sVersion string `json:"k8sVersion" yaml:"k8sVersion"` // Overrides KUBERNETES_VERSION for the session cluster
 	Resources  struct {
 		CPU    string `json:"cpu"`
 		Memory string `json:"memory"`
 	} `json:"resources"`
+	ResourceLimits ResourceLimits `json:"resourceLimits" yaml:"resourceLimits"` // Session namespace quota
+}
+
+// ResourceLimits are the hard ResourceQuota limits of a session namespace.
+// Empty fields fall back to the configured defaults.
+type ResourceLimits struct {
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+	Pods   string `json:"pods,omitempty"`
 }
 
 // Task represents a task in a scenario
 type Task struct {
-	ID          string           `json:"id"`
-	Title       string           `json:"title"`
-	Description string           `json:"description"`
-	Validation  []ValidationRule `json:"validation"`
-	Hints       []string         `json:"hints,omitempty"`
-	Objective   string           `json:"objective,omitempty"` // Add this line
-	Steps       []string         `json:"steps,omitempty"`     // Add this line
+	ID                          string           `json:"id" yaml:"id"`
+	Title                       string           `json:"title" yaml:"title,omitempty"`
+	Description                 string           `json:"description" yaml:"description,omitempty"`
+	Validation                  []ValidationRule `json:"validation" yaml:"validation,omitempty"`
+	Hints                       []string         `json:"hints,omitempty" yaml:"hints,omitempty"`
+	HintUnlockAfterAttempts     []int            `json:"hintUnlockAfterAttempts,omitempty" yaml:"hintUnlockAfterAttempts,omitempty"` // Failed attempts before each hint is shown, from the task's "Hint Unlock" section
+	Objective                   string           `json:"objective,omitempty" yaml:"objective,omitempty"`
+	Steps                       []string         `json:"steps,omitempty" yaml:"steps,omitempty"`
+	TimeEstimateSeconds         int              `json:"timeEstimateSeconds,omitempty" yaml:"timeEstimateSeconds,omitempty"`                 // From the task's "Time Estimate" section; drives the time bonus and the countdown
+	Solution                    string           `json:"solution,omitempty" yaml:"solution,omitempty"`                                       // Markdown from the task's "Solution" section
+	SolutionUnlockAfterAttempts int              `json:"solutionUnlockAfterAttempts,omitempty" yaml:"solutionUnlockAfterAttempts,omitempty"` // Attempts before the solution is shown, from the task's "Solution Unlock" section; 0 means 5
+}
 
+// TaskSummary previews a task without its validation rules or solution steps
+type TaskSummary struct {
+	ID               string `json:"id"`
+	Title            string `json:"title"`
+	Description      string `json:"description"`
+	Objective        string `json:"objective,omitempty"`
+	HintCount        int    `json:"hintCount"`
+	EstimatedMinutes int    `json:"estimatedMinutes,omitempty"`
 }
 
 type ValidationRule struct {
-	ID           string          `json:"id"`
-	Type         string          `json:"type"`
-	Description  string          `json:"description,omitempty"`
-	Resource     *ResourceTarget `json:"resource,omitempty"`
-	Command      *CommandTarget  `json:"command,omitempty"`
-	Script       *ScriptTarget   `json:"script,omitempty"`
-	File         *FileTarget     `json:"file,omitempty"`
-	Condition    string          `json:"condition"`
-	Value        interface{}     `json:"value"`
-	ErrorMessage string          `json:"errorMessage"`
+	ID             string                `json:"id"`
+	Type           string                `json:"type"`
+	Description    string                `json:"description,omitempty"`
+	Resource       *ResourceTarget       `json:"resource,omitempty"`
+	ResourceCount  *ResourceCountTarget  `json:"resourceCount,omitempty" yaml:"resourceCount,omitempty"`
+	Command        *CommandTarget        `json:"command,omitempty"`
+	Script         *ScriptTarget         `json:"script,omitempty"`
+	File           *FileTarget           `json:"file,omitempty"`
+	Admission      *AdmissionTarget      `json:"admission,omitempty"`
+	Certificate    *CertTarget           `json:"certificate,omitempty"`
+	Helm           *HelmTarget           `json:"helm,omitempty"`
+	Etcd           *EtcdTarget           `json:"etcd,omitempty"`
+	RBAC           *RBACTarget           `json:"rbac,omitempty"`
+	NetworkPolicy  *NetworkPolicyTarget  `json:"networkPolicy,omitempty" yaml:"networkPolicy,omitempty"`
+	ConfigMap      *ConfigMapTarget      `json:"configMap,omitempty" yaml:"configMap,omitempty"`
+	OPA            *OPATarget            `json:"opa,omitempty" yaml:"opa,omitempty"`
+	ServiceAccount *ServiceAccountTarget `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
+	PodSecurity    *PSATarget            `json:"podSecurity,omitempty" yaml:"podSecurity,omitempty"`
+	Condition      string                `json:"condition"`
+	Value          interface{}           `json:"value"`
+	ErrorMessage   string                `json:"errorMessage" yaml:"errorMessage"`
+	TimeoutSeconds int                   `json:"timeoutSeconds,omitempty" yaml:"timeoutSeconds,omitempty"` // 0 means no per-rule limit
+
+	// Re-run a failing rule, for state that converges asynchronously
+	RetryCount           int `json:"retryCount,omitempty" yaml:"retryCount,omitempty"`
+	RetryIntervalSeconds int `json:"retryIntervalSeconds,omitempty" yaml:"retryIntervalSeconds,omitempty"` // Defaults to 5
 }
 
 type ResourceTarget struct {
@@ -125,6 +379,88 @@ type ResourceTarget struct {
 	Property  string `json:"property,omitempty"`
 }
 
+// ResourceCountTarget selects a set of Kubernetes resources to be counted
+type ResourceCountTarget struct {
+	Kind          string `json:"kind"`
+	Namespace     string `json:"namespace"`
+	LabelSelector string `json:"labelSelector,omitempty" yaml:"labelSelector,omitempty"`
+}
+
+// AdmissionTarget names an API server admission plugin to check
+type AdmissionTarget struct {
+	PluginName string `json:"pluginName" yaml:"pluginName"`
+}
+
+// CertTarget describes an X.509 certificate on a VM and the properties expected of it.
+// Supported property keys are issuer, subject, san and not_after_days.
+type CertTarget struct {
+	Path       string            `json:"path"`
+	Target     string            `json:"target"` // "control-plane" or "worker"
+	Properties map[string]string `json:"properties"`
+}
+
+// HelmTarget identifies a Helm release and the chart it is expected to run
+type HelmTarget struct {
+	ReleaseName string `json:"releaseName" yaml:"releaseName"`
+	Namespace   string `json:"namespace"`
+	ChartName   string `json:"chartName,omitempty" yaml:"chartName,omitempty"`
+	Version     string `json:"version,omitempty"` // Chart version
+}
+
+// RBACTarget describes a permission check for a service account
+type RBACTarget struct {
+	Verb                    string `json:"verb"`
+	Resource                string `json:"resource"`
+	Namespace               string `json:"namespace,omitempty"` // Defaults to "default"
+	ServiceAccount          string `json:"serviceAccount" yaml:"serviceAccount"`
+	ServiceAccountNamespace string `json:"serviceAccountNamespace,omitempty" yaml:"serviceAccountNamespace,omitempty"` // Defaults to Namespace
+}
+
+// NetworkPolicyTarget describes a connection attempt from a pod, used to check that
+// NetworkPolicies allow or block the traffic
+type NetworkPolicyTarget struct {
+	SourceNamespace string `json:"sourceNamespace" yaml:"sourceNamespace"`
+	SourcePod       string `json:"sourcePod" yaml:"sourcePod"`
+	DestIP          string `json:"destIP" yaml:"destIP"` // IP address or DNS name
+	DestPort        int    `json:"destPort" yaml:"destPort"`
+	Protocol        string `json:"protocol,omitempty"` // "tcp" (default) or "udp"
+}
+
+// ConfigMapTarget identifies a key of a ConfigMap
+type ConfigMapTarget struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"` // Defaults to "default"
+	Key       string `json:"key"`
+}
+
+// OPATarget identifies a Gatekeeper constraint and the number of audit violations expected
+type OPATarget struct {
+	ConstraintKind          string `json:"constraintKind" yaml:"constraintKind"` // e.g. K8sRequiredLabels
+	ConstraintName          string `json:"constraintName" yaml:"constraintName"`
+	ViolationCountCondition string `json:"violationCountCondition" yaml:"violationCountCondition"` // "zero", "nonzero" or "gte"
+	ViolationCountValue     int    `json:"violationCountValue,omitempty" yaml:"violationCountValue,omitempty"`
+}
+
+// ServiceAccountTarget identifies a pod whose service account token mount is checked
+type ServiceAccountTarget struct {
+	PodName       string `json:"podName" yaml:"podName"`
+	Namespace     string `json:"namespace"`
+	ExpectMounted bool   `json:"expectMounted" yaml:"expectMounted"` // Used when the rule has no condition
+}
+
+// PSATarget identifies a namespace and the Pod Security Admission level expected for a mode
+type PSATarget struct {
+	Namespace string `json:"namespace"`
+	Level     string `json:"level"` // privileged, baseline or restricted
+	Mode      string `json:"mode"`  // enforce, audit or warn
+}
+
+// EtcdTarget identifies an etcd key and optionally a pattern its value must match
+type EtcdTarget struct {
+	Key          string `json:"key"`
+	ValuePattern string `json:"valuePattern,omitempty" yaml:"valuePattern,omitempty"` // Regular expression
+}
+
 type CommandTarget struct {
 	Command string `json:"command"`
 	Target  string `json:"target"` // "control-plane" or "worker"
@@ -133,7 +469,7 @@ type CommandTarget struct {
 type ScriptTarget struct {
 	Script      string `json:"script"`
 	Target      string `json:"target"`
-	SuccessCode int    `json:"successCode"`
+	SuccessCode int    `json:"successCode" yaml:"successCode"`
 }
 
 type FileTarget struct {
@@ -142,15 +478,16 @@ type FileTarget struct {
 }
 type SetupStep struct {
 	ID          string           `json:"id"`
-	Type        string           `json:"type"`   // "command", "resource", "script", "wait"
+	Type        string           `json:"type"`   // "command", "resource", "apply_manifest", "script", "wait", "wait_for_resource", "rollback_command"
 	Target      string           `json:"target"` // "control-plane", "worker", "both"
 	Description string           `json:"description"`
 	Command     string           `json:"command,omitempty"`
 	Script      string           `json:"script,omitempty"`
-	Resource    string           `json:"resource,omitempty"` // YAML content
+	Resource    string           `json:"resource,omitempty"` // YAML content (a text/template for "apply_manifest"), or a kubectl wait expression for "wait_for_resource"
 	Timeout     time.Duration    `json:"timeout"`
-	RetryCount  int              `json:"retryCount"`
+	RetryCount  int              `json:"retryCount" yaml:"retryCount"`
 	Conditions  []SetupCondition `json:"conditions,omitempty"`
+	Parallel    bool             `json:"parallel,omitempty"` // Run together with adjacent parallel steps
 }
 
 // TerminalSession represents a terminal session for a VM
@@ -164,7 +501,8 @@ type TerminalSession struct {
 
 // CreateSessionRequest represents a request to create a new session
 type CreateSessionRequest struct {
-	ScenarioID string `json:"scenarioId"`
+	ScenarioID string            `json:"scenarioId"`
+	Tags       map[string]string `json:"tags,omitempty"`
 }
 
 // CreateSessionResponse represents a response to a create session request
@@ -177,11 +515,13 @@ type CreateSessionResponse struct {
 type CreateTerminalRequest struct {
 	SessionID string `json:"sessionId"`
 	Target    string `json:"target"`
+	Type      string `json:"type,omitempty"` // "ssh" (default) or "console"
 }
 
 // CreateTerminalResponse represents a response to a create terminal request
 type CreateTerminalResponse struct {
 	TerminalID string `json:"terminalId"`
+	Type       string `json:"type"`
 }
 
 // ResizeTerminalRequest represents a request to resize a terminal
@@ -190,6 +530,13 @@ type ResizeTerminalRequest struct {
 	Cols uint16 `json:"cols"`
 }
 
+// BulkDeleteRequest selects sessions to delete by scenario and/or age
+type BulkDeleteRequest struct {
+	ScenarioID       string `json:"scenarioId,omitempty"`
+	OlderThanMinutes int    `json:"olderThanMinutes,omitempty"`
+	DryRun           bool   `json:"dryRun,omitempty"`
+}
+
 type SetupCondition struct {
 	Type     string        `json:"type"` // "resource_exists", "command_success", "pod_ready"
 	Resource string        `json:"resource,omitempty"`
@@ -218,7 +565,7 @@ type SnapshotInfo struct {
 
 // ClusterPool represents a managed cluster in the pool
 type ClusterPool struct {
-	ClusterID       string        `json:"clusterId"` // "cluster1", "cluster2", "cluster3"
+	ClusterID       string        `json:"clusterId"` // e.g. "cluster1"
 	Namespace       string        `json:"namespace"` // matches clusterID
 	Status          ClusterStatus `json:"status"`
 	AssignedSession string        `json:"assignedSession,omitempty"`
@@ -228,6 +575,7 @@ type ClusterPool struct {
 	WorkerNodeVM    string        `json:"workerNodeVM"`   // e.g., "wk-cluster1"
 	CreatedAt       time.Time     `json:"createdAt"`
 	LastHealthCheck time.Time     `json:"lastHealthCheck"`
+	ErrorSince      time.Time     `json:"errorSince,omitempty"`
 }
 
 // ClusterStatus represents the state of a cluster in the pool
@@ -241,6 +589,12 @@ const (
 	StatusCreating  ClusterStatus = "creating"  // Initial bootstrap in progress
 )
 
+// ClusterPoolDiagnostics combines pool-wide statistics with per-cluster detail
+type ClusterPoolDiagnostics struct {
+	ClusterPoolStats
+	Clusters []ClusterPool `json:"clusters"`
+}
+
 // ClusterPoolStats provides pool-wide statistics
 type ClusterPoolStats struct {
 	TotalClusters     int                      `json:"totalClusters"`
@@ -249,4 +603,8 @@ type ClusterPoolStats struct {
 	ResettingClusters int                      `json:"resettingClusters"`
 	ErrorClusters     int                      `json:"errorClusters"`
 	StatusByCluster   map[string]ClusterStatus `json:"statusByCluster"`
+	// LastHealthCheckTime is keyed by cluster ID
+	LastHealthCheckTime map[string]time.Time `json:"lastHealthCheckTime"`
+	// QueuedSessions lists the sessions waiting for a cluster, longest waiting first
+	QueuedSessions []string `json:"queuedSessions"`
 }
@@ -0,0 +1,175 @@
+// backend/internal/scenarios/archive.go - Export and import of scenarios as ZIP archives
+
+package scenarios
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MaxImportSize is the largest total uncompressed size accepted by ImportScenarios
+const MaxImportSize = 100 << 20
+
+// ErrInvalidArchive is returned by ImportScenarios when the archive is rejected before extraction
+var ErrInvalidArchive = errors.New("invalid scenario archive")
+
+// ExportScenario returns a ZIP archive of the scenario directory. Entries are stored under
+// "<id>/" so the archive can be passed back to ImportScenarios.
+func (sm *ScenarioManager) ExportScenario(id string) ([]byte, error) {
+	sm.scenarioMutex.RLock()
+	_, exists := sm.scenarios[id]
+	sm.scenarioMutex.RUnlock()
+	if !exists {
+		return nil, NewScenarioNotFoundError(id)
+	}
+
+	scenarioPath := filepath.Join(sm.scenariosDir, id)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	err := filepath.WalkDir(scenarioPath, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(sm.scenariosDir, filePath)
+		if err != nil {
+			return err
+		}
+
+		w, err := zw.Create(filepath.ToSlash(relPath))
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(w, f)
+		return err
+	})
+	if err != nil {
+		return nil, NewIOError("export", scenarioPath, err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, NewIOError("export", scenarioPath, err)
+	}
+
+	sm.logger.WithFields(logrus.Fields{
+		"scenarioID": id,
+		"size":       buf.Len(),
+	}).Info("Exported scenario")
+
+	return buf.Bytes(), nil
+}
+
+// ImportScenarios extracts a ZIP archive of one or more scenario directories into the
+// scenarios directory, overwriting existing files, and reloads the scenarios.
+// It returns the IDs of the imported scenario directories.
+func (sm *ScenarioManager) ImportScenarios(data []byte) ([]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+
+	// Check every entry before writing anything
+	var totalSize uint64
+	scenarioIDs := make(map[string]bool)
+	for _, file := range zr.File {
+		name := path.Clean(file.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") || strings.Contains(name, "\\") {
+			return nil, fmt.Errorf("%w: invalid path %s", ErrInvalidArchive, file.Name)
+		}
+
+		topLevel, rest, nested := strings.Cut(name, "/")
+		if !nested || rest == "" {
+			if file.FileInfo().IsDir() {
+				continue
+			}
+			return nil, fmt.Errorf("%w: file %s is not inside a scenario directory", ErrInvalidArchive, file.Name)
+		}
+		if strings.HasPrefix(topLevel, "_") || strings.HasPrefix(topLevel, ".") {
+			return nil, fmt.Errorf("%w: invalid scenario directory %s", ErrInvalidArchive, topLevel)
+		}
+		scenarioIDs[topLevel] = true
+
+		totalSize += file.UncompressedSize64
+		if totalSize > MaxImportSize {
+			return nil, fmt.Errorf("%w: exceeds the maximum uncompressed size of %d bytes", ErrInvalidArchive, MaxImportSize)
+		}
+	}
+
+	if len(scenarioIDs) == 0 {
+		return nil, fmt.Errorf("%w: no scenario directories found", ErrInvalidArchive)
+	}
+
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		target := filepath.Join(sm.scenariosDir, filepath.FromSlash(path.Clean(file.Name)))
+		if err := extractZipFile(file, target); err != nil {
+			return nil, NewIOError("import", target, err)
+		}
+	}
+
+	ids := make([]string, 0, len(scenarioIDs))
+	for id := range scenarioIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	sm.logger.WithField("scenarios", ids).Info("Imported scenarios")
+
+	if err := sm.ReloadScenarios(); err != nil {
+		return ids, fmt.Errorf("failed to reload scenarios: %w", err)
+	}
+
+	return ids, nil
+}
+
+// extractZipFile writes a single archive entry to target, creating parent directories
+func extractZipFile(file *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	r, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// The declared size was checked, but do not trust it while decompressing
+	if _, err := io.Copy(f, io.LimitReader(r, int64(file.UncompressedSize64))); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
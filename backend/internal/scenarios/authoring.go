@@ -0,0 +1,200 @@
+// backend/internal/scenarios/authoring.go - Creating scenarios from their JSON definition
+
+package scenarios
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// ErrScenarioExists is returned by CreateScenario when the scenario ID is already taken
+var ErrScenarioExists = errors.New("scenario already exists")
+
+var (
+	// scenarioIDPattern matches IDs usable as scenario directory names
+	scenarioIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	// taskIDPattern matches the task IDs loadTasks reads from NN-task.md file names
+	taskIDPattern = regexp.MustCompile(`^\d+$`)
+	// stepPrefixPattern matches the numbered or bulleted line parseSteps starts a new step at
+	stepPrefixPattern = regexp.MustCompile(`^(\d+\.|-)`)
+)
+
+// CreateScenario writes a scenario to the scenarios directory in the layout loadScenario
+// reads: metadata.yaml, tasks/NN-task.md, validation/NN-validation.yaml and setup/init.yaml.
+// The files are written to a hidden directory first, so a failed write leaves no partial
+// scenario behind. The scenarios are reloaded afterwards.
+func (sm *ScenarioManager) CreateScenario(scenario *models.Scenario) error {
+	if !scenarioIDPattern.MatchString(scenario.ID) {
+		return NewScenarioInvalidError(scenario.ID, "id must contain only lowercase letters, digits and dashes")
+	}
+	if err := sm.validateScenarioMetadata(scenario); err != nil {
+		return NewScenarioInvalidError(scenario.ID, err.Error())
+	}
+	seen := make(map[string]bool, len(scenario.Tasks))
+	for _, task := range scenario.Tasks {
+		if !taskIDPattern.MatchString(task.ID) {
+			return NewScenarioInvalidError(scenario.ID, fmt.Sprintf("task id %q must be numeric, e.g. \"01\"", task.ID))
+		}
+		if seen[task.ID] {
+			return NewScenarioInvalidError(scenario.ID, fmt.Sprintf("duplicate task id %q", task.ID))
+		}
+		seen[task.ID] = true
+	}
+
+	sm.scenarioMutex.RLock()
+	_, loaded := sm.scenarios[scenario.ID]
+	sm.scenarioMutex.RUnlock()
+	scenarioPath := filepath.Join(sm.scenariosDir, scenario.ID)
+	if _, err := os.Stat(scenarioPath); loaded || err == nil {
+		return fmt.Errorf("%w: %s", ErrScenarioExists, scenario.ID)
+	}
+
+	tmpPath, err := os.MkdirTemp(sm.scenariosDir, ".create-"+scenario.ID+"-")
+	if err != nil {
+		return NewIOError("create", sm.scenariosDir, err)
+	}
+	defer os.RemoveAll(tmpPath)
+
+	if err := writeScenarioFiles(tmpPath, scenario); err != nil {
+		return NewIOError("write", scenarioPath, err)
+	}
+	if err := os.Chmod(tmpPath, 0755); err != nil {
+		return NewIOError("write", scenarioPath, err)
+	}
+	if err := os.Rename(tmpPath, scenarioPath); err != nil {
+		return NewIOError("write", scenarioPath, err)
+	}
+
+	sm.logger.WithFields(logrus.Fields{
+		"scenarioID": scenario.ID,
+		"tasks":      len(scenario.Tasks),
+	}).Info("Created scenario")
+
+	if err := sm.ReloadScenarios(); err != nil {
+		return fmt.Errorf("failed to reload scenarios: %w", err)
+	}
+	return nil
+}
+
+// writeScenarioFiles writes the files of a scenario into dir
+func writeScenarioFiles(dir string, scenario *models.Scenario) error {
+	// Tasks and setup steps have files of their own
+	metadata := *scenario
+	metadata.Tasks = nil
+	metadata.SetupSteps = nil
+	metadata.Rollback = nil
+	if err := writeYAMLFile(filepath.Join(dir, "metadata.yaml"), metadata); err != nil {
+		return err
+	}
+
+	for _, task := range scenario.Tasks {
+		taskPath := filepath.Join(dir, "tasks", task.ID+"-task.md")
+		if err := writeFile(taskPath, []byte(renderTaskMarkdown(task))); err != nil {
+			return err
+		}
+
+		if len(task.Validation) > 0 {
+			validationPath := filepath.Join(dir, "validation", task.ID+"-validation.yaml")
+			validation := struct {
+				Validation []models.ValidationRule `yaml:"validation"`
+			}{task.Validation}
+			if err := writeYAMLFile(validationPath, validation); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(scenario.SetupSteps) > 0 || len(scenario.Rollback) > 0 {
+		setup := struct {
+			Steps    []models.SetupStep `yaml:"steps"`
+			Rollback []models.SetupStep `yaml:"rollback,omitempty"`
+		}{scenario.SetupSteps, scenario.Rollback}
+		if err := writeYAMLFile(filepath.Join(dir, "setup", "init.yaml"), setup); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderTaskMarkdown renders a task in the markdown format parseTaskMarkdown reads
+func renderTaskMarkdown(task models.Task) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n", task.Title)
+	writeSection(&b, "Description", task.Description)
+	writeSection(&b, "Objectives", task.Objective)
+
+	if len(task.Steps) > 0 {
+		steps := make([]string, 0, len(task.Steps))
+		for i, step := range task.Steps {
+			if !stepPrefixPattern.MatchString(step) {
+				step = fmt.Sprintf("%d. %s", i+1, step)
+			}
+			steps = append(steps, step)
+		}
+		writeSection(&b, "Step-by-Step Guide", strings.Join(steps, "\n"))
+	}
+
+	if len(task.Hints) > 0 {
+		hints := make([]string, 0, len(task.Hints))
+		for _, hint := range task.Hints {
+			hints = append(hints, fmt.Sprintf("<details>\n<summary>%s</summary>\n</details>", hint))
+		}
+		writeSection(&b, "Hints", strings.Join(hints, "\n\n"))
+	}
+
+	if len(task.HintUnlockAfterAttempts) > 0 {
+		thresholds := make([]string, 0, len(task.HintUnlockAfterAttempts))
+		for _, threshold := range task.HintUnlockAfterAttempts {
+			thresholds = append(thresholds, fmt.Sprint(threshold))
+		}
+		writeSection(&b, "Hint Unlock", strings.Join(thresholds, ", "))
+	}
+
+	writeSection(&b, "Solution", task.Solution)
+	if task.SolutionUnlockAfterAttempts > 0 {
+		writeSection(&b, "Solution Unlock", fmt.Sprint(task.SolutionUnlockAfterAttempts))
+	}
+
+	if task.TimeEstimateSeconds > 0 {
+		writeSection(&b, "Time Estimate", (time.Duration(task.TimeEstimateSeconds) * time.Second).String())
+	}
+
+	return b.String()
+}
+
+// writeSection writes a "## title" section; empty sections are left out
+func writeSection(b *strings.Builder, title, content string) {
+	if strings.TrimSpace(content) == "" {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n%s\n", title, content)
+}
+
+// writeYAMLFile writes v as YAML to path, creating parent directories
+func writeYAMLFile(path string, v interface{}) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filepath.Base(path), err)
+	}
+	return writeFile(path, data)
+}
+
+// writeFile writes data to path, creating parent directories
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,113 @@
+// backend/internal/scenarios/difficulty_votes.go - Difficulty ratings submitted by users
+
+package scenarios
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// Bounds of a difficulty rating
+const (
+	MinDifficultyRating = 1
+	MaxDifficultyRating = 5
+)
+
+var (
+	// ErrInvalidDifficultyRating is returned for a rating outside MinDifficultyRating..MaxDifficultyRating
+	ErrInvalidDifficultyRating = errors.New("difficulty rating must be between 1 and 5")
+	// ErrScenarioNotCompleted is returned when the rating session has not completed the scenario
+	ErrScenarioNotCompleted = errors.New("session has not completed the scenario")
+)
+
+// DifficultyVoteStore holds the difficulty ratings of each scenario in memory. A session
+// rates a scenario once; rating again replaces its earlier rating.
+type DifficultyVoteStore struct {
+	votes map[string]map[string]int // Scenario ID -> session ID -> rating
+	mutex sync.RWMutex
+}
+
+// NewDifficultyVoteStore creates an empty vote store
+func NewDifficultyVoteStore() *DifficultyVoteStore {
+	return &DifficultyVoteStore{
+		votes: make(map[string]map[string]int),
+	}
+}
+
+// Add records the rating a session gave a scenario
+func (s *DifficultyVoteStore) Add(scenarioID, sessionID string, rating int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.votes[scenarioID] == nil {
+		s.votes[scenarioID] = make(map[string]int)
+	}
+	s.votes[scenarioID][sessionID] = rating
+}
+
+// Average returns the average rating of a scenario and the number of ratings
+func (s *DifficultyVoteStore) Average(scenarioID string) (float64, int) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	votes := s.votes[scenarioID]
+	if len(votes) == 0 {
+		return 0, 0
+	}
+	total := 0
+	for _, rating := range votes {
+		total += rating
+	}
+	return float64(total) / float64(len(votes)), len(votes)
+}
+
+// RateDifficulty records a user's difficulty rating of a scenario. Only sessions that
+// completed every task of the scenario since the server started may rate it.
+func (sm *ScenarioManager) RateDifficulty(scenarioID, sessionID string, rating int) error {
+	if _, err := sm.GetScenario(scenarioID); err != nil {
+		return err
+	}
+	if rating < MinDifficultyRating || rating > MaxDifficultyRating {
+		return ErrInvalidDifficultyRating
+	}
+
+	sm.statsMutex.RLock()
+	stats, ok := sm.stats[scenarioID]
+	completed := ok && stats.completed[sessionID]
+	sm.statsMutex.RUnlock()
+	if !completed {
+		return fmt.Errorf("%w: %s", ErrScenarioNotCompleted, sessionID)
+	}
+
+	sm.difficultyVotes.Add(scenarioID, sessionID, rating)
+
+	sm.logger.WithFields(logrus.Fields{
+		"scenarioID": scenarioID,
+		"sessionID":  sessionID,
+		"rating":     rating,
+	}).Info("Scenario difficulty rated")
+
+	return nil
+}
+
+// GetDifficultyRating returns the author's difficulty of a scenario next to the average user rating
+func (sm *ScenarioManager) GetDifficultyRating(scenarioID string) (models.DifficultyRating, error) {
+	scenario, err := sm.GetScenario(scenarioID)
+	if err != nil {
+		return models.DifficultyRating{}, err
+	}
+
+	average, count := sm.difficultyVotes.Average(scenarioID)
+	return models.DifficultyRating{
+		ScenarioID:       scenarioID,
+		AuthorDifficulty: scenario.Difficulty,
+		CommunityAverage: math.Round(average*10) / 10,
+		VoteCount:        count,
+	}, nil
+}
@@ -2,7 +2,10 @@
 
 package scenarios
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // Error types for scenario management
 type ScenarioError struct {
@@ -31,19 +34,47 @@ const (
 	ErrTypeIO             = "IO_ERROR"
 )
 
+// ScenarioNotFoundError is returned when no scenario has the requested ID
+type ScenarioNotFoundError struct {
+	ID string
+}
+
+func (e *ScenarioNotFoundError) Error() string {
+	return fmt.Sprintf("%s: scenario not found: %s", ErrTypeNotFound, e.ID)
+}
+
+// ScenarioInvalidError is returned when a scenario exists but its definition is invalid
+type ScenarioInvalidError struct {
+	ID     string
+	Reason string
+}
+
+func (e *ScenarioInvalidError) Error() string {
+	return fmt.Sprintf("%s: scenario %s is invalid: %s", ErrTypeInvalid, e.ID, e.Reason)
+}
+
+// ScenarioIOError is returned when reading or writing scenario files fails
+type ScenarioIOError struct {
+	Op   string
+	Path string
+	Err  error
+}
+
+func (e *ScenarioIOError) Error() string {
+	return fmt.Sprintf("%s: IO error during %s on %s: %v", ErrTypeIO, e.Op, e.Path, e.Err)
+}
+
+func (e *ScenarioIOError) Unwrap() error {
+	return e.Err
+}
+
 // Error constructors
-func NewScenarioNotFoundError(id string) *ScenarioError {
-	return &ScenarioError{
-		Type:    ErrTypeNotFound,
-		Message: fmt.Sprintf("scenario not found: %s", id),
-	}
+func NewScenarioNotFoundError(id string) *ScenarioNotFoundError {
+	return &ScenarioNotFoundError{ID: id}
 }
 
-func NewScenarioInvalidError(id string, reason string) *ScenarioError {
-	return &ScenarioError{
-		Type:    ErrTypeInvalid,
-		Message: fmt.Sprintf("scenario %s is invalid: %s", id, reason),
-	}
+func NewScenarioInvalidError(id string, reason string) *ScenarioInvalidError {
+	return &ScenarioInvalidError{ID: id, Reason: reason}
 }
 
 func NewValidationError(taskID string, err error) *ScenarioError {
@@ -62,32 +93,27 @@ func NewInitializationError(scenarioID string, err error) *ScenarioError {
 	}
 }
 
-func NewIOError(operation string, path string, err error) *ScenarioError {
-	return &ScenarioError{
-		Type:    ErrTypeIO,
-		Message: fmt.Sprintf("IO error during %s on %s", operation, path),
-		Err:     err,
-	}
+func NewIOError(operation string, path string, err error) *ScenarioIOError {
+	return &ScenarioIOError{Op: operation, Path: path, Err: err}
 }
 
 // Helper to check error types
 func IsNotFoundError(err error) bool {
-	if se, ok := err.(*ScenarioError); ok {
-		return se.Type == ErrTypeNotFound
-	}
-	return false
+	var notFound *ScenarioNotFoundError
+	return errors.As(err, &notFound)
+}
+
+func IsInvalidError(err error) bool {
+	var invalid *ScenarioInvalidError
+	return errors.As(err, &invalid)
 }
 
 func IsValidationError(err error) bool {
-	if se, ok := err.(*ScenarioError); ok {
-		return se.Type == ErrTypeValidation
-	}
-	return false
+	var se *ScenarioError
+	return errors.As(err, &se) && se.Type == ErrTypeValidation
 }
 
 func IsInitializationError(err error) bool {
-	if se, ok := err.(*ScenarioError); ok {
-		return se.Type == ErrTypeInitialization
-	}
-	return false
+	var se *ScenarioError
+	return errors.As(err, &se) && se.Type == ErrTypeInitialization
 }
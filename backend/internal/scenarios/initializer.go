@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fullstack-pw/cks/backend/internal/kubevirt"
@@ -15,12 +17,26 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+// setupRetryBaseDelay is the initial delay between setup step retries; it doubles on each attempt
+const setupRetryBaseDelay = 5 * time.Second
+
+// defaultWaitTimeout is used by wait_for_resource steps that do not set a timeout
+const defaultWaitTimeout = 5 * time.Minute
+
+// rollbackStepTimeout bounds each rollback step run after a failed initialization
+const rollbackStepTimeout = 60 * time.Second
+
 type ScenarioInitializer struct {
 	kubeClient     kubernetes.Interface
 	kubevirtClient *kubevirt.Client
+	onProgress     SetupProgressFunc
 	logger         *logrus.Logger
 }
 
+// SetupProgressFunc is called when a setup step starts. current counts from 1 and
+// rollback_command steps, which only run on failure, are not counted.
+type SetupProgressFunc func(current, total int, description string)
+
 func NewScenarioInitializer(kubeClient kubernetes.Interface, kubevirtClient *kubevirt.Client, logger *logrus.Logger) *ScenarioInitializer {
 	return &ScenarioInitializer{
 		kubeClient:     kubeClient,
@@ -29,6 +45,11 @@ func NewScenarioInitializer(kubeClient kubernetes.Interface, kubevirtClient *kub
 	}
 }
 
+// OnProgress registers a function called as each setup step starts
+func (si *ScenarioInitializer) OnProgress(fn SetupProgressFunc) {
+	si.onProgress = fn
+}
+
 func (si *ScenarioInitializer) InitializeScenario(ctx context.Context, session *models.Session, scenario *models.Scenario) error {
 	si.logger.WithFields(logrus.Fields{
 		"sessionID":  session.ID,
@@ -41,51 +62,211 @@ func (si *ScenarioInitializer) InitializeScenario(ctx context.Context, session *
 		return fmt.Errorf("failed to load setup steps: %w", err)
 	}
 
+	// rollback_command steps are only run on failure, undoing the steps before them
+	var rollbackSteps []models.SetupStep
+
+	// Number the steps that run for progress reporting
+	total := 0
+	numbers := make([]int, len(setupSteps))
+	for i, step := range setupSteps {
+		if step.Type != "rollback_command" {
+			total++
+			numbers[i] = total
+		}
+	}
+	reportStart := func(i int) {
+		if si.onProgress == nil {
+			return
+		}
+		description := setupSteps[i].Description
+		if description == "" {
+			description = setupSteps[i].ID
+		}
+		si.onProgress(numbers[i], total, description)
+	}
+
+	// Consecutive parallel steps are collected and run together before the next serial step
+	var batch []int
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := si.runParallelSteps(ctx, session, setupSteps, batch, reportStart)
+		batch = nil
+		return err
+	}
+
 	// Execute each setup step
 	for i, step := range setupSteps {
-		si.logger.WithField("step", step.ID).Infof("Executing setup step %d/%d", i+1, len(setupSteps))
+		if step.Type == "rollback_command" {
+			rollbackSteps = append(rollbackSteps, step)
+			continue
+		}
 
-		err := si.executeSetupStep(ctx, session, step)
+		if step.Parallel {
+			batch = append(batch, i)
+			continue
+		}
+
+		err := flush()
+		if err == nil {
+			si.logger.WithField("step", step.ID).Infof("Executing setup step %d/%d", numbers[i], total)
+			reportStart(i)
+			err = si.runSetupStep(ctx, session, step)
+		}
 		if err != nil {
-			// Retry logic
-			for retry := 0; retry < step.RetryCount; retry++ {
-				si.logger.WithError(err).Warnf("Setup step failed, retry %d/%d", retry+1, step.RetryCount)
-				time.Sleep(5 * time.Second)
+			si.rollback(ctx, session, append(rollbackSteps, scenario.Rollback...))
+			return err
+		}
+	}
 
-				err = si.executeSetupStep(ctx, session, step)
-				if err == nil {
-					break
-				}
+	if err := flush(); err != nil {
+		si.rollback(ctx, session, append(rollbackSteps, scenario.Rollback...))
+		return err
+	}
+
+	si.logger.WithField("sessionID", session.ID).Info("Scenario initialization completed")
+	return nil
+}
+
+// runSetupStep executes a step with retries and waits for its conditions
+func (si *ScenarioInitializer) runSetupStep(ctx context.Context, session *models.Session, step models.SetupStep) error {
+	err := si.executeSetupStep(ctx, session, step)
+	if err != nil {
+		// Retry logic with exponential backoff
+		for retry := 0; retry < step.RetryCount; retry++ {
+			delay := setupRetryBaseDelay * time.Duration(1<<retry)
+			si.logger.WithError(err).WithField("delay", delay).Warnf("Setup step failed, retry %d/%d", retry+1, step.RetryCount)
+
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("setup step %s cancelled: %w", step.ID, ctx.Err())
+			case <-time.After(delay):
 			}
 
-			if err != nil {
-				return fmt.Errorf("setup step %s failed: %w", step.ID, err)
+			err = si.executeSetupStep(ctx, session, step)
+			if err == nil {
+				break
 			}
 		}
 
-		// Wait for conditions
-		if len(step.Conditions) > 0 {
-			err = si.waitForConditions(ctx, session, step)
-			if err != nil {
-				return fmt.Errorf("conditions not met for step %s: %w", step.ID, err)
+		if err != nil {
+			return fmt.Errorf("setup step %s failed: %w", step.ID, err)
+		}
+	}
+
+	// Wait for conditions
+	if len(step.Conditions) > 0 {
+		if err := si.waitForConditions(ctx, session, step); err != nil {
+			return fmt.Errorf("conditions not met for step %s: %w", step.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// runParallelSteps runs the steps at the given indexes concurrently, calling onStart with each
+// index as its step is launched. The first failure cancels the other steps of the batch and is
+// returned; results are logged in step order.
+func (si *ScenarioInitializer) runParallelSteps(ctx context.Context, session *models.Session, steps []models.SetupStep, indexes []int, onStart func(int)) error {
+	batchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, len(indexes))
+	firstFailed := -1
+	var (
+		wg       sync.WaitGroup
+		failOnce sync.Once
+	)
+
+	for n, i := range indexes {
+		si.logger.WithField("step", steps[i].ID).Info("Executing setup step in parallel")
+		onStart(i)
+
+		wg.Add(1)
+		go func(n int, step models.SetupStep) {
+			defer wg.Done()
+
+			if err := si.runSetupStep(batchCtx, session, step); err != nil {
+				errs[n] = err
+				failOnce.Do(func() {
+					firstFailed = n
+					cancel()
+				})
 			}
+		}(n, steps[i])
+	}
+	wg.Wait()
+
+	for n, i := range indexes {
+		entry := si.logger.WithFields(logrus.Fields{
+			"sessionID": session.ID,
+			"step":      steps[i].ID,
+		})
+		switch {
+		case errs[n] == nil:
+			entry.Info("Parallel setup step completed")
+		case n == firstFailed:
+			entry.WithError(errs[n]).Error("Parallel setup step failed")
+		default:
+			entry.WithError(errs[n]).Warn("Parallel setup step did not complete after the batch was cancelled")
 		}
 	}
 
-	si.logger.WithField("sessionID", session.ID).Info("Scenario initialization completed")
+	if firstFailed >= 0 {
+		return errs[firstFailed]
+	}
 	return nil
 }
 
+// rollback runs cleanup steps in reverse order after a failed initialization.
+// Failures are logged and do not stop the remaining steps.
+func (si *ScenarioInitializer) rollback(ctx context.Context, session *models.Session, steps []models.SetupStep) {
+	if len(steps) == 0 {
+		return
+	}
+
+	si.logger.WithFields(logrus.Fields{
+		"sessionID": session.ID,
+		"stepCount": len(steps),
+	}).Warn("Setup failed, running rollback steps")
+
+	// Rollback must still run when initialization failed because ctx was cancelled
+	baseCtx := context.WithoutCancel(ctx)
+
+	for i := len(steps) - 1; i >= 0; i-- {
+		step := steps[i]
+
+		stepCtx, cancel := context.WithTimeout(baseCtx, rollbackStepTimeout)
+		err := si.executeSetupStep(stepCtx, session, step)
+		cancel()
+
+		logger := si.logger.WithFields(logrus.Fields{
+			"sessionID": session.ID,
+			"step":      step.ID,
+		})
+		if err != nil {
+			logger.WithError(err).Warn("Rollback step failed")
+		} else {
+			logger.Warn("Rollback step completed")
+		}
+	}
+}
+
 func (si *ScenarioInitializer) executeSetupStep(ctx context.Context, session *models.Session, step models.SetupStep) error {
 	switch step.Type {
-	case "command":
+	case "command", "rollback_command":
 		return si.executeCommand(ctx, session, step)
 	case "resource":
 		return si.createResource(ctx, session, step)
+	case "apply_manifest":
+		return si.executeApplyManifestStep(ctx, session, step)
 	case "script":
 		return si.executeScript(ctx, session, step)
 	case "wait":
 		return si.waitForDuration(ctx, step)
+	case "wait_for_resource":
+		return si.executeWaitStep(ctx, session, step)
 	default:
 		return fmt.Errorf("unknown setup step type: %s", step.Type)
 	}
@@ -135,6 +316,46 @@ func (si *ScenarioInitializer) createResource(ctx context.Context, session *mode
 	return nil
 }
 
+// executeWaitStep blocks until a resource reaches a condition using kubectl wait,
+// e.g. resource "deployment/nginx --for=condition=available"
+func (si *ScenarioInitializer) executeWaitStep(ctx context.Context, session *models.Session, step models.SetupStep) error {
+	if strings.TrimSpace(step.Resource) == "" {
+		return fmt.Errorf("wait_for_resource step %s has no resource expression", step.ID)
+	}
+
+	timeout := step.Timeout
+	if timeout <= 0 {
+		timeout = defaultWaitTimeout
+	}
+
+	// Give the command a little longer than kubectl's own timeout so its exit code is reported
+	waitCtx, cancel := context.WithTimeout(ctx, timeout+30*time.Second)
+	defer cancel()
+
+	cmd := fmt.Sprintf("kubectl wait %s --timeout=%ds 2>&1; echo $?", step.Resource, int(timeout.Seconds()))
+	output, err := si.kubevirtClient.ExecuteCommandInVM(waitCtx, session.Namespace, session.ControlPlaneVM, cmd)
+	if err != nil {
+		return fmt.Errorf("kubectl wait failed for %s: %w", step.Resource, err)
+	}
+
+	stdout, exitCode, err := splitExitCode(output)
+	if err != nil {
+		return err
+	}
+
+	if exitCode != 0 {
+		return fmt.Errorf("kubectl wait for %s exited with code %d: %s", step.Resource, exitCode, strings.TrimSpace(stdout))
+	}
+
+	si.logger.WithFields(logrus.Fields{
+		"step":     step.ID,
+		"resource": step.Resource,
+		"output":   stdout,
+	}).Debug("Resource reached expected condition")
+
+	return nil
+}
+
 func (si *ScenarioInitializer) waitForConditions(ctx context.Context, session *models.Session, step models.SetupStep) error {
 	timeout := step.Timeout
 	if timeout == 0 {
@@ -188,8 +409,7 @@ func (si *ScenarioInitializer) getTargetVMs(session *models.Session, target stri
 	}
 }
 
-// Add these methods to ScenarioInitializer
-
+// loadSetupSteps returns the setup steps of a scenario, reading setup/init.yaml when they are not loaded yet
 func (si *ScenarioInitializer) loadSetupSteps(scenario *models.Scenario) ([]models.SetupStep, error) {
 	// If setup steps are already in the scenario, return them
 	if len(scenario.SetupSteps) > 0 {
@@ -227,34 +447,89 @@ func (si *ScenarioInitializer) executeScript(ctx context.Context, session *model
 	targets := si.getTargetVMs(session, step.Target)
 
 	for _, target := range targets {
-		// Create temporary script file
-		scriptFile := fmt.Sprintf("/tmp/setup-%s-%s.sh", session.ID, step.ID)
-
-		// Write script to file
-		cmd := fmt.Sprintf("cat > %s << 'EOF'\n%s\nEOF\nchmod +x %s", scriptFile, step.Script, scriptFile)
-		_, err := si.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, target, cmd)
-		if err != nil {
-			return fmt.Errorf("failed to create script file: %w", err)
+		if err := si.runScriptOnVM(ctx, session, step, target); err != nil {
+			return err
 		}
+	}
 
-		// Execute script
-		output, err := si.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, target, fmt.Sprintf("bash %s", scriptFile))
-		if err != nil {
-			return fmt.Errorf("script execution failed: %w, output: %s", err, output)
+	return nil
+}
+
+// runScriptOnVM copies the step script to the VM, executes it and checks its exit code
+func (si *ScenarioInitializer) runScriptOnVM(ctx context.Context, session *models.Session, step models.SetupStep, target string) error {
+	// Apply per-step timeout
+	if step.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, step.Timeout)
+		defer cancel()
+	}
+
+	scriptFile := fmt.Sprintf("/tmp/setup-%s-%s.sh", session.ID, step.ID)
+	stderrFile := scriptFile + ".stderr"
+
+	// Write script to file
+	cmd := fmt.Sprintf("cat > %s << 'EOF'\n%s\nEOF\nchmod +x %s", scriptFile, step.Script, scriptFile)
+	_, err := si.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, target, cmd)
+	if err != nil {
+		return fmt.Errorf("failed to create script file on %s: %w", target, err)
+	}
+
+	// Cleanup with a fresh context so it still runs after a timeout
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		si.kubevirtClient.ExecuteCommandInVM(cleanupCtx, session.Namespace, target, fmt.Sprintf("rm -f %s %s", scriptFile, stderrFile))
+	}()
+
+	// Execute script, keeping stderr aside and printing the exit code last
+	output, err := si.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, target, fmt.Sprintf("%s 2>%s; echo $?", scriptFile, stderrFile))
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("script timed out after %s on %s: %w", step.Timeout, target, err)
 		}
+		return fmt.Errorf("script execution failed on %s: %w", target, err)
+	}
 
-		// Cleanup
-		si.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, target, fmt.Sprintf("rm %s", scriptFile))
+	stdout, exitCode, err := splitExitCode(output)
+	if err != nil {
+		return fmt.Errorf("script execution failed on %s: %w", target, err)
+	}
 
-		si.logger.WithFields(logrus.Fields{
-			"target": target,
-			"output": output,
-		}).Debug("Script executed")
+	stderr, _ := si.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, target, fmt.Sprintf("cat %s", stderrFile))
+
+	si.logger.WithFields(logrus.Fields{
+		"step":     step.ID,
+		"target":   target,
+		"exitCode": exitCode,
+		"stdout":   stdout,
+		"stderr":   stderr,
+	}).Debug("Script executed")
+
+	if exitCode != 0 {
+		return fmt.Errorf("script exited with code %d on %s, stderr: %s", exitCode, target, strings.TrimSpace(stderr))
 	}
 
 	return nil
 }
 
+// splitExitCode separates script stdout from the exit code echoed on the last line
+func splitExitCode(output string) (string, int, error) {
+	output = strings.TrimRight(output, "\n")
+	idx := strings.LastIndex(output, "\n")
+
+	stdout, exitCodeStr := "", output
+	if idx >= 0 {
+		stdout, exitCodeStr = output[:idx], output[idx+1:]
+	}
+
+	exitCode, err := strconv.Atoi(strings.TrimSpace(exitCodeStr))
+	if err != nil {
+		return stdout, 0, fmt.Errorf("failed to parse exit code from %q: %w", exitCodeStr, err)
+	}
+
+	return stdout, exitCode, nil
+}
+
 func (si *ScenarioInitializer) waitForDuration(ctx context.Context, step models.SetupStep) error {
 	si.logger.WithField("duration", step.Timeout).Info("Waiting for duration")
 
@@ -0,0 +1,242 @@
+// backend/internal/scenarios/lint.go - Offline checks for scenario authors
+
+package scenarios
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+	"github.com/fullstack-pw/cks/backend/internal/validation"
+)
+
+// setupStepTypes lists the step types understood by ScenarioInitializer
+var setupStepTypes = map[string]bool{
+	"command":           true,
+	"resource":          true,
+	"apply_manifest":    true,
+	"script":            true,
+	"wait":              true,
+	"wait_for_resource": true,
+	"rollback_command":  true,
+}
+
+// setupConditionTypes lists the condition types understood by ScenarioInitializer.checkCondition
+var setupConditionTypes = map[string]bool{
+	"resource_exists": true,
+	"command_success": true,
+	"pod_ready":       true,
+}
+
+// LintScenario checks a scenario directory the same way the server would load it and
+// returns every problem found instead of stopping at the first one
+func LintScenario(scenarioPath string, logger *logrus.Logger) []error {
+	sm := &ScenarioManager{logger: logger}
+	var problems []error
+	report := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	if err := NewStructureValidator(scenarioPath).Validate(); err != nil {
+		report("structure: %v", err)
+	}
+
+	// Metadata
+	var scenario models.Scenario
+	metadataContent, err := os.ReadFile(filepath.Join(scenarioPath, "metadata.yaml"))
+	if err != nil {
+		report("metadata.yaml: %v", err)
+	} else if err := yaml.Unmarshal(metadataContent, &scenario); err != nil {
+		report("metadata.yaml: invalid YAML: %v", err)
+	} else if err := sm.validateScenarioMetadata(&scenario); err != nil {
+		report("metadata.yaml: %v", err)
+	}
+
+	// Prerequisites must reference sibling scenarios
+	scenarioID := filepath.Base(filepath.Clean(scenarioPath))
+	for _, prerequisite := range scenario.Prerequisites {
+		if prerequisite == scenarioID || prerequisite == scenario.ID {
+			report("metadata.yaml: scenario lists itself as a prerequisite")
+			continue
+		}
+		if !fileExists(filepath.Join(filepath.Dir(filepath.Clean(scenarioPath)), prerequisite, "metadata.yaml")) {
+			report("metadata.yaml: prerequisite %q does not exist", prerequisite)
+		}
+	}
+
+	// Tasks and their validation rules
+	taskPattern := regexp.MustCompile(`^(\d+)-task\.md$`)
+	entries, err := os.ReadDir(filepath.Join(scenarioPath, "tasks"))
+	if err != nil && !os.IsNotExist(err) {
+		report("tasks: %v", err)
+	}
+
+	for _, entry := range entries {
+		matches := taskPattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || matches == nil {
+			continue
+		}
+		taskID := matches[1]
+		taskFile := filepath.Join("tasks", entry.Name())
+
+		content, err := os.ReadFile(filepath.Join(scenarioPath, taskFile))
+		if err != nil {
+			report("%s: %v", taskFile, err)
+			continue
+		}
+
+		task, err := sm.parseTaskMarkdown(taskID, string(content))
+		if err != nil {
+			report("%s: %v", taskFile, err)
+			continue
+		}
+		if !strings.HasPrefix(string(content), "# ") {
+			report("%s: missing H1 title", taskFile)
+		}
+		if task.Description == "" {
+			report("%s: missing \"## Description\" section", taskFile)
+		}
+
+		problems = append(problems, lintValidationFile(scenarioPath, taskID)...)
+	}
+
+	// Setup steps
+	problems = append(problems, lintSetupFile(scenarioPath)...)
+
+	return problems
+}
+
+// lintValidationFile checks every rule in a task's validation file
+func lintValidationFile(scenarioPath, taskID string) []error {
+	validationFile := filepath.Join("validation", fmt.Sprintf("%s-validation.yaml", taskID))
+
+	content, err := os.ReadFile(filepath.Join(scenarioPath, validationFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return []error{fmt.Errorf("%s: %v", validationFile, err)}
+	}
+
+	var validationSpec struct {
+		Validation []models.ValidationRule `yaml:"validation"`
+	}
+	if err := yaml.Unmarshal(content, &validationSpec); err != nil {
+		return []error{fmt.Errorf("%s: invalid YAML: %v", validationFile, err)}
+	}
+
+	var problems []error
+	seen := make(map[string]bool)
+	for i, rule := range validationSpec.Validation {
+		if err := validation.ValidateRule(rule); err != nil {
+			problems = append(problems, fmt.Errorf("%s: rule %d (%s): %v", validationFile, i+1, rule.ID, err))
+		}
+		if rule.ID != "" && seen[rule.ID] {
+			problems = append(problems, fmt.Errorf("%s: duplicate rule id %q", validationFile, rule.ID))
+		}
+		seen[rule.ID] = true
+	}
+
+	return problems
+}
+
+// lintSetupFile checks the schema of setup/init.yaml
+func lintSetupFile(scenarioPath string) []error {
+	setupFile := filepath.Join("setup", "init.yaml")
+
+	content, err := os.ReadFile(filepath.Join(scenarioPath, setupFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return []error{fmt.Errorf("%s: %v", setupFile, err)}
+	}
+
+	var setup struct {
+		Steps    []models.SetupStep `yaml:"steps"`
+		Rollback []models.SetupStep `yaml:"rollback"`
+	}
+	if err := yaml.Unmarshal(content, &setup); err != nil {
+		return []error{fmt.Errorf("%s: invalid YAML: %v", setupFile, err)}
+	}
+
+	problems := lintSetupSteps(setupFile+": step", setup.Steps)
+	problems = append(problems, lintSetupSteps(setupFile+": rollback step", setup.Rollback)...)
+
+	return problems
+}
+
+// lintSetupSteps checks a list of setup steps, prefixing problems with label and the step position
+func lintSetupSteps(label string, steps []models.SetupStep) []error {
+	var problems []error
+	report := func(i int, step models.SetupStep, format string, args ...interface{}) {
+		prefix := fmt.Sprintf("%s %d (%s): ", label, i+1, step.ID)
+		problems = append(problems, fmt.Errorf(prefix+format, args...))
+	}
+
+	for i, step := range steps {
+		if step.ID == "" {
+			report(i, step, "missing required field: id")
+		}
+
+		if !setupStepTypes[step.Type] {
+			report(i, step, "unknown step type %q (expected one of %s)", step.Type, strings.Join(sortedKeys(setupStepTypes), ", "))
+		}
+
+		switch step.Target {
+		case "", "control-plane", "worker", "both":
+		default:
+			report(i, step, "invalid target %q", step.Target)
+		}
+
+		switch step.Type {
+		case "command", "rollback_command":
+			if step.Command == "" {
+				report(i, step, "%s step requires command", step.Type)
+			}
+		case "script":
+			if step.Script == "" {
+				report(i, step, "script step requires script")
+			}
+		case "resource", "wait_for_resource":
+			if step.Resource == "" {
+				report(i, step, "%s step requires resource", step.Type)
+			}
+		case "apply_manifest":
+			if step.Resource == "" {
+				report(i, step, "apply_manifest step requires resource")
+			} else if _, err := parseManifestTemplate(step); err != nil {
+				report(i, step, "%v", err)
+			}
+		case "wait":
+			if step.Timeout <= 0 {
+				report(i, step, "wait step requires a positive timeout")
+			}
+		}
+
+		for _, condition := range step.Conditions {
+			if !setupConditionTypes[condition.Type] {
+				report(i, step, "unknown condition type %q", condition.Type)
+			}
+		}
+	}
+
+	return problems
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
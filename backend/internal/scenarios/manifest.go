@@ -0,0 +1,74 @@
+// backend/internal/scenarios/manifest.go - apply_manifest setup steps
+
+package scenarios
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// manifestHeredocDelimiter ends the manifest written to the control plane; manifests must not contain it
+const manifestHeredocDelimiter = "CKS_MANIFEST_EOF"
+
+// manifestTemplateData holds the variables available to apply_manifest templates
+type manifestTemplateData struct {
+	SessionID  string
+	Namespace  string
+	ScenarioID string
+}
+
+// parseManifestTemplate parses the resource of an apply_manifest step as a text/template
+func parseManifestTemplate(step models.SetupStep) (*template.Template, error) {
+	tmpl, err := template.New(step.ID).Option("missingkey=error").Parse(step.Resource)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// executeApplyManifestStep renders the step's manifest with the session variables, writes it
+// to a temporary file on the control plane and applies it with kubectl. The file is removed
+// whether or not the apply succeeds.
+func (si *ScenarioInitializer) executeApplyManifestStep(ctx context.Context, session *models.Session, step models.SetupStep) error {
+	tmpl, err := parseManifestTemplate(step)
+	if err != nil {
+		return fmt.Errorf("apply_manifest step %s: %w", step.ID, err)
+	}
+
+	var manifest bytes.Buffer
+	if err := tmpl.Execute(&manifest, manifestTemplateData{
+		SessionID:  session.ID,
+		Namespace:  session.Namespace,
+		ScenarioID: session.ScenarioID,
+	}); err != nil {
+		return fmt.Errorf("apply_manifest step %s: failed to render manifest: %w", step.ID, err)
+	}
+
+	if strings.Contains(manifest.String(), manifestHeredocDelimiter) {
+		return fmt.Errorf("apply_manifest step %s: manifest must not contain %s", step.ID, manifestHeredocDelimiter)
+	}
+
+	cmd := fmt.Sprintf("f=$(mktemp /tmp/cks-manifest-XXXXXX.yaml) && cat > \"$f\" << '%s'\n%s\n%s\nkubectl apply -f \"$f\"; rc=$?; rm -f \"$f\"; exit $rc",
+		manifestHeredocDelimiter, manifest.String(), manifestHeredocDelimiter)
+
+	output, err := si.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, cmd, false)
+	if err != nil {
+		// The error carries kubectl's stderr
+		return fmt.Errorf("kubectl apply failed for step %s: %w", step.ID, err)
+	}
+
+	si.logger.WithFields(logrus.Fields{
+		"sessionID": session.ID,
+		"step":      step.ID,
+		"output":    strings.TrimSpace(output),
+	}).Debug("Manifest applied")
+
+	return nil
+}
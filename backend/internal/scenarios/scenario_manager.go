@@ -1,29 +1,48 @@
 package scenarios
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/fullstack-pw/cks/backend/internal/models"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // ScenarioManager handles loading and managing scenarios with improved thread safety
 type ScenarioManager struct {
 	scenariosDir string
 	scenarios    map[string]*models.Scenario
-	categories   map[string]string
+	invalid      map[string]*ScenarioInvalidError // Scenario directories that failed validation, by ID
+	categories   map[string]*models.CategoryNode  // All categories by ID, linked into a tree
+	categoryTree []*models.CategoryNode           // Top-level categories
+	searchIndex  map[string][]string              // Word -> IDs of scenarios containing it, rebuilt on load
+
+	// CacheTTL is how long loaded scenarios are used before GetScenario and ListScenarios
+	// reload them in the background, for scenario directories updated externally.
+	// 0 never reloads.
+	CacheTTL          time.Duration
+	scenariosLoadedAt time.Time
+	refreshOnce       *sync.Once // Replaced on every load, so one refresh runs per expiry
+
+	// Completion statistics and difficulty ratings, reset on restart
+	stats           map[string]*mutableScenarioStats
+	difficultyVotes *DifficultyVoteStore
 
 	// Use RWMutex for better read concurrency
 	scenarioMutex sync.RWMutex
 	categoryMutex sync.RWMutex
+	statsMutex    sync.RWMutex
 
 	logger *logrus.Logger
 
@@ -33,11 +52,15 @@ type ScenarioManager struct {
 
 func NewScenarioManager(scenariosDir string, logger *logrus.Logger) (*ScenarioManager, error) {
 	sm := &ScenarioManager{
-		scenariosDir: scenariosDir,
-		scenarios:    make(map[string]*models.Scenario),
-		categories:   make(map[string]string),
-		logger:       logger,
-		watcherStop:  make(chan struct{}),
+		scenariosDir:    scenariosDir,
+		scenarios:       make(map[string]*models.Scenario),
+		invalid:         make(map[string]*ScenarioInvalidError),
+		categories:      make(map[string]*models.CategoryNode),
+		stats:           make(map[string]*mutableScenarioStats),
+		difficultyVotes: NewDifficultyVoteStore(),
+		logger:          logger,
+		refreshOnce:     &sync.Once{},
+		watcherStop:     make(chan struct{}),
 	}
 
 	// Load scenarios and categories
@@ -58,11 +81,16 @@ func (sm *ScenarioManager) GetScenario(id string) (*models.Scenario, error) {
 		"scenarioID": id,
 		"method":     "GetScenario",
 	}).Debug("Getting scenario")
+	sm.refreshIfStale()
+
 	sm.scenarioMutex.RLock()
 	defer sm.scenarioMutex.RUnlock()
 
 	scenario, exists := sm.scenarios[id]
 	if !exists {
+		if invalidErr, ok := sm.invalid[id]; ok {
+			return nil, invalidErr
+		}
 		return nil, NewScenarioNotFoundError(id)
 	}
 
@@ -119,16 +147,35 @@ func (sm *ScenarioManager) GetScenario(id string) (*models.Scenario, error) {
 	return &scenarioCopy, nil
 }
 
-// ListScenarios returns scenarios with optional filtering
-func (sm *ScenarioManager) ListScenarios(category, difficulty, searchQuery string) ([]*models.Scenario, error) {
+// ListScenarios returns scenarios with optional filtering.
+// Scenarios must carry every one of the given tags.
+func (sm *ScenarioManager) ListScenarios(category, difficulty, searchQuery string, tags []string) ([]*models.Scenario, error) {
+	// A parent category also matches scenarios in any of its subcategories
+	var categoryIDs map[string]bool
+	if category != "" {
+		categoryIDs = sm.categoryAndDescendants(category)
+	}
+
+	sm.refreshIfStale()
+
 	sm.scenarioMutex.RLock()
 	defer sm.scenarioMutex.RUnlock()
 
+	// Every word of the search query must appear in the title, description or topics
+	var searchIDs map[string]bool
+	if searchQuery != "" {
+		searchIDs = sm.searchScenarioIDs(searchQuery)
+	}
+
 	// Create result slice with initial capacity
 	scenarios := make([]*models.Scenario, 0, len(sm.scenarios))
 
 	// Apply filters
 	for _, scenario := range sm.scenarios {
+		if searchIDs != nil && !searchIDs[scenario.ID] {
+			continue
+		}
+
 		// Create a copy for each scenario
 		scenarioCopy := *scenario
 
@@ -136,7 +183,7 @@ func (sm *ScenarioManager) ListScenarios(category, difficulty, searchQuery strin
 		if category != "" {
 			categoryMatch := false
 			for _, t := range scenarioCopy.Topics {
-				if t == category {
+				if categoryIDs[t] {
 					categoryMatch = true
 					break
 				}
@@ -151,26 +198,9 @@ func (sm *ScenarioManager) ListScenarios(category, difficulty, searchQuery strin
 			continue
 		}
 
-		// Filter by search query
-		if searchQuery != "" {
-			searchQuery = strings.ToLower(searchQuery)
-			title := strings.ToLower(scenarioCopy.Title)
-			desc := strings.ToLower(scenarioCopy.Description)
-
-			if !strings.Contains(title, searchQuery) && !strings.Contains(desc, searchQuery) {
-				// Check topics
-				topicMatch := false
-				for _, topic := range scenarioCopy.Topics {
-					if strings.Contains(strings.ToLower(topic), searchQuery) {
-						topicMatch = true
-						break
-					}
-				}
-
-				if !topicMatch {
-					continue
-				}
-			}
+		// Filter by tags
+		if !hasAllTags(scenarioCopy.Tags, tags) {
+			continue
 		}
 
 		// Add scenario to results
@@ -185,38 +215,144 @@ func (sm *ScenarioManager) ListScenarios(category, difficulty, searchQuery strin
 	return scenarios, nil
 }
 
-// GetCategories returns all scenario categories with proper locking
-func (sm *ScenarioManager) GetCategories() (map[string]string, error) {
+// hasAllTags reports whether scenarioTags contains every tag in required
+func hasAllTags(scenarioTags, required []string) bool {
+	for _, tag := range required {
+		found := false
+		for _, t := range scenarioTags {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// GetTags returns the sorted, deduplicated tags used by all loaded scenarios
+func (sm *ScenarioManager) GetTags() []string {
+	sm.scenarioMutex.RLock()
+	defer sm.scenarioMutex.RUnlock()
+
+	seen := make(map[string]bool)
+	tags := make([]string, 0)
+	for _, scenario := range sm.scenarios {
+		for _, tag := range scenario.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+
+	return tags
+}
+
+// GetCategories returns the scenario category tree with proper locking
+func (sm *ScenarioManager) GetCategories() ([]*models.CategoryNode, error) {
+	sm.categoryMutex.RLock()
+	defer sm.categoryMutex.RUnlock()
+
+	// Copy the tree to avoid race conditions
+	tree := make([]*models.CategoryNode, 0, len(sm.categoryTree))
+	for _, node := range sm.categoryTree {
+		tree = append(tree, copyCategoryNode(node))
+	}
+
+	return tree, nil
+}
+
+// GetCategoryNames returns the name of every category, including subcategories, by ID
+func (sm *ScenarioManager) GetCategoryNames() (map[string]string, error) {
 	sm.categoryMutex.RLock()
 	defer sm.categoryMutex.RUnlock()
 
-	// Copy categories map to avoid race conditions
-	categories := make(map[string]string, len(sm.categories))
-	for k, v := range sm.categories {
-		categories[k] = v
+	names := make(map[string]string, len(sm.categories))
+	for id, node := range sm.categories {
+		names[id] = node.Name
 	}
 
-	return categories, nil
+	return names, nil
 }
 
-// ReloadScenarios reloads all scenarios from disk
+// categoryAndDescendants returns the IDs of a category and all of its subcategories
+func (sm *ScenarioManager) categoryAndDescendants(categoryID string) map[string]bool {
+	sm.categoryMutex.RLock()
+	defer sm.categoryMutex.RUnlock()
+
+	ids := map[string]bool{categoryID: true}
+	pending := []*models.CategoryNode{sm.categories[categoryID]}
+	for len(pending) > 0 {
+		node := pending[0]
+		pending = pending[1:]
+		if node == nil {
+			continue
+		}
+		for _, child := range node.Children {
+			ids[child.ID] = true
+			pending = append(pending, child)
+		}
+	}
+
+	return ids
+}
+
+// copyCategoryNode returns a deep copy of a category subtree
+func copyCategoryNode(node *models.CategoryNode) *models.CategoryNode {
+	nodeCopy := *node
+	nodeCopy.Children = nil
+	for _, child := range node.Children {
+		nodeCopy.Children = append(nodeCopy.Children, copyCategoryNode(child))
+	}
+	return &nodeCopy
+}
+
+// ReloadScenarios reloads all scenarios from disk. The previously loaded scenarios are
+// served until the reload completes.
 func (sm *ScenarioManager) ReloadScenarios() error {
 	sm.logger.Info("Starting to load scenarios")
-	sm.scenarioMutex.Lock()
-	defer sm.scenarioMutex.Unlock()
+	return sm.loadScenarios()
+}
 
-	// Clear existing scenarios
-	sm.scenarios = make(map[string]*models.Scenario)
+// refreshIfStale starts a background reload once the loaded scenarios are older than
+// CacheTTL. Callers keep getting the loaded scenarios while it runs.
+func (sm *ScenarioManager) refreshIfStale() {
+	if sm.CacheTTL <= 0 {
+		return
+	}
 
-	// Reload scenarios without the lock (will acquire it when storing)
-	sm.scenarioMutex.Unlock()
-	err := sm.loadScenarios()
+	sm.scenarioMutex.RLock()
+	stale := time.Since(sm.scenariosLoadedAt) > sm.CacheTTL
+	once := sm.refreshOnce
+	sm.scenarioMutex.RUnlock()
+
+	if stale {
+		once.Do(func() {
+			go func() {
+				if err := sm.ReloadScenarios(); err != nil {
+					sm.logger.WithError(err).Warn("Background scenario reload failed")
+					// Retry once the TTL expires again rather than on every lookup
+					sm.markLoaded()
+				}
+			}()
+		})
+	}
+}
+
+// markLoaded restarts the CacheTTL period
+func (sm *ScenarioManager) markLoaded() {
 	sm.scenarioMutex.Lock()
+	defer sm.scenarioMutex.Unlock()
 
-	return err
+	sm.scenariosLoadedAt = time.Now()
+	sm.refreshOnce = &sync.Once{}
 }
 
-// loadScenarios loads all scenarios from the directory
+// loadScenarios loads all scenarios from the directory and replaces the loaded scenarios
 func (sm *ScenarioManager) loadScenarios() error {
 	// Check if scenarios directory exists
 	info, err := os.Stat(sm.scenariosDir)
@@ -236,6 +372,8 @@ func (sm *ScenarioManager) loadScenarios() error {
 
 	// Collect errors but continue loading other scenarios
 	var loadErrors []error
+	scenarios := make(map[string]*models.Scenario)
+	invalid := make(map[string]*ScenarioInvalidError)
 
 	// Process each scenario directory
 	for _, entry := range entries {
@@ -256,6 +394,12 @@ func (sm *ScenarioManager) loadScenarios() error {
 		if err != nil {
 			sm.logger.WithError(err).Warnf("Failed to load scenario %s", scenarioID)
 			loadErrors = append(loadErrors, err)
+
+			// Remember invalid scenarios so lookups can report why they are unavailable
+			var invalidErr *ScenarioInvalidError
+			if errors.As(err, &invalidErr) {
+				invalid[scenarioID] = invalidErr
+			}
 			continue
 		}
 
@@ -275,19 +419,24 @@ func (sm *ScenarioManager) loadScenarios() error {
 			}(),
 		}).Info("Loaded scenario with tasks and validation")
 
-		// Store scenario with proper locking
-		sm.scenarioMutex.Lock()
-		sm.scenarios[scenario.ID] = scenario
-		sm.scenarioMutex.Unlock()
+		scenarios[scenario.ID] = scenario
 	}
 
-	sm.logger.WithField("count", len(sm.scenarios)).Info("Loaded scenarios")
-
-	// Return error if no scenarios were loaded successfully
-	if len(sm.scenarios) == 0 && len(loadErrors) > 0 {
+	// Keep the loaded scenarios if none could be loaded this time
+	if len(scenarios) == 0 && len(loadErrors) > 0 {
 		return fmt.Errorf("failed to load any scenarios: %v", loadErrors[0])
 	}
 
+	sm.scenarioMutex.Lock()
+	sm.scenarios = scenarios
+	sm.invalid = invalid
+	sm.scenarioMutex.Unlock()
+	sm.markLoaded()
+
+	sm.rebuildSearchIndex()
+
+	sm.logger.WithField("count", len(scenarios)).Info("Loaded scenarios")
+
 	return nil
 }
 
@@ -318,11 +467,18 @@ func (sm *ScenarioManager) loadScenario(scenarioID string, scenarioPath string)
 		scenario.ID = scenarioID
 	}
 
+	if scenario.Deprecated && scenario.DeprecationMessage == "" {
+		scenario.DeprecationMessage = fmt.Sprintf("Scenario %s is deprecated", scenario.ID)
+	}
+
 	// Validate scenario metadata
 	if err := sm.validateScenarioMetadata(&scenario); err != nil {
 		return nil, NewScenarioInvalidError(scenarioID, err.Error())
 	}
 
+	// Tasks are only loaded from the tasks directory
+	scenario.Tasks = nil
+
 	// Load tasks
 	if err := sm.loadTasks(&scenario, scenarioPath); err != nil {
 		return nil, fmt.Errorf("failed to load tasks: %w", err)
@@ -360,6 +516,17 @@ func (sm *ScenarioManager) validateScenarioMetadata(scenario *models.Scenario) e
 		return fmt.Errorf("invalid difficulty: %s", scenario.Difficulty)
 	}
 
+	// Resource limits must be valid quantities
+	limits := scenario.Requirements.ResourceLimits
+	for name, value := range map[string]string{"cpu": limits.CPU, "memory": limits.Memory, "pods": limits.Pods} {
+		if value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return fmt.Errorf("invalid resourceLimits.%s: %q", name, value)
+		}
+	}
+
 	return nil
 }
 
@@ -627,8 +794,8 @@ func (sm *ScenarioManager) parseTaskMarkdown(taskID, content string) (models.Tas
 			continue
 		}
 
-		// Add content to current section
-		if currentSection != "" && trimmedLine != "" {
+		// Add content to current section; the solution keeps its blank lines, since it is markdown
+		if currentSection != "" && (trimmedLine != "" || currentSection == "Solution") {
 			sectionContent[currentSection] = append(sectionContent[currentSection], line)
 		}
 	}
@@ -653,6 +820,41 @@ func (sm *ScenarioManager) parseTaskMarkdown(taskID, content string) (models.Tas
 		task.Hints = sm.parseHints(hints)
 	}
 
+	// Extract hint unlock thresholds (e.g. "0, 1, 3"), one per hint
+	if unlock, exists := sectionContent["Hint Unlock"]; exists && len(unlock) > 0 {
+		thresholds, err := parseHintUnlock(unlock[0], len(task.Hints))
+		if err != nil {
+			sm.logger.WithError(err).WithField("taskID", taskID).Warn("Invalid hint unlock thresholds, all hints unlocked")
+		} else {
+			task.HintUnlockAfterAttempts = thresholds
+		}
+	}
+
+	// Extract time estimate (e.g. "10m"), used for the scoring time bonus
+	if estimate, exists := sectionContent["Time Estimate"]; exists && len(estimate) > 0 {
+		duration, err := time.ParseDuration(strings.TrimSpace(estimate[0]))
+		if err != nil {
+			sm.logger.WithError(err).WithField("taskID", taskID).Warn("Invalid task time estimate")
+		} else {
+			task.TimeEstimateSeconds = int(duration.Seconds())
+		}
+	}
+
+	// Extract the solution, revealed after SolutionUnlockAfterAttempts attempts
+	if solution, exists := sectionContent["Solution"]; exists {
+		task.Solution = strings.TrimSpace(strings.Join(solution, "\n"))
+	}
+
+	// Extract solution unlock threshold (e.g. "3")
+	if unlock, exists := sectionContent["Solution Unlock"]; exists && len(unlock) > 0 {
+		threshold, err := strconv.Atoi(strings.TrimSpace(unlock[0]))
+		if err != nil || threshold < 1 {
+			sm.logger.WithField("taskID", taskID).Warnf("Invalid solution unlock threshold %q, must be at least 1; using the default", unlock[0])
+		} else {
+			task.SolutionUnlockAfterAttempts = threshold
+		}
+	}
+
 	// If no title found in H1, try to extract from filename
 	if task.Title == "" {
 		task.Title = fmt.Sprintf("Task %s", taskID)
@@ -666,6 +868,25 @@ func (sm *ScenarioManager) parseTaskMarkdown(taskID, content string) (models.Tas
 	return task, nil
 }
 
+// parseHintUnlock parses a comma-separated list with one attempt threshold per hint
+func parseHintUnlock(line string, hintCount int) ([]int, error) {
+	parts := strings.Split(line, ",")
+	if len(parts) != hintCount {
+		return nil, fmt.Errorf("expected %d thresholds, got %d", hintCount, len(parts))
+	}
+
+	thresholds := make([]int, 0, len(parts))
+	for _, part := range parts {
+		threshold, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || threshold < 0 {
+			return nil, fmt.Errorf("invalid threshold %q", strings.TrimSpace(part))
+		}
+		thresholds = append(thresholds, threshold)
+	}
+
+	return thresholds, nil
+}
+
 // Stop gracefully shuts down the scenario manager
 func (sm *ScenarioManager) Stop() {
 	close(sm.watcherStop)
@@ -740,7 +961,7 @@ func (sm *ScenarioManager) parseHints(hintLines []string) []string {
 	return hints
 }
 
-// Add to ScenarioManager
+// loadSetupSteps loads the setup steps of a scenario from setup/init.yaml
 func (sm *ScenarioManager) loadSetupSteps(scenario *models.Scenario, scenarioPath string) error {
 	setupFile := filepath.Join(scenarioPath, "setup", "init.yaml")
 
@@ -756,7 +977,8 @@ func (sm *ScenarioManager) loadSetupSteps(scenario *models.Scenario, scenarioPat
 	}
 
 	var setup struct {
-		Steps []models.SetupStep `yaml:"steps"`
+		Steps    []models.SetupStep `yaml:"steps"`
+		Rollback []models.SetupStep `yaml:"rollback"`
 	}
 
 	err = yaml.Unmarshal(content, &setup)
@@ -765,10 +987,12 @@ func (sm *ScenarioManager) loadSetupSteps(scenario *models.Scenario, scenarioPat
 	}
 
 	scenario.SetupSteps = setup.Steps
+	scenario.Rollback = setup.Rollback
 
 	sm.logger.WithFields(logrus.Fields{
-		"scenarioID": scenario.ID,
-		"stepCount":  len(scenario.SetupSteps),
+		"scenarioID":    scenario.ID,
+		"stepCount":     len(scenario.SetupSteps),
+		"rollbackCount": len(scenario.Rollback),
 	}).Debug("Loaded setup steps")
 
 	return nil
@@ -791,7 +1015,11 @@ func (sm *ScenarioManager) loadCategories() error {
 	_, err := os.Stat(categoriesPath)
 	if err != nil {
 		// Use default categories
-		sm.categories = defaultCategories
+		nodes := make(map[string]*models.CategoryNode, len(defaultCategories))
+		for id, name := range defaultCategories {
+			nodes[id] = &models.CategoryNode{ID: id, Name: name}
+		}
+		sm.setCategories(nodes)
 		return nil
 	}
 
@@ -806,6 +1034,7 @@ func (sm *ScenarioManager) loadCategories() error {
 		Categories map[string]struct {
 			Name        string `yaml:"name"`
 			Description string `yaml:"description"`
+			ParentID    string `yaml:"parentId"`
 		} `yaml:"categories"`
 	}
 
@@ -814,15 +1043,72 @@ func (sm *ScenarioManager) loadCategories() error {
 		return err
 	}
 
-	// Convert to simple map
-	sm.categories = make(map[string]string, len(categories.Categories))
+	nodes := make(map[string]*models.CategoryNode, len(categories.Categories))
 	for id, category := range categories.Categories {
-		sm.categories[id] = category.Name
+		nodes[id] = &models.CategoryNode{
+			ID:          id,
+			Name:        category.Name,
+			Description: category.Description,
+			ParentID:    category.ParentID,
+		}
 	}
+	sm.setCategories(nodes)
 
 	return nil
 }
 
+// setCategories links categories to their parents and stores the resulting tree.
+// Categories with an unknown parent or in a parent cycle become top-level categories.
+func (sm *ScenarioManager) setCategories(nodes map[string]*models.CategoryNode) {
+	ids := make([]string, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		node := nodes[id]
+		if node.ParentID == "" {
+			continue
+		}
+
+		if _, exists := nodes[node.ParentID]; !exists {
+			sm.logger.WithFields(logrus.Fields{
+				"categoryID": id,
+				"parentID":   node.ParentID,
+			}).Warn("Category parent not found, treating as top-level category")
+			node.ParentID = ""
+			continue
+		}
+
+		// Walk up the parents to detect cycles
+		for parent, steps := nodes[node.ParentID], 0; parent != nil && steps <= len(nodes); parent, steps = nodes[parent.ParentID], steps+1 {
+			if parent.ID == id {
+				sm.logger.WithField("categoryID", id).Warn("Category parent cycle detected, treating as top-level category")
+				node.ParentID = ""
+				break
+			}
+		}
+	}
+
+	var tree []*models.CategoryNode
+	for _, id := range ids {
+		node := nodes[id]
+		if node.ParentID == "" {
+			tree = append(tree, node)
+		} else {
+			parent := nodes[node.ParentID]
+			parent.Children = append(parent.Children, node)
+		}
+	}
+
+	sm.categoryMutex.Lock()
+	defer sm.categoryMutex.Unlock()
+
+	sm.categories = nodes
+	sm.categoryTree = tree
+}
+
 func parseSteps(stepLines []string) []string {
 	steps := []string{}
 	for _, line := range stepLines {
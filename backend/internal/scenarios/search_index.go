@@ -0,0 +1,82 @@
+// backend/internal/scenarios/search_index.go - Keyword index for scenario search
+
+package scenarios
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// tokenize splits text into lowercase words with surrounding punctuation removed
+func tokenize(text string) []string {
+	fields := strings.Fields(strings.ToLower(text))
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		token := strings.TrimFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// buildSearchIndex maps each word of the scenarios' titles, descriptions and topics
+// to the sorted IDs of the scenarios containing it
+func buildSearchIndex(scenarios map[string]*models.Scenario) map[string][]string {
+	index := make(map[string][]string)
+
+	for id, scenario := range scenarios {
+		words := make(map[string]bool)
+		for _, token := range tokenize(scenario.Title + " " + scenario.Description + " " + strings.Join(scenario.Topics, " ")) {
+			words[token] = true
+		}
+		for word := range words {
+			index[word] = append(index[word], id)
+		}
+	}
+
+	for _, ids := range index {
+		sort.Strings(ids)
+	}
+	return index
+}
+
+// rebuildSearchIndex replaces the search index with one built from the loaded scenarios
+func (sm *ScenarioManager) rebuildSearchIndex() {
+	sm.scenarioMutex.Lock()
+	defer sm.scenarioMutex.Unlock()
+
+	sm.searchIndex = buildSearchIndex(sm.scenarios)
+}
+
+// searchScenarioIDs returns the IDs of the scenarios containing every word of query, or nil
+// when the query has no words. Must be called with sm.scenarioMutex held.
+func (sm *ScenarioManager) searchScenarioIDs(query string) map[string]bool {
+	tokens := tokenize(query)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	matches := make(map[string]bool)
+	for _, id := range sm.searchIndex[tokens[0]] {
+		matches[id] = true
+	}
+
+	for _, token := range tokens[1:] {
+		next := make(map[string]bool)
+		for _, id := range sm.searchIndex[token] {
+			if matches[id] {
+				next[id] = true
+			}
+		}
+		matches = next
+	}
+
+	return matches
+}
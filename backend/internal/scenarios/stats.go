@@ -0,0 +1,121 @@
+// backend/internal/scenarios/stats.go - In-memory completion statistics per scenario
+
+package scenarios
+
+import (
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// mutableScenarioStats accumulates the outcomes of the sessions started for a scenario
+type mutableScenarioStats struct {
+	attempts               map[string]bool            // Session IDs that validated at least one task
+	completed              map[string]bool            // Session IDs that completed every task
+	taskCompletions        map[string]map[string]bool // Task ID -> session IDs that completed it
+	taskDurations          map[string][]float64       // Task ID -> DurationSeconds of each completion
+	totalCompletionSeconds float64
+}
+
+func newMutableScenarioStats() *mutableScenarioStats {
+	return &mutableScenarioStats{
+		attempts:        make(map[string]bool),
+		completed:       make(map[string]bool),
+		taskCompletions: make(map[string]map[string]bool),
+		taskDurations:   make(map[string][]float64),
+	}
+}
+
+// RecordTaskResult updates the statistics of the session's scenario after a task validation.
+// It is registered as the SessionManager task result observer.
+func (sm *ScenarioManager) RecordTaskResult(session models.Session, taskID, status string) {
+	if session.ScenarioID == "" {
+		return
+	}
+
+	sm.statsMutex.Lock()
+	defer sm.statsMutex.Unlock()
+
+	stats, ok := sm.stats[session.ScenarioID]
+	if !ok {
+		stats = newMutableScenarioStats()
+		sm.stats[session.ScenarioID] = stats
+	}
+
+	stats.attempts[session.ID] = true
+
+	if status == "completed" {
+		if stats.taskCompletions[taskID] == nil {
+			stats.taskCompletions[taskID] = make(map[string]bool)
+		}
+		if !stats.taskCompletions[taskID][session.ID] {
+			for _, task := range session.Tasks {
+				if task.ID == taskID && task.DurationSeconds > 0 {
+					stats.taskDurations[taskID] = append(stats.taskDurations[taskID], task.DurationSeconds)
+				}
+			}
+		}
+		stats.taskCompletions[taskID][session.ID] = true
+	}
+
+	if session.Status == models.SessionStatusCompleted && !session.CompletedAt.IsZero() && !stats.completed[session.ID] {
+		stats.completed[session.ID] = true
+		stats.totalCompletionSeconds += session.CompletedAt.Sub(session.StartTime).Seconds()
+	}
+}
+
+// GetStats returns completion statistics for every scenario that has been attempted
+// since the server started
+func (sm *ScenarioManager) GetStats() map[string]models.ScenarioStats {
+	sm.statsMutex.RLock()
+	defer sm.statsMutex.RUnlock()
+
+	result := make(map[string]models.ScenarioStats, len(sm.stats))
+	for scenarioID, stats := range sm.stats {
+		scenarioStats := models.ScenarioStats{
+			ScenarioID:          scenarioID,
+			TotalAttempts:       len(stats.attempts),
+			CompletedAttempts:   len(stats.completed),
+			TaskCompletionRates: make(map[string]float64),
+			AverageTaskSeconds:  make(map[string]float64),
+		}
+
+		if scenarioStats.CompletedAttempts > 0 {
+			scenarioStats.AverageCompletionSeconds = stats.totalCompletionSeconds / float64(scenarioStats.CompletedAttempts)
+		}
+
+		// Report every task of the scenario, including those nobody has completed yet
+		for _, taskID := range sm.taskIDs(scenarioID) {
+			scenarioStats.TaskCompletionRates[taskID] = 0
+		}
+		for taskID, sessions := range stats.taskCompletions {
+			scenarioStats.TaskCompletionRates[taskID] = float64(len(sessions)) / float64(scenarioStats.TotalAttempts) * 100
+		}
+		for taskID, durations := range stats.taskDurations {
+			var total float64
+			for _, duration := range durations {
+				total += duration
+			}
+			scenarioStats.AverageTaskSeconds[taskID] = total / float64(len(durations))
+		}
+
+		result[scenarioID] = scenarioStats
+	}
+
+	return result
+}
+
+// taskIDs returns the task IDs of a loaded scenario
+func (sm *ScenarioManager) taskIDs(scenarioID string) []string {
+	sm.scenarioMutex.RLock()
+	defer sm.scenarioMutex.RUnlock()
+
+	scenario, ok := sm.scenarios[scenarioID]
+	if !ok {
+		return nil
+	}
+
+	ids := make([]string, 0, len(scenario.Tasks))
+	for _, task := range scenario.Tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
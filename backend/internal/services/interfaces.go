@@ -8,18 +8,38 @@ import (
 	"time"
 
 	"github.com/fullstack-pw/cks/backend/internal/models"
+	"github.com/fullstack-pw/cks/backend/internal/terminal"
 	"github.com/fullstack-pw/cks/backend/internal/validation"
 )
 
 // SessionService defines the interface for session-related operations
 type SessionService interface {
-	CreateSession(ctx context.Context, scenarioID string) (*models.Session, error)
+	CreateSession(ctx context.Context, scenarioID string, opts models.SessionOptions) (*models.Session, error)
 	GetSession(sessionID string) (*models.Session, error)
+	GetSessionView(ctx context.Context, sessionID string) (*models.SessionView, error)
+	GetSessionProgress(sessionID string) (*models.SessionProgress, error)
+	GetScoreBreakdown(sessionID string) (*models.ScoreBreakdown, error)
+	GetTaskHints(sessionID, taskID string) (*models.TaskHints, error)
+	GetHint(sessionID, taskID string, index int) (*models.HintResponse, error)
+	GetSolution(sessionID, taskID string) (*models.SolutionResponse, error)
 	ListSessions() []*models.Session
+	ListTerminals(sessionID string) ([]models.TerminalInfo, error)
+	SubscribeEvents(sessionID string) (<-chan models.SessionEvent, func(), error)
+	SubscribeProvisioningLog(sessionID string) ([]string, <-chan string, func(), error)
 	DeleteSession(ctx context.Context, sessionID string) error
 	ExtendSession(sessionID string, duration time.Duration) error
+	PatchSession(sessionID string, patch models.SessionPatch) error
+	GetKubeconfig(ctx context.Context, sessionID string) ([]byte, error)
+	PauseSession(ctx context.Context, sessionID string) error
+	ResumeSession(ctx context.Context, sessionID string) error
+	CreateSessionSnapshot(ctx context.Context, sessionID string) error
+	RestoreSessionSnapshot(ctx context.Context, sessionID string) error
 	UpdateTaskStatus(sessionID, taskID string, status string) error
+	ResetTask(sessionID, taskID string) error
+	RecommendScenarios(userID string) ([]string, error)
 	ValidateTask(ctx context.Context, sessionID, taskID string) (*validation.ValidationResponse, error)
+	TrackValidation() func()
+	AcquireValidationSlot(ctx context.Context) (func(), error)
 	CheckVMsStatus(ctx context.Context, session *models.Session) (string, error)
 	UpdateSessionStatus(sessionID string, status models.SessionStatus, message string) error
 	RegisterTerminalSession(sessionID, terminalID, target string) error
@@ -31,17 +51,29 @@ type SessionService interface {
 
 // TerminalService defines the interface for terminal-related operations
 type TerminalService interface {
-	CreateSession(sessionID, namespace, target string) (string, error)
+	CreateSession(sessionID, namespace, target, terminalType string) (string, error)
 	HandleTerminal(w http.ResponseWriter, r *http.Request, terminalID string)
 	ResizeTerminal(terminalID string, rows, cols uint16) error
 	CloseSession(terminalID string) error
-	CleanupSessionSSH(sessionID string) // Add this method
+	CleanupSessionSSH(sessionID string)
+	ActiveConnections(sessionID, target string) int
+	CommandHistory(sessionID, target string) []string
+	ClearTerminalHistory(sessionID, terminalID string) error
+	GetMetrics() terminal.TerminalMetrics
 }
 
 // ScenarioService defines the interface for scenario-related operations
 type ScenarioService interface {
 	GetScenario(id string) (*models.Scenario, error)
-	ListScenarios(category, difficulty, searchQuery string) ([]*models.Scenario, error)
-	GetCategories() (map[string]string, error)
+	ListScenarios(category, difficulty, searchQuery string, tags []string) ([]*models.Scenario, error)
+	GetTags() []string
+	GetCategories() ([]*models.CategoryNode, error)
+	GetCategoryNames() (map[string]string, error)
+	GetStats() map[string]models.ScenarioStats
+	RateDifficulty(scenarioID, sessionID string, rating int) error
+	GetDifficultyRating(scenarioID string) (models.DifficultyRating, error)
 	ReloadScenarios() error
+	ExportScenario(id string) ([]byte, error)
+	ImportScenarios(data []byte) ([]string, error)
+	CreateScenario(scenario *models.Scenario) error
 }
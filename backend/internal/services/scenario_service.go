@@ -24,16 +24,56 @@ func (s *ScenarioServiceImpl) GetScenario(id string) (*models.Scenario, error) {
 	return s.scenarioManager.GetScenario(id)
 }
 
+// GetStats returns completion statistics for every attempted scenario
+func (s *ScenarioServiceImpl) GetStats() map[string]models.ScenarioStats {
+	return s.scenarioManager.GetStats()
+}
+
+// RateDifficulty records a user's difficulty rating of a scenario
+func (s *ScenarioServiceImpl) RateDifficulty(scenarioID, sessionID string, rating int) error {
+	return s.scenarioManager.RateDifficulty(scenarioID, sessionID, rating)
+}
+
+// GetDifficultyRating returns the author and community difficulty of a scenario
+func (s *ScenarioServiceImpl) GetDifficultyRating(scenarioID string) (models.DifficultyRating, error) {
+	return s.scenarioManager.GetDifficultyRating(scenarioID)
+}
+
 // ListScenarios returns a list of scenarios
-func (s *ScenarioServiceImpl) ListScenarios(category, difficulty, searchQuery string) ([]*models.Scenario, error) {
-	return s.scenarioManager.ListScenarios(category, difficulty, searchQuery)
+func (s *ScenarioServiceImpl) ListScenarios(category, difficulty, searchQuery string, tags []string) ([]*models.Scenario, error) {
+	return s.scenarioManager.ListScenarios(category, difficulty, searchQuery, tags)
+}
+
+// GetTags returns all tags used by scenarios
+func (s *ScenarioServiceImpl) GetTags() []string {
+	return s.scenarioManager.GetTags()
 }
 
-// GetCategories returns all scenario categories
-func (s *ScenarioServiceImpl) GetCategories() (map[string]string, error) {
+// GetCategories returns the scenario category tree
+func (s *ScenarioServiceImpl) GetCategories() ([]*models.CategoryNode, error) {
 	return s.scenarioManager.GetCategories()
 }
 
+// GetCategoryNames returns the name of every scenario category by ID
+func (s *ScenarioServiceImpl) GetCategoryNames() (map[string]string, error) {
+	return s.scenarioManager.GetCategoryNames()
+}
+
 func (s *ScenarioServiceImpl) ReloadScenarios() error {
 	return s.scenarioManager.ReloadScenarios()
 }
+
+// ExportScenario returns a ZIP archive of a scenario
+func (s *ScenarioServiceImpl) ExportScenario(id string) ([]byte, error) {
+	return s.scenarioManager.ExportScenario(id)
+}
+
+// ImportScenarios extracts a ZIP archive of scenarios and reloads them
+func (s *ScenarioServiceImpl) ImportScenarios(data []byte) ([]string, error) {
+	return s.scenarioManager.ImportScenarios(data)
+}
+
+// CreateScenario writes a new scenario to the scenarios directory and reloads the scenarios
+func (s *ScenarioServiceImpl) CreateScenario(scenario *models.Scenario) error {
+	return s.scenarioManager.CreateScenario(scenario)
+}
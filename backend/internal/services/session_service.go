@@ -24,8 +24,8 @@ func NewSessionService(sessionManager *sessions.SessionManager) SessionService {
 }
 
 // CreateSession creates a new session
-func (s *SessionServiceImpl) CreateSession(ctx context.Context, scenarioID string) (*models.Session, error) {
-	return s.sessionManager.CreateSession(ctx, scenarioID)
+func (s *SessionServiceImpl) CreateSession(ctx context.Context, scenarioID string, opts models.SessionOptions) (*models.Session, error) {
+	return s.sessionManager.CreateSession(ctx, scenarioID, opts)
 }
 
 // GetSession returns a session by ID
@@ -33,6 +33,46 @@ func (s *SessionServiceImpl) GetSession(sessionID string) (*models.Session, erro
 	return s.sessionManager.GetSession(sessionID)
 }
 
+// GetSessionProgress returns completion progress for a session
+func (s *SessionServiceImpl) GetSessionProgress(sessionID string) (*models.SessionProgress, error) {
+	return s.sessionManager.GetSessionProgress(sessionID)
+}
+
+// GetScoreBreakdown returns the score breakdown for a session
+func (s *SessionServiceImpl) GetScoreBreakdown(sessionID string) (*models.ScoreBreakdown, error) {
+	return s.sessionManager.GetScoreBreakdown(sessionID)
+}
+
+// GetSessionView returns a session with its scenario and task details
+func (s *SessionServiceImpl) GetSessionView(ctx context.Context, sessionID string) (*models.SessionView, error) {
+	return s.sessionManager.GetSessionView(ctx, sessionID)
+}
+
+// GetHint reveals a single hint of a task
+func (s *SessionServiceImpl) GetHint(sessionID, taskID string, index int) (*models.HintResponse, error) {
+	return s.sessionManager.GetHint(sessionID, taskID, index)
+}
+
+// GetSolution reveals the solution of a task
+func (s *SessionServiceImpl) GetSolution(sessionID, taskID string) (*models.SolutionResponse, error) {
+	return s.sessionManager.GetSolution(sessionID, taskID)
+}
+
+// GetTaskHints returns the unlocked hints of a task
+func (s *SessionServiceImpl) GetTaskHints(sessionID, taskID string) (*models.TaskHints, error) {
+	return s.sessionManager.GetTaskHints(sessionID, taskID)
+}
+
+// RecommendScenarios returns the IDs of scenarios to try next
+func (s *SessionServiceImpl) RecommendScenarios(userID string) ([]string, error) {
+	return s.sessionManager.RecommendScenarios(userID)
+}
+
+// ResetTask resets a task to pending
+func (s *SessionServiceImpl) ResetTask(sessionID, taskID string) error {
+	return s.sessionManager.ResetTask(sessionID, taskID)
+}
+
 // ListSessions returns all sessions
 func (s *SessionServiceImpl) ListSessions() []*models.Session {
 	return s.sessionManager.ListSessions()
@@ -43,16 +83,71 @@ func (s *SessionServiceImpl) DeleteSession(ctx context.Context, sessionID string
 	return s.sessionManager.DeleteSession(ctx, sessionID)
 }
 
+// PatchSession updates the user-defined metadata of a session
+func (s *SessionServiceImpl) PatchSession(sessionID string, patch models.SessionPatch) error {
+	return s.sessionManager.PatchSession(sessionID, patch)
+}
+
+// GetKubeconfig returns the admin kubeconfig of the session cluster
+func (s *SessionServiceImpl) GetKubeconfig(ctx context.Context, sessionID string) ([]byte, error) {
+	return s.sessionManager.GetKubeconfig(ctx, sessionID)
+}
+
 // ExtendSession extends the session expiration time
 func (s *SessionServiceImpl) ExtendSession(sessionID string, duration time.Duration) error {
 	return s.sessionManager.ExtendSession(sessionID, duration)
 }
 
+// PauseSession pauses a session's VMs
+func (s *SessionServiceImpl) PauseSession(ctx context.Context, sessionID string) error {
+	return s.sessionManager.PauseSession(ctx, sessionID)
+}
+
+// ResumeSession resumes a paused session
+func (s *SessionServiceImpl) ResumeSession(ctx context.Context, sessionID string) error {
+	return s.sessionManager.ResumeSession(ctx, sessionID)
+}
+
+// CreateSessionSnapshot starts snapshotting a session's VMs
+func (s *SessionServiceImpl) CreateSessionSnapshot(ctx context.Context, sessionID string) error {
+	return s.sessionManager.CreateSessionSnapshot(ctx, sessionID)
+}
+
+// RestoreSessionSnapshot starts restoring a session's VMs from its snapshot
+func (s *SessionServiceImpl) RestoreSessionSnapshot(ctx context.Context, sessionID string) error {
+	return s.sessionManager.RestoreSessionSnapshot(ctx, sessionID)
+}
+
 // UpdateTaskStatus updates the status of a task
 func (s *SessionServiceImpl) UpdateTaskStatus(sessionID, taskID string, status string) error {
 	return s.sessionManager.UpdateTaskStatus(sessionID, taskID, status)
 }
 
+// AcquireValidationSlot waits for a free validation slot; the returned function releases it
+func (s *SessionServiceImpl) AcquireValidationSlot(ctx context.Context) (func(), error) {
+	return s.sessionManager.AcquireValidationSlot(ctx)
+}
+
+// TrackValidation records a validation as in flight until the returned function is called
+func (s *SessionServiceImpl) TrackValidation() func() {
+	return s.sessionManager.TrackValidation()
+}
+
+// ListTerminals returns the terminals of a session
+func (s *SessionServiceImpl) ListTerminals(sessionID string) ([]models.TerminalInfo, error) {
+	return s.sessionManager.ListTerminals(sessionID)
+}
+
+// SubscribeEvents subscribes to the event stream of a session
+func (s *SessionServiceImpl) SubscribeEvents(sessionID string) (<-chan models.SessionEvent, func(), error) {
+	return s.sessionManager.SubscribeEvents(sessionID)
+}
+
+// SubscribeProvisioningLog returns the provisioning log of a session and follows new lines
+func (s *SessionServiceImpl) SubscribeProvisioningLog(sessionID string) ([]string, <-chan string, func(), error) {
+	return s.sessionManager.SubscribeProvisioningLog(sessionID)
+}
+
 // ValidateTask validates a task
 func (s *SessionServiceImpl) ValidateTask(ctx context.Context, sessionID, taskID string) (*validation.ValidationResponse, error) {
 	return s.sessionManager.ValidateTask(ctx, sessionID, taskID)
@@ -21,8 +21,8 @@ func NewTerminalService(terminalManager *terminal.Manager) TerminalService {
 }
 
 // CreateSession creates a new terminal session
-func (t *TerminalServiceImpl) CreateSession(sessionID, namespace, target string) (string, error) {
-	return t.terminalManager.CreateSession(sessionID, namespace, target)
+func (t *TerminalServiceImpl) CreateSession(sessionID, namespace, target, terminalType string) (string, error) {
+	return t.terminalManager.CreateSession(sessionID, namespace, target, terminalType)
 }
 
 // HandleTerminal handles a terminal connection
@@ -40,6 +40,26 @@ func (t *TerminalServiceImpl) CloseSession(terminalID string) error {
 	return t.terminalManager.CloseSession(terminalID)
 }
 
+// ActiveConnections returns the number of WebSockets attached to a session's terminal
+func (t *TerminalServiceImpl) ActiveConnections(sessionID, target string) int {
+	return t.terminalManager.ActiveConnections(sessionID, target)
+}
+
+// CommandHistory returns the commands entered in a session's terminal
+func (t *TerminalServiceImpl) CommandHistory(sessionID, target string) []string {
+	return t.terminalManager.CommandHistory(sessionID, target)
+}
+
+// ClearTerminalHistory discards the commands recorded for a session's terminal
+func (t *TerminalServiceImpl) ClearTerminalHistory(sessionID, terminalID string) error {
+	return t.terminalManager.ClearTerminalHistory(sessionID, terminalID)
+}
+
+// GetMetrics returns terminal usage statistics
+func (t *TerminalServiceImpl) GetMetrics() terminal.TerminalMetrics {
+	return t.terminalManager.GetMetrics()
+}
+
 // CleanupSessionSSH cleans up persistent SSH connections for a session
 func (t *TerminalServiceImpl) CleanupSessionSSH(sessionID string) {
 	t.terminalManager.CleanupSessionSSH(sessionID)
@@ -0,0 +1,133 @@
+// backend/internal/sessions/base_snapshot.go - Base snapshots of a session's cluster
+
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/kubevirt"
+)
+
+// Names of the base snapshots in a session namespace
+const (
+	ControlPlaneBaseSnapshot = "cks-control-plane-base-snapshot"
+	WorkerBaseSnapshot       = "cks-worker-base-snapshot"
+)
+
+// baseSnapshotReadyTimeout bounds waiting for the base snapshots to become ready to use
+const baseSnapshotReadyTimeout = 10 * time.Minute
+
+// CreateBaseClusterSnapshot snapshots both VMs of a session. The VMs must be running; they
+// are stopped so that the disks are consistent and started again once the snapshots are ready
+// or have failed.
+func (sm *SessionManager) CreateBaseClusterSnapshot(ctx context.Context, sessionID string) error {
+	session, err := sm.GetSession(sessionID)
+	if err != nil {
+		return err
+	}
+	namespace := session.Namespace
+	vmNames := []string{session.ControlPlaneVM, session.WorkerNodeVM}
+
+	for _, vmName := range vmNames {
+		status, err := sm.kubevirtClient.GetVMStatus(ctx, namespace, vmName)
+		if err != nil {
+			return fmt.Errorf("failed to get status of VM %s: %w", vmName, err)
+		}
+		if status != "Running" {
+			return fmt.Errorf("%w: VM %s is %s, not Running", ErrInvalidSessionState, vmName, status)
+		}
+	}
+
+	logger := sm.logger.WithFields(logrus.Fields{
+		"sessionID": sessionID,
+		"namespace": namespace,
+	})
+	logger.Info("Creating base cluster snapshot")
+
+	if err := sm.kubevirtClient.StopVMs(ctx, namespace, vmNames...); err != nil {
+		return fmt.Errorf("failed to stop VMs: %w", err)
+	}
+
+	snapshotErr := sm.snapshotStoppedVMs(ctx, namespace, map[string]string{
+		session.ControlPlaneVM: ControlPlaneBaseSnapshot,
+		session.WorkerNodeVM:   WorkerBaseSnapshot,
+	})
+
+	// Restart the VMs whatever the outcome, so the session stays usable
+	for _, vmName := range vmNames {
+		if err := sm.kubevirtClient.StartVM(ctx, namespace, vmName); err != nil {
+			logger.WithError(err).WithField("vmName", vmName).Error("Failed to restart VM after snapshot")
+			if snapshotErr == nil {
+				snapshotErr = fmt.Errorf("failed to restart VM %s: %w", vmName, err)
+			}
+		}
+	}
+
+	if snapshotErr != nil {
+		return snapshotErr
+	}
+
+	logger.Info("Base cluster snapshot created")
+	return nil
+}
+
+// snapshotStoppedVMs creates a snapshot of each VM, keyed by VM name, and waits until all are ready
+func (sm *SessionManager) snapshotStoppedVMs(ctx context.Context, namespace string, snapshots map[string]string) error {
+	for vmName, snapshotName := range snapshots {
+		if err := sm.kubevirtClient.CreateVMSnapshot(ctx, namespace, vmName, snapshotName); err != nil {
+			return err
+		}
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, baseSnapshotReadyTimeout)
+	defer cancel()
+
+	for _, snapshotName := range snapshots {
+		if err := sm.kubevirtClient.WaitForSnapshotReady(waitCtx, namespace, snapshotName); err != nil {
+			return fmt.Errorf("snapshot %s did not become ready: %w", snapshotName, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteBaseSnapshots deletes the base snapshots of a session; missing snapshots are ignored
+func (sm *SessionManager) DeleteBaseSnapshots(ctx context.Context, sessionID string) error {
+	session, err := sm.GetSession(sessionID)
+	if err != nil {
+		return err
+	}
+
+	for _, snapshotName := range []string{ControlPlaneBaseSnapshot, WorkerBaseSnapshot} {
+		if err := sm.kubevirtClient.DeleteVMSnapshot(ctx, session.Namespace, snapshotName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetSnapshotInfo returns the base snapshots of a session; snapshots that do not exist are omitted
+func (sm *SessionManager) GetSnapshotInfo(ctx context.Context, sessionID string) ([]*kubevirt.SnapshotInfo, error) {
+	session, err := sm.GetSession(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*kubevirt.SnapshotInfo, 0, 2)
+	for _, snapshotName := range []string{ControlPlaneBaseSnapshot, WorkerBaseSnapshot} {
+		info, err := sm.kubevirtClient.GetSnapshotInfo(ctx, session.Namespace, snapshotName)
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			infos = append(infos, info)
+		}
+	}
+
+	return infos, nil
+}
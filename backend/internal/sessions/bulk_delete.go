@@ -0,0 +1,84 @@
+// backend/internal/sessions/bulk_delete.go - Deleting many sessions at once
+
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxConcurrentBulkDeletes is the number of sessions BulkDeleteSessions deletes at once
+const maxConcurrentBulkDeletes = 5
+
+// BulkDeleteFilter selects the sessions deleted by BulkDeleteSessions; empty fields match all sessions
+type BulkDeleteFilter struct {
+	ScenarioID string
+	OlderThan  time.Duration
+	DryRun     bool
+}
+
+// BulkDeleteSessions deletes the sessions matching filter and returns how many were deleted
+// together with the IDs of all matching sessions. In dry-run mode nothing is deleted.
+// The returned error joins the errors of the sessions that could not be deleted.
+func (sm *SessionManager) BulkDeleteSessions(ctx context.Context, filter BulkDeleteFilter) (int, []string, error) {
+	cutoff := time.Now().Add(-filter.OlderThan)
+
+	var sessionIDs []string
+	for _, session := range sm.ListSessions() {
+		if filter.ScenarioID != "" && session.ScenarioID != filter.ScenarioID {
+			continue
+		}
+		if filter.OlderThan > 0 && !session.StartTime.Before(cutoff) {
+			continue
+		}
+		sessionIDs = append(sessionIDs, session.ID)
+	}
+	sort.Strings(sessionIDs)
+
+	if filter.DryRun || len(sessionIDs) == 0 {
+		return 0, sessionIDs, nil
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		deleted int
+		errs    []error
+	)
+	slots := make(chan struct{}, maxConcurrentBulkDeletes)
+
+	for _, sessionID := range sessionIDs {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(sessionID string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			err := sm.DeleteSession(ctx, sessionID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("session %s: %w", sessionID, err))
+				return
+			}
+			deleted++
+		}(sessionID)
+	}
+	wg.Wait()
+
+	sm.logger.WithFields(logrus.Fields{
+		"scenarioID": filter.ScenarioID,
+		"olderThan":  filter.OlderThan.String(),
+		"matched":    len(sessionIDs),
+		"deleted":    deleted,
+	}).Info("Bulk deleted sessions")
+
+	return deleted, sessionIDs, errors.Join(errs...)
+}
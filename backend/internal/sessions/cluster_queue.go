@@ -0,0 +1,79 @@
+// backend/internal/sessions/cluster_queue.go - Sessions waiting for a pool cluster
+
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/clusterpool"
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// clusterWaitTimeout bounds how long a queued session waits for a cluster
+const clusterWaitTimeout = 30 * time.Minute
+
+// isPoolExhausted reports whether a cluster assignment failed because every cluster is in use
+func isPoolExhausted(err error) bool {
+	return errors.Is(err, clusterpool.ErrNoAvailableClusters)
+}
+
+// queueSession starts waiting in the background for a cluster for a queued session. Deleting
+// the session cancels the wait. Must be called with sm.lock held.
+func (sm *SessionManager) queueSession(session *models.Session, scenario *models.Scenario) {
+	ctx, cancel := context.WithTimeout(context.Background(), clusterWaitTimeout)
+	sm.clusterWaits[session.ID] = cancel
+	go sm.waitForCluster(ctx, session.ID, scenario)
+}
+
+// waitForCluster waits for a cluster for a queued session and provisions the session once it
+// has one. The session fails when no cluster becomes available in time.
+func (sm *SessionManager) waitForCluster(ctx context.Context, sessionID string, scenario *models.Scenario) {
+	cluster, err := sm.clusterPool.WaitForCluster(ctx, sessionID)
+
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+
+	if cancel, ok := sm.clusterWaits[sessionID]; ok {
+		cancel()
+		delete(sm.clusterWaits, sessionID)
+	}
+
+	session, ok := sm.sessions[sessionID]
+	if !ok || session.Status != models.SessionStatusQueued {
+		// Deleted while waiting
+		if err == nil {
+			if releaseErr := sm.clusterPool.ReleaseCluster(sessionID); releaseErr != nil {
+				sm.logger.WithError(releaseErr).WithField("sessionID", sessionID).Warn("Failed to release cluster of deleted session")
+			}
+		}
+		sm.finishProvisioningLog(sessionID)
+		return
+	}
+
+	if err != nil {
+		sm.logger.WithError(err).WithField("sessionID", sessionID).Error("Queued session did not get a cluster")
+		sm.setSessionStatus(session, models.SessionStatusFailed, fmt.Sprintf("No cluster became available: %v", err))
+		sm.finishProvisioningLog(sessionID)
+		return
+	}
+
+	setSessionCluster(session, cluster)
+	session.StartTime = time.Now()
+	session.ExpirationTime = session.StartTime.Add(time.Duration(sm.config.SessionTimeoutMinutes) * time.Minute)
+	sm.setSessionStatus(session, models.SessionStatusRunning, "")
+
+	sm.logger.WithFields(logrus.Fields{
+		"sessionID":      sessionID,
+		"clusterID":      cluster.ClusterID,
+		"namespace":      session.Namespace,
+		"controlPlaneVM": session.ControlPlaneVM,
+		"workerNodeVM":   session.WorkerNodeVM,
+	}).Info("Queued session assigned a cluster")
+
+	sm.provisionSession(session, scenario)
+}
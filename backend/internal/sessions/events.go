@@ -0,0 +1,243 @@
+// backend/internal/sessions/events.go - Server-sent event subscriptions for sessions
+
+package sessions
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// Session event types delivered to subscribers
+const (
+	EventExpiryWarning = "expiry_warning"
+	EventTaskReset     = "task.reset"
+	EventSetupProgress = "setup.progress"
+	EventTaskDeadline  = "task.deadline_warning"
+)
+
+// taskDeadlineWarningMinutes are the minutes before a task deadline at which a
+// task.deadline_warning event is sent
+var taskDeadlineWarningMinutes = []int{2, 1}
+
+// taskDeadlineWarning is the last deadline warning sent for a task
+type taskDeadlineWarning struct {
+	deadline time.Time
+	minutes  int
+}
+
+// expiryWarningCheckInterval is how often session expiration times are checked for warnings
+const expiryWarningCheckInterval = 15 * time.Second
+
+// subscriberBufferSize is the number of events queued per subscriber before events are dropped
+const subscriberBufferSize = 16
+
+// SubscribeEvents registers a subscriber for a session's events. The returned function
+// unsubscribes; the channel is closed when the session is deleted or on unsubscribe.
+func (sm *SessionManager) SubscribeEvents(sessionID string) (<-chan models.SessionEvent, func(), error) {
+	sm.lock.RLock()
+	_, ok := sm.sessions[sessionID]
+	sm.lock.RUnlock()
+	if !ok {
+		return nil, nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	ch := make(chan models.SessionEvent, subscriberBufferSize)
+
+	sm.subscribersLock.Lock()
+	if sm.subscribers[sessionID] == nil {
+		sm.subscribers[sessionID] = make(map[chan models.SessionEvent]struct{})
+	}
+	sm.subscribers[sessionID][ch] = struct{}{}
+	sm.subscribersLock.Unlock()
+
+	unsubscribe := func() {
+		sm.subscribersLock.Lock()
+		defer sm.subscribersLock.Unlock()
+
+		if _, ok := sm.subscribers[sessionID][ch]; ok {
+			delete(sm.subscribers[sessionID], ch)
+			close(ch)
+		}
+		if len(sm.subscribers[sessionID]) == 0 {
+			delete(sm.subscribers, sessionID)
+		}
+	}
+
+	return ch, unsubscribe, nil
+}
+
+// publishEvent delivers an event to every subscriber of a session without blocking
+func (sm *SessionManager) publishEvent(sessionID string, event models.SessionEvent) {
+	sm.subscribersLock.Lock()
+	defer sm.subscribersLock.Unlock()
+
+	for ch := range sm.subscribers[sessionID] {
+		select {
+		case ch <- event:
+		default:
+			sm.logger.WithFields(logrus.Fields{
+				"sessionID": sessionID,
+				"eventType": event.Type,
+			}).Warn("Dropping session event for slow subscriber")
+		}
+	}
+}
+
+// closeSubscribers ends every subscription to a session
+func (sm *SessionManager) closeSubscribers(sessionID string) {
+	sm.subscribersLock.Lock()
+	defer sm.subscribersLock.Unlock()
+
+	for ch := range sm.subscribers[sessionID] {
+		close(ch)
+	}
+	delete(sm.subscribers, sessionID)
+	delete(sm.expiryWarningsSent, sessionID)
+	delete(sm.taskDeadlineWarningsSent, sessionID)
+}
+
+// sendExpiryWarnings publishes an expiry_warning event when a session crosses one of the
+// configured WarningMinutes thresholds. Thresholds are re-armed when a session is extended.
+func (sm *SessionManager) sendExpiryWarnings() {
+	thresholds := append([]int(nil), sm.config.WarningMinutes...)
+	sort.Sort(sort.Reverse(sort.IntSlice(thresholds)))
+
+	type warning struct {
+		sessionID string
+		minutes   int
+	}
+	var warnings []warning
+
+	sm.lock.RLock()
+	remaining := make(map[string]time.Duration, len(sm.sessions))
+	for id, session := range sm.sessions {
+		if session.Status == models.SessionStatusRunning {
+			remaining[id] = time.Until(session.ExpirationTime)
+		}
+	}
+	sm.lock.RUnlock()
+
+	sm.subscribersLock.Lock()
+	for id, left := range remaining {
+		sent := sm.expiryWarningsSent[id]
+		if sent == nil {
+			sent = make(map[int]bool)
+			sm.expiryWarningsSent[id] = sent
+		}
+
+		// Only the smallest threshold crossed since the last check is announced
+		crossed := 0
+		for _, minutes := range thresholds {
+			threshold := time.Duration(minutes) * time.Minute
+			if left > threshold {
+				delete(sent, minutes)
+				continue
+			}
+			if left > 0 && !sent[minutes] {
+				crossed = minutes
+			}
+			sent[minutes] = true
+		}
+		if crossed > 0 {
+			warnings = append(warnings, warning{sessionID: id, minutes: crossed})
+		}
+	}
+	sm.subscribersLock.Unlock()
+
+	for _, w := range warnings {
+		sm.logger.WithFields(logrus.Fields{
+			"sessionID":        w.sessionID,
+			"remainingMinutes": w.minutes,
+		}).Debug("Sending session expiry warning")
+
+		sm.publishEvent(w.sessionID, models.SessionEvent{
+			Type:             EventExpiryWarning,
+			RemainingSeconds: w.minutes * 60,
+		})
+	}
+}
+
+// sendTaskDeadlineWarnings publishes a task.deadline_warning event when a started task crosses
+// one of the taskDeadlineWarningMinutes before its deadline, and marks tasks whose deadline
+// has passed as timed out. A reset task gets a new deadline, which re-arms the warnings.
+func (sm *SessionManager) sendTaskDeadlineWarnings() {
+	type deadline struct {
+		sessionID string
+		taskID    string
+		at        time.Time
+	}
+	var deadlines []deadline
+
+	now := time.Now()
+	sm.lock.Lock()
+	for id, session := range sm.sessions {
+		if session.Status != models.SessionStatusRunning {
+			continue
+		}
+		for i := range session.Tasks {
+			task := &session.Tasks[i]
+			if task.DeadlineAt.IsZero() || task.Status == "completed" {
+				continue
+			}
+			if !now.Before(task.DeadlineAt) {
+				task.TimedOut = true
+				continue
+			}
+			deadlines = append(deadlines, deadline{sessionID: id, taskID: task.ID, at: task.DeadlineAt})
+		}
+	}
+	sm.lock.Unlock()
+
+	type warning struct {
+		sessionID string
+		taskID    string
+		minutes   int
+	}
+	var warnings []warning
+
+	sm.subscribersLock.Lock()
+	for _, d := range deadlines {
+		sent := sm.taskDeadlineWarningsSent[d.sessionID]
+		if sent == nil {
+			sent = make(map[string]taskDeadlineWarning)
+			sm.taskDeadlineWarningsSent[d.sessionID] = sent
+		}
+		last, ok := sent[d.taskID]
+		if !ok || !last.deadline.Equal(d.at) {
+			last = taskDeadlineWarning{deadline: d.at}
+		}
+
+		// Only the smallest threshold crossed since the last check is announced
+		crossed := 0
+		for _, minutes := range taskDeadlineWarningMinutes {
+			if d.at.Sub(now) <= time.Duration(minutes)*time.Minute && (last.minutes == 0 || minutes < last.minutes) {
+				crossed = minutes
+			}
+		}
+		if crossed > 0 {
+			last.minutes = crossed
+			warnings = append(warnings, warning{sessionID: d.sessionID, taskID: d.taskID, minutes: crossed})
+		}
+		sent[d.taskID] = last
+	}
+	sm.subscribersLock.Unlock()
+
+	for _, w := range warnings {
+		sm.logger.WithFields(logrus.Fields{
+			"sessionID":        w.sessionID,
+			"taskID":           w.taskID,
+			"remainingMinutes": w.minutes,
+		}).Debug("Sending task deadline warning")
+
+		sm.publishEvent(w.sessionID, models.SessionEvent{
+			Type:             EventTaskDeadline,
+			TaskID:           w.taskID,
+			RemainingSeconds: w.minutes * 60,
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// backend/internal/sessions/hints.go - Revealing task hints one at a time
+
+package sessions
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// ErrHintNotFound is returned when a task has no hint at the requested index
+var ErrHintNotFound = errors.New("hint not found")
+
+// GetHint reveals the hint at index of a task if the task's validation attempts unlock it,
+// using the same thresholds as GetTaskHints. Revealing a hint records it in the task's
+// HintsUsed and HintRequestedAt; a locked hint is reported without its text.
+func (sm *SessionManager) GetHint(sessionID, taskID string, index int) (*models.HintResponse, error) {
+	sm.lock.RLock()
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		sm.lock.RUnlock()
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+	scenarioID := session.ScenarioID
+	attempts := 0
+	for _, task := range session.Tasks {
+		if task.ID == taskID {
+			attempts = task.AttemptCount
+			break
+		}
+	}
+	sm.lock.RUnlock()
+
+	scenario, err := sm.scenarioManager.GetScenario(scenarioID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scenario: %w", err)
+	}
+
+	var task *models.Task
+	for i := range scenario.Tasks {
+		if scenario.Tasks[i].ID == taskID {
+			task = &scenario.Tasks[i]
+			break
+		}
+	}
+	if task == nil {
+		return nil, fmt.Errorf("task not found: %s", taskID)
+	}
+	if index < 0 || index >= len(task.Hints) {
+		return nil, fmt.Errorf("%w: task %s has %d hints", ErrHintNotFound, taskID, len(task.Hints))
+	}
+
+	threshold := 0
+	if index < len(task.HintUnlockAfterAttempts) {
+		threshold = task.HintUnlockAfterAttempts[index]
+	}
+	response := &models.HintResponse{
+		Index:                        index,
+		Unlocked:                     attempts >= threshold,
+		NextHintUnlocksAfterAttempts: unlockedHints(*task, attempts).NextHintUnlocksAfterAttempts,
+	}
+	if !response.Unlocked {
+		response.UnlocksAfterAttempts = &threshold
+		return response, nil
+	}
+	response.Hint = task.Hints[index]
+
+	sm.lock.Lock()
+	if session, ok := sm.sessions[sessionID]; ok {
+		for i := range session.Tasks {
+			if session.Tasks[i].ID != taskID {
+				continue
+			}
+			status := &session.Tasks[i]
+			status.HintRequestedAt = time.Now()
+			// Count each hint once, however often it is requested
+			if !slices.Contains(status.RevealedHints, index) {
+				status.RevealedHints = append(status.RevealedHints, index)
+				status.HintsUsed = len(status.RevealedHints)
+			}
+			break
+		}
+	}
+	sm.lock.Unlock()
+
+	sm.logger.WithFields(logrus.Fields{
+		"sessionID": sessionID,
+		"taskID":    taskID,
+		"index":     index,
+	}).Info("Hint revealed")
+
+	return response, nil
+}
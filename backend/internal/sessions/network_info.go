@@ -0,0 +1,79 @@
+// backend/internal/sessions/network_info.go - Direct access to session clusters
+
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// adminKubeconfigPath is the kubeadm admin kubeconfig on the control plane VM
+const adminKubeconfigPath = "/etc/kubernetes/admin.conf"
+
+// networkInfoTimeout bounds waiting for the IPs of a new session's VMs
+const networkInfoTimeout = 3 * time.Minute
+
+// kubeconfigServerPattern matches the host of the API server URL in a kubeconfig
+var kubeconfigServerPattern = regexp.MustCompile(`(?m)^(\s*server:\s*https?://)[^:/\s]+`)
+
+// populateNetworkInfo records the IPs of a session's VMs once they are known
+func (sm *SessionManager) populateNetworkInfo(sessionID, namespace, controlPlaneVM, workerNodeVM string) {
+	ctx, cancel := context.WithTimeout(context.Background(), networkInfoTimeout)
+	defer cancel()
+
+	info := &models.NetworkInfo{
+		ControlPlaneIP: sm.kubevirtClient.GetVMIP(ctx, namespace, controlPlaneVM),
+		WorkerNodeIP:   sm.kubevirtClient.GetVMIP(ctx, namespace, workerNodeVM),
+		KubeconfigPath: adminKubeconfigPath,
+	}
+
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		return
+	}
+	session.NetworkInfo = info
+
+	sm.logger.WithFields(logrus.Fields{
+		"sessionID":      sessionID,
+		"controlPlaneIP": info.ControlPlaneIP,
+		"workerNodeIP":   info.WorkerNodeIP,
+	}).Debug("Session network info recorded")
+}
+
+// GetKubeconfig returns the admin kubeconfig of a session's cluster with the API server
+// address pointing at the control plane VM's IP
+func (sm *SessionManager) GetKubeconfig(ctx context.Context, sessionID string) ([]byte, error) {
+	sm.lock.RLock()
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		sm.lock.RUnlock()
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+	namespace := session.Namespace
+	controlPlaneVM := session.ControlPlaneVM
+	var controlPlaneIP string
+	if session.NetworkInfo != nil {
+		controlPlaneIP = session.NetworkInfo.ControlPlaneIP
+	}
+	sm.lock.RUnlock()
+
+	if controlPlaneIP == "" {
+		return nil, fmt.Errorf("IP address of control plane VM %s is not known yet", controlPlaneVM)
+	}
+
+	kubeconfig, err := sm.kubevirtClient.ExecuteCommandInVM(ctx, namespace, controlPlaneVM, "sudo cat "+adminKubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig: %w", err)
+	}
+
+	return kubeconfigServerPattern.ReplaceAll([]byte(kubeconfig), []byte("${1}"+controlPlaneIP)), nil
+}
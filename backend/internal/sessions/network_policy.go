@@ -0,0 +1,87 @@
+// backend/internal/sessions/network_policy.go - Network isolation of session namespaces
+
+package sessions
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// sessionNetworkPolicyName is the NetworkPolicy isolating a session namespace
+	sessionNetworkPolicyName = "session-isolation"
+	// kubevirtNamespace runs the KubeVirt components the VMs talk to
+	kubevirtNamespace = "kubevirt"
+)
+
+// createSessionNetworkPolicy isolates a session namespace from the other sessions. Pods in the
+// namespace, i.e. the VMs of the session cluster, only accept traffic from each other and may
+// only reach each other, the cluster DNS and the KubeVirt namespace.
+func (sm *SessionManager) createSessionNetworkPolicy(ctx context.Context, namespace string) error {
+	udp, tcp := corev1.ProtocolUDP, corev1.ProtocolTCP
+	dnsPort := intstr.FromInt32(53)
+	sameNamespace := []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: sessionNetworkPolicyName,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{From: sameNamespace},
+			},
+			Egress: []networkingv1.NetworkPolicyEgressRule{
+				{To: sameNamespace},
+				{
+					To: []networkingv1.NetworkPolicyPeer{{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"kubernetes.io/metadata.name": "kube-system"},
+						},
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"k8s-app": "kube-dns"},
+						},
+					}},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: &udp, Port: &dnsPort},
+						{Protocol: &tcp, Port: &dnsPort},
+					},
+				},
+				{
+					To: []networkingv1.NetworkPolicyPeer{{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"kubernetes.io/metadata.name": kubevirtNamespace},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	policies := sm.clientset.NetworkingV1().NetworkPolicies(namespace)
+	existing, err := policies.Get(ctx, sessionNetworkPolicyName, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		existing.Spec = policy.Spec
+		if _, err := policies.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update network policy: %w", err)
+		}
+		sm.logger.WithField("namespace", namespace).Info("Session network policy updated")
+	case k8serrors.IsNotFound(err):
+		if _, err := policies.Create(ctx, policy, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create network policy: %w", err)
+		}
+		sm.logger.WithField("namespace", namespace).Info("Session network policy created")
+	default:
+		return fmt.Errorf("failed to check existing network policy: %w", err)
+	}
+
+	return nil
+}
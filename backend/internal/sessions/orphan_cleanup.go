@@ -0,0 +1,88 @@
+// backend/internal/sessions/orphan_cleanup.go - Removal of session namespaces left behind by a crash
+
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// orphanCleanupDelay gives provisioning started by a sibling pod during a rolling restart time to register
+const orphanCleanupDelay = 60 * time.Second
+
+// sessionNamespaceSelector matches namespaces created by createNamespace
+const sessionNamespaceSelector = "cks.io/session=true"
+
+// startOrphanCleanup removes orphaned session namespaces once the startup delay has passed
+func (sm *SessionManager) startOrphanCleanup() {
+	select {
+	case <-time.After(orphanCleanupDelay):
+	case <-sm.stopCh:
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if err := sm.cleanupOrphanedNamespaces(ctx); err != nil {
+		sm.logger.WithError(err).Error("Failed to clean up orphaned session namespaces")
+	}
+}
+
+// cleanupOrphanedNamespaces deletes session namespaces that belong neither to a loaded
+// session nor to the cluster pool
+func (sm *SessionManager) cleanupOrphanedNamespaces(ctx context.Context) error {
+	namespaces, err := sm.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
+		LabelSelector: sessionNamespaceSelector,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list session namespaces: %w", err)
+	}
+
+	// Pool cluster namespaces carry the session label but are reused across sessions
+	inUse := make(map[string]bool)
+	for _, cluster := range sm.clusterPool.ListClusters() {
+		inUse[cluster.Namespace] = true
+	}
+	for _, clusterID := range sm.clusterPool.ClusterIDs() {
+		inUse[clusterID] = true
+	}
+
+	sm.lock.RLock()
+	for _, session := range sm.sessions {
+		inUse[session.Namespace] = true
+	}
+	sm.lock.RUnlock()
+
+	orphaned := 0
+	for _, ns := range namespaces.Items {
+		if inUse[ns.Name] || ns.DeletionTimestamp != nil {
+			continue
+		}
+
+		orphaned++
+		sm.logger.WithField("namespace", ns.Name).Warn("Found orphaned session namespace, scheduling deletion")
+
+		go func(namespace string) {
+			deleteCtx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			if err := sm.clientset.CoreV1().Namespaces().Delete(deleteCtx, namespace, metav1.DeleteOptions{}); err != nil {
+				sm.logger.WithError(err).WithField("namespace", namespace).Error("Failed to delete orphaned session namespace")
+				return
+			}
+			sm.logger.WithField("namespace", namespace).Info("Deleted orphaned session namespace")
+		}(ns.Name)
+	}
+
+	sm.logger.WithFields(logrus.Fields{
+		"sessionNamespaces": len(namespaces.Items),
+		"orphaned":          orphaned,
+	}).Info("Orphaned session namespace scan completed")
+
+	return nil
+}
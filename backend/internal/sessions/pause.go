@@ -0,0 +1,96 @@
+// backend/internal/sessions/pause.go - Pausing and resuming session VMs
+
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// ErrInvalidSessionState is returned when a session or its VMs are not in a state that allows the operation
+var ErrInvalidSessionState = errors.New("invalid session state")
+
+// PauseSession pauses the session VMs and stops the expiration countdown
+func (sm *SessionManager) PauseSession(ctx context.Context, sessionID string) error {
+	sm.lock.RLock()
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		sm.lock.RUnlock()
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+	status := session.Status
+	namespace := session.Namespace
+	vmNames := []string{session.ControlPlaneVM, session.WorkerNodeVM}
+	sm.lock.RUnlock()
+
+	if status != models.SessionStatusRunning {
+		return fmt.Errorf("%w: cannot pause session in status %s", ErrInvalidSessionState, status)
+	}
+
+	for i, vmName := range vmNames {
+		if err := sm.kubevirtClient.PauseVM(ctx, namespace, vmName); err != nil {
+			// Leave the session running rather than half paused
+			for _, paused := range vmNames[:i] {
+				if resumeErr := sm.kubevirtClient.ResumeVM(ctx, namespace, paused); resumeErr != nil {
+					sm.logger.WithError(resumeErr).WithField("vmName", paused).Error("Failed to resume VM after pause failure")
+				}
+			}
+			return err
+		}
+	}
+
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+
+	session.PausedAt = time.Now()
+	sm.setSessionStatus(session, models.SessionStatusPaused, "Paused by user")
+
+	return nil
+}
+
+// ResumeSession resumes the session VMs and restarts the expiration countdown from the time
+// that was remaining when the session was paused
+func (sm *SessionManager) ResumeSession(ctx context.Context, sessionID string) error {
+	sm.lock.RLock()
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		sm.lock.RUnlock()
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+	status := session.Status
+	namespace := session.Namespace
+	vmNames := []string{session.ControlPlaneVM, session.WorkerNodeVM}
+	sm.lock.RUnlock()
+
+	if status != models.SessionStatusPaused {
+		return fmt.Errorf("%w: cannot resume session in status %s", ErrInvalidSessionState, status)
+	}
+
+	for _, vmName := range vmNames {
+		if err := sm.kubevirtClient.ResumeVM(ctx, namespace, vmName); err != nil {
+			return err
+		}
+	}
+
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+
+	pausedFor := time.Since(session.PausedAt)
+	session.ExpirationTime = session.ExpirationTime.Add(pausedFor)
+	session.PausedAt = time.Time{}
+	sm.setSessionStatus(session, models.SessionStatusRunning, "")
+
+	sm.logger.WithFields(logrus.Fields{
+		"sessionID":      sessionID,
+		"pausedFor":      pausedFor.Round(time.Second),
+		"expirationTime": session.ExpirationTime,
+	}).Info("Session resumed")
+
+	return nil
+}
@@ -0,0 +1,163 @@
+// backend/internal/sessions/provisioning_log.go - Log lines recorded while a session is provisioned
+
+package sessions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// provisioningLogSize is the number of log lines kept per session
+const provisioningLogSize = 200
+
+// provisioningLogSubscriberBuffer is the number of lines queued per subscriber before lines are dropped
+const provisioningLogSubscriberBuffer = 64
+
+// provisioningLog holds the last log lines of a session's provisioning and the subscribers
+// following it. It is guarded by SessionManager.provisioningLogsLock.
+type provisioningLog struct {
+	lines       []string
+	subscribers map[chan string]struct{}
+	done        bool // Provisioning finished, no more lines are recorded
+}
+
+// provisioningLogHook records log entries carrying a sessionID field in that session's
+// provisioning log. It must not log, since it runs inside logger calls.
+type provisioningLogHook struct {
+	sm        *SessionManager
+	formatter logrus.Formatter
+}
+
+func newProvisioningLogHook(sm *SessionManager) *provisioningLogHook {
+	return &provisioningLogHook{
+		sm:        sm,
+		formatter: &logrus.TextFormatter{DisableColors: true, FullTimestamp: true},
+	}
+}
+
+// Levels records info and more severe entries
+func (h *provisioningLogHook) Levels() []logrus.Level {
+	return logrus.AllLevels[:logrus.InfoLevel+1]
+}
+
+// Fire appends the formatted entry to the provisioning log of its session, if one is recorded
+func (h *provisioningLogHook) Fire(entry *logrus.Entry) error {
+	sessionID, ok := entry.Data["sessionID"].(string)
+	if !ok || sessionID == "" {
+		return nil
+	}
+
+	// Skip formatting entries of sessions that are not provisioning
+	h.sm.provisioningLogsLock.Lock()
+	log, ok := h.sm.provisioningLogs[sessionID]
+	recording := ok && !log.done
+	h.sm.provisioningLogsLock.Unlock()
+	if !recording {
+		return nil
+	}
+
+	formatted, err := h.formatter.Format(entry)
+	if err != nil {
+		return fmt.Errorf("failed to format provisioning log entry: %w", err)
+	}
+	h.sm.appendProvisioningLog(sessionID, strings.TrimRight(string(formatted), "\n"))
+	return nil
+}
+
+// startProvisioningLog starts recording the log lines of a session
+func (sm *SessionManager) startProvisioningLog(sessionID string) {
+	sm.provisioningLogsLock.Lock()
+	defer sm.provisioningLogsLock.Unlock()
+
+	sm.provisioningLogs[sessionID] = &provisioningLog{subscribers: make(map[chan string]struct{})}
+}
+
+// appendProvisioningLog adds a line to a session's provisioning log, dropping the oldest line
+// when it is full, and delivers it to subscribers without blocking
+func (sm *SessionManager) appendProvisioningLog(sessionID, line string) {
+	sm.provisioningLogsLock.Lock()
+	defer sm.provisioningLogsLock.Unlock()
+
+	log, ok := sm.provisioningLogs[sessionID]
+	if !ok || log.done {
+		return
+	}
+	if len(log.lines) >= provisioningLogSize {
+		log.lines = append(log.lines[:0], log.lines[len(log.lines)-provisioningLogSize+1:]...)
+	}
+	log.lines = append(log.lines, line)
+
+	for ch := range log.subscribers {
+		select {
+		case ch <- line:
+		default:
+		}
+	}
+}
+
+// finishProvisioningLog stops recording a session's provisioning log and ends its subscriptions.
+// The recorded lines stay available until the session is deleted.
+func (sm *SessionManager) finishProvisioningLog(sessionID string) {
+	sm.provisioningLogsLock.Lock()
+	defer sm.provisioningLogsLock.Unlock()
+
+	log, ok := sm.provisioningLogs[sessionID]
+	if !ok || log.done {
+		return
+	}
+	log.done = true
+	for ch := range log.subscribers {
+		close(ch)
+	}
+	log.subscribers = nil
+}
+
+// deleteProvisioningLog ends the subscriptions to a session's provisioning log and discards it
+func (sm *SessionManager) deleteProvisioningLog(sessionID string) {
+	sm.finishProvisioningLog(sessionID)
+
+	sm.provisioningLogsLock.Lock()
+	defer sm.provisioningLogsLock.Unlock()
+	delete(sm.provisioningLogs, sessionID)
+}
+
+// SubscribeProvisioningLog returns the provisioning log lines recorded so far and a channel
+// of the lines that follow. The channel is closed once provisioning finishes, immediately if
+// it already has, or when the session is deleted. The returned function unsubscribes.
+func (sm *SessionManager) SubscribeProvisioningLog(sessionID string) ([]string, <-chan string, func(), error) {
+	sm.lock.RLock()
+	_, ok := sm.sessions[sessionID]
+	sm.lock.RUnlock()
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	sm.provisioningLogsLock.Lock()
+	defer sm.provisioningLogsLock.Unlock()
+
+	ch := make(chan string, provisioningLogSubscriberBuffer)
+	log, ok := sm.provisioningLogs[sessionID]
+	if !ok || log.done {
+		close(ch)
+		var lines []string
+		if ok {
+			lines = append(lines, log.lines...)
+		}
+		return lines, ch, func() {}, nil
+	}
+	log.subscribers[ch] = struct{}{}
+
+	unsubscribe := func() {
+		sm.provisioningLogsLock.Lock()
+		defer sm.provisioningLogsLock.Unlock()
+
+		if _, ok := log.subscribers[ch]; ok {
+			delete(log.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return append([]string(nil), log.lines...), ch, unsubscribe, nil
+}
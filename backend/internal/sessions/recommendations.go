@@ -0,0 +1,104 @@
+// backend/internal/sessions/recommendations.go - Scenario recommendations based on user progress
+
+package sessions
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// maxRecommendations is the number of scenarios returned by RecommendScenarios
+const maxRecommendations = 5
+
+// nextDifficulty is the difficulty recommended after completing a scenario of each difficulty
+var nextDifficulty = map[string]string{
+	"beginner":     "intermediate",
+	"intermediate": "advanced",
+	"advanced":     "advanced",
+}
+
+// RecommendScenarios returns up to five IDs of scenarios the user has not completed yet.
+// Scenarios whose prerequisites are met come first, then those one difficulty step above
+// the most recently completed scenario, then by ID. Anonymous users get a random selection.
+func (sm *SessionManager) RecommendScenarios(userID string) ([]string, error) {
+	scenarios, err := sm.scenarioManager.ListScenarios("", "", "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scenarios: %w", err)
+	}
+
+	if userID == "" {
+		rand.Shuffle(len(scenarios), func(i, j int) {
+			scenarios[i], scenarios[j] = scenarios[j], scenarios[i]
+		})
+		return firstScenarioIDs(scenarios, maxRecommendations), nil
+	}
+
+	targetDifficulty := "beginner"
+	if completed := sm.userProgress.Completed(userID); len(completed) > 0 {
+		if last, err := sm.scenarioManager.GetScenario(completed[len(completed)-1]); err == nil {
+			if next, ok := nextDifficulty[last.Difficulty]; ok {
+				targetDifficulty = next
+			}
+		}
+	}
+
+	type candidate struct {
+		scenario        *models.Scenario
+		prerequisitesOK bool
+		difficultyMatch bool
+	}
+
+	var candidates []candidate
+	for _, scenario := range scenarios {
+		if sm.userProgress.HasCompleted(userID, scenario.ID) {
+			continue
+		}
+
+		prerequisitesOK := true
+		for _, prerequisite := range scenario.Prerequisites {
+			if !sm.userProgress.HasCompleted(userID, prerequisite) {
+				prerequisitesOK = false
+				break
+			}
+		}
+
+		candidates = append(candidates, candidate{
+			scenario:        scenario,
+			prerequisitesOK: prerequisitesOK,
+			difficultyMatch: scenario.Difficulty == targetDifficulty,
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.prerequisitesOK != b.prerequisitesOK {
+			return a.prerequisitesOK
+		}
+		if a.difficultyMatch != b.difficultyMatch {
+			return a.difficultyMatch
+		}
+		return a.scenario.ID < b.scenario.ID
+	})
+
+	ranked := make([]*models.Scenario, len(candidates))
+	for i, c := range candidates {
+		ranked[i] = c.scenario
+	}
+	return firstScenarioIDs(ranked, maxRecommendations), nil
+}
+
+// firstScenarioIDs returns the IDs of up to limit scenarios
+func firstScenarioIDs(scenarios []*models.Scenario, limit int) []string {
+	if len(scenarios) > limit {
+		scenarios = scenarios[:limit]
+	}
+
+	ids := make([]string, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		ids = append(ids, scenario.ID)
+	}
+	return ids
+}
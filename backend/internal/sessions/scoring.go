@@ -0,0 +1,112 @@
+// backend/internal/sessions/scoring.go - Session scoring based on task completion and time taken
+
+package sessions
+
+import (
+	"time"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// defaultPointsPerTask is used when a scenario does not configure scoring
+const defaultPointsPerTask = 10
+
+// defaultSolutionPenaltyPercent is used when a scenario does not set SolutionPenaltyPercent
+const defaultSolutionPenaltyPercent = 50
+
+// calculateScore awards points to newly completed tasks and updates the session total.
+// Points are awarded once per task, so later re-validation does not change them. Tasks
+// completed after revealing their solution lose the solution penalty and get no time bonus.
+func calculateScore(session *models.Session, scenario *models.Scenario) {
+	pointsPerTask := defaultPointsPerTask
+	var scoreConfig models.ScoreConfig
+	if scenario != nil {
+		scoreConfig = scenario.ScoreConfig
+		if scoreConfig.PointsPerTask > 0 {
+			pointsPerTask = scoreConfig.PointsPerTask
+		}
+	}
+
+	total := 0
+	for i := range session.Tasks {
+		task := &session.Tasks[i]
+
+		if task.Status == "completed" && task.Points == 0 && !task.CompletedAt.IsZero() {
+			task.Points = pointsPerTask
+			if task.SolutionRevealed && task.SolutionRevealedAt.Before(task.CompletedAt) {
+				task.Points = int(float64(pointsPerTask) * (100 - solutionPenaltyPercent(scoreConfig)) / 100)
+			} else if scoreConfig.TimeBonusEnabled {
+				task.TimeBonus = timeBonus(session, scenario, task, pointsPerTask)
+			}
+		}
+
+		total += task.Points + task.TimeBonus
+	}
+
+	session.Score = total
+}
+
+// solutionPenaltyPercent returns the share of a task's points lost by revealing its solution
+func solutionPenaltyPercent(scoreConfig models.ScoreConfig) float64 {
+	if scoreConfig.SolutionPenaltyPercent <= 0 {
+		return defaultSolutionPenaltyPercent
+	}
+	return min(scoreConfig.SolutionPenaltyPercent, 100)
+}
+
+// timeBonus scales the maximum bonus by how much of the task's time estimate was left unused.
+// Time is measured from the previous task completion, or the session start for the first task.
+// Tasks completed after their deadline earn no bonus.
+func timeBonus(session *models.Session, scenario *models.Scenario, task *models.TaskStatus, points int) int {
+	if task.TimedOut {
+		return 0
+	}
+
+	estimate := 0
+	for _, scenarioTask := range scenario.Tasks {
+		if scenarioTask.ID == task.ID {
+			estimate = scenarioTask.TimeEstimateSeconds
+			break
+		}
+	}
+	if estimate <= 0 || scenario.ScoreConfig.MaxTimeBonusPercent <= 0 {
+		return 0
+	}
+
+	started := session.StartTime
+	for _, other := range session.Tasks {
+		if other.ID != task.ID && other.CompletedAt.After(started) && other.CompletedAt.Before(task.CompletedAt) {
+			started = other.CompletedAt
+		}
+	}
+
+	limit := time.Duration(estimate) * time.Second
+	taken := task.CompletedAt.Sub(started)
+	if taken >= limit {
+		return 0
+	}
+
+	unused := float64(limit-taken) / float64(limit)
+	return int(float64(points) * scenario.ScoreConfig.MaxTimeBonusPercent / 100 * unused)
+}
+
+// buildScoreBreakdown reports the points earned by each task in a session
+func buildScoreBreakdown(session *models.Session) *models.ScoreBreakdown {
+	breakdown := &models.ScoreBreakdown{
+		SessionID: session.ID,
+		Tasks:     make([]models.TaskScore, 0, len(session.Tasks)),
+	}
+
+	for _, task := range session.Tasks {
+		breakdown.Tasks = append(breakdown.Tasks, models.TaskScore{
+			TaskID:    task.ID,
+			Status:    task.Status,
+			Points:    task.Points,
+			TimeBonus: task.TimeBonus,
+			Total:     task.Points + task.TimeBonus,
+		})
+		breakdown.Total += task.Points + task.TimeBonus
+	}
+
+	return breakdown
+}
@@ -5,11 +5,15 @@ package sessions
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
@@ -18,12 +22,16 @@ import (
 	"github.com/fullstack-pw/cks/backend/internal/kubevirt"
 	"github.com/fullstack-pw/cks/backend/internal/models"
 	"github.com/fullstack-pw/cks/backend/internal/scenarios"
+	"github.com/fullstack-pw/cks/backend/internal/tracing"
 	"github.com/fullstack-pw/cks/backend/internal/validation"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// completedSessionGracePeriod is how long a completed session is kept for the user to review results
+const completedSessionGracePeriod = 5 * time.Minute
+
 type SessionManager struct {
 	sessions            map[string]*models.Session
 	lock                sync.RWMutex
@@ -31,11 +39,36 @@ type SessionManager struct {
 	kubevirtClient      *kubevirt.Client
 	config              *config.Config
 	unifiedValidator    *validation.UnifiedValidator
+	validationClient    *validation.GRPCClient // Remote validation service, nil to validate locally
 	logger              *logrus.Logger
 	stopCh              chan struct{}
 	scenarioManager     *scenarios.ScenarioManager
 	clusterPool         *clusterpool.Manager
 	terminalCleanupFunc func(sessionID string)
+	taskResultObserver  func(session models.Session, taskID, status string)
+	userProgress        UserProgressStore
+
+	// In-flight validations, waited for on shutdown
+	validations       sync.WaitGroup
+	activeValidations int64
+
+	// Semaphore of MaxConcurrentValidations slots, see AcquireValidationSlot
+	validationSlots chan struct{}
+
+	// Event stream subscribers and the expiry warnings already sent, keyed by session ID
+	subscribers        map[string]map[chan models.SessionEvent]struct{}
+	expiryWarningsSent map[string]map[int]bool
+	subscribersLock    sync.Mutex
+
+	// Last task deadline warning sent, keyed by session and task ID; guarded by subscribersLock
+	taskDeadlineWarningsSent map[string]map[string]taskDeadlineWarning
+
+	// Cancels the cluster wait of queued sessions, keyed by session ID; guarded by lock
+	clusterWaits map[string]context.CancelFunc
+
+	// Log lines recorded while sessions are provisioned, keyed by session ID
+	provisioningLogs     map[string]*provisioningLog
+	provisioningLogsLock sync.Mutex
 }
 
 func NewSessionManager(
@@ -56,7 +89,31 @@ func NewSessionManager(
 		logger:           logger,
 		stopCh:           make(chan struct{}),
 		scenarioManager:  scenarioManager,
-		clusterPool:      clusterPool, // Add this line
+		clusterPool:      clusterPool,
+		userProgress:     NewInMemoryUserProgressStore(),
+		clusterWaits:     make(map[string]context.CancelFunc),
+
+		subscribers:        make(map[string]map[chan models.SessionEvent]struct{}),
+		expiryWarningsSent: make(map[string]map[int]bool),
+		provisioningLogs:   make(map[string]*provisioningLog),
+
+		taskDeadlineWarningsSent: make(map[string]map[string]taskDeadlineWarning),
+	}
+	logger.AddHook(newProvisioningLogHook(sm))
+
+	maxValidations := cfg.MaxConcurrentValidations
+	if maxValidations < 1 {
+		maxValidations = 1
+	}
+	sm.validationSlots = make(chan struct{}, maxValidations)
+
+	if cfg.ValidationServiceEndpoint != "" {
+		client, err := validation.NewGRPCClient(cfg.ValidationServiceEndpoint, cfg.ValidationGRPCToken, cfg.ValidationServiceCAFile)
+		if err != nil {
+			return nil, err
+		}
+		sm.validationClient = client
+		logger.WithField("endpoint", cfg.ValidationServiceEndpoint).Info("Using remote validation service")
 	}
 
 	// Clean stale terminals after backend restart
@@ -65,6 +122,11 @@ func NewSessionManager(
 	// Start session cleanup goroutine
 	go sm.cleanupExpiredSessions()
 
+	// Remove session namespaces left behind by a previous crash
+	if cfg.OrphanCleanupEnabled {
+		go sm.startOrphanCleanup()
+	}
+
 	return sm, nil
 }
 
@@ -73,8 +135,29 @@ func (sm *SessionManager) SetTerminalCleanupFunc(cleanupFunc func(sessionID stri
 	sm.terminalCleanupFunc = cleanupFunc
 }
 
+// SetTaskResultObserver sets a callback invoked after every stored task validation result.
+// It runs with the session lock held and must not call back into the SessionManager.
+func (sm *SessionManager) SetTaskResultObserver(observer func(session models.Session, taskID, status string)) {
+	sm.taskResultObserver = observer
+}
+
+// SetUserProgressStore replaces the store used to check scenario prerequisites
+func (sm *SessionManager) SetUserProgressStore(store UserProgressStore) {
+	sm.userProgress = store
+}
+
 // CreateSession creates a new session using cluster pool assignment
-func (sm *SessionManager) CreateSession(ctx context.Context, scenarioID string) (*models.Session, error) {
+func (sm *SessionManager) CreateSession(ctx context.Context, scenarioID string, opts models.SessionOptions) (*models.Session, error) {
+	ctx, span := tracing.Start(ctx, "SessionManager.CreateSession", attribute.String("scenario.id", scenarioID))
+	session, err := sm.createSession(ctx, scenarioID, opts)
+	if session != nil {
+		span.SetAttributes(attribute.String("session.id", session.ID), attribute.String("cluster.id", session.AssignedCluster))
+	}
+	tracing.End(span, err)
+	return session, err
+}
+
+func (sm *SessionManager) createSession(ctx context.Context, scenarioID string, opts models.SessionOptions) (*models.Session, error) {
 	sm.lock.Lock()
 	defer sm.lock.Unlock()
 
@@ -83,34 +166,49 @@ func (sm *SessionManager) CreateSession(ctx context.Context, scenarioID string)
 		return nil, fmt.Errorf("maximum number of concurrent sessions reached")
 	}
 
+	// Load scenario and check prerequisites before allocating any resources
+	var scenario *models.Scenario
+	if scenarioID != "" {
+		var err error
+		scenario, err = sm.loadScenario(ctx, scenarioID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load scenario: %w", err)
+		}
+
+		if scenario.Deprecated && !sm.config.AllowDeprecatedScenarios {
+			return nil, &ScenarioDeprecatedError{ScenarioID: scenario.ID, Message: scenario.DeprecationMessage}
+		}
+
+		if err := sm.checkPrerequisites(scenario, opts.UserID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Generate session ID
 	sessionID := uuid.New().String()[:8]
 
-	// Assign cluster from pool
+	// Assign cluster from pool; when the pool is exhausted the session waits for a cluster
 	assignedCluster, err := sm.clusterPool.AssignCluster(sessionID)
-	if err != nil {
+	queued := isPoolExhausted(err)
+	if err != nil && !queued {
 		return nil, fmt.Errorf("failed to assign cluster: %w", err)
 	}
+	sm.startProvisioningLog(sessionID)
 
-	sm.logger.WithFields(logrus.Fields{
-		"sessionID": sessionID,
-		"clusterID": assignedCluster.ClusterID,
-		"namespace": assignedCluster.Namespace,
-	}).Info("Cluster assigned to session")
+	if !queued {
+		sm.logger.WithFields(logrus.Fields{
+			"sessionID": sessionID,
+			"clusterID": assignedCluster.ClusterID,
+			"namespace": assignedCluster.Namespace,
+		}).Info("Cluster assigned to session")
+	}
 
 	// Initialize variables
 	var tasks []models.TaskStatus
 	var scenarioTitle string
 
-	// Load scenario if specified
-	if scenarioID != "" {
-		scenario, err := sm.loadScenario(ctx, scenarioID)
-		if err != nil {
-			// Release cluster on error
-			sm.clusterPool.ReleaseCluster(sessionID)
-			return nil, fmt.Errorf("failed to load scenario: %w", err)
-		}
-
+	// Initialize tasks if a scenario was specified
+	if scenario != nil {
 		// Store scenario title for logging
 		scenarioTitle = scenario.Title
 
@@ -131,26 +229,40 @@ func (sm *SessionManager) CreateSession(ctx context.Context, scenarioID string)
 		}).Info("Initialized session with scenario tasks")
 	}
 
-	// Create session object using assigned cluster
+	// Create session object
 	session := &models.Session{
 		ID:               sessionID,
-		Namespace:        assignedCluster.Namespace, // Use cluster namespace
 		ScenarioID:       scenarioID,
+		UserID:           opts.UserID,
+		ClientIP:         opts.ClientIP,
+		UserAgent:        opts.UserAgent,
+		CreatedBy:        createdBy(opts.UserID),
+		Tags:             opts.Tags,
 		Status:           models.SessionStatusRunning, // Immediate running status
 		StartTime:        time.Now(),
 		ExpirationTime:   time.Now().Add(time.Duration(sm.config.SessionTimeoutMinutes) * time.Minute),
-		ControlPlaneVM:   assignedCluster.ControlPlaneVM, // Use cluster VMs
-		WorkerNodeVM:     assignedCluster.WorkerNodeVM,   // Use cluster VMs
 		Tasks:            tasks,
 		TerminalSessions: make(map[string]string),
 		ActiveTerminals:  make(map[string]models.TerminalInfo),
-		AssignedCluster:  assignedCluster.ClusterID, // Track assigned cluster
-		ClusterLockTime:  assignedCluster.LockTime,  // Track lock time
 	}
 
 	// Store session
 	sm.sessions[sessionID] = session
 
+	if queued {
+		session.Status = models.SessionStatusQueued
+		session.StatusMessage = "Waiting for a cluster to become available"
+		sm.logger.WithFields(logrus.Fields{
+			"sessionID":  sessionID,
+			"scenarioID": scenarioID,
+		}).Info("No cluster available, session queued")
+
+		sm.notifyWebhook(EventSessionCreated, newSessionEventPayload(session, ""))
+		sm.queueSession(session, scenario)
+		return session, nil
+	}
+	setSessionCluster(session, assignedCluster)
+
 	sm.logger.WithFields(logrus.Fields{
 		"sessionID":      sessionID,
 		"clusterID":      assignedCluster.ClusterID,
@@ -161,20 +273,90 @@ func (sm *SessionManager) CreateSession(ctx context.Context, scenarioID string)
 		"workerNodeVM":   session.WorkerNodeVM,
 	}).Info("Session created with assigned cluster - ready immediately")
 
+	sm.notifyWebhook(EventSessionCreated, newSessionEventPayload(session, ""))
+	sm.provisionSession(session, scenario)
+
+	return session, nil
+}
+
+// setSessionCluster records the pool cluster a session runs on
+func setSessionCluster(session *models.Session, cluster *models.ClusterPool) {
+	session.Namespace = cluster.Namespace
+	session.ControlPlaneVM = cluster.ControlPlaneVM
+	session.WorkerNodeVM = cluster.WorkerNodeVM
+	session.AssignedCluster = cluster.ClusterID
+	session.ClusterLockTime = cluster.LockTime
+}
+
+// provisionSession starts the background work of a session that was assigned a cluster:
+// resolving the VM addresses and setting up its scenario. Must be called with sm.lock held.
+func (sm *SessionManager) provisionSession(session *models.Session, scenario *models.Scenario) {
+	sessionID := session.ID
+
+	go sm.populateNetworkInfo(sessionID, session.Namespace, session.ControlPlaneVM, session.WorkerNodeVM)
+
 	// Initialize scenario in background if needed
-	if scenarioID != "" {
+	if scenario != nil {
 		go func() {
+			defer sm.finishProvisioningLog(sessionID)
+
 			initCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 			defer cancel()
 
+			// Resource-intensive scenarios may ask for a larger namespace quota
+			if limits := scenario.Requirements.ResourceLimits; limits != (models.ResourceLimits{}) {
+				if err := sm.setupResourceQuotasForScenario(initCtx, session.Namespace, limits); err != nil {
+					sm.logger.WithError(err).WithField("sessionID", sessionID).Error("Failed to apply scenario resource quota")
+				}
+			}
+
 			err := sm.initializeScenario(initCtx, session)
 			if err != nil {
 				sm.logger.WithError(err).WithField("sessionID", sessionID).Error("Failed to initialize scenario (session still usable)")
 			}
 		}()
+	} else {
+		sm.finishProvisioningLog(sessionID)
 	}
+}
 
-	return session, nil
+// createdBy returns the creator recorded on a session
+func createdBy(userID string) string {
+	if userID == "" {
+		return "anonymous"
+	}
+	return userID
+}
+
+// checkPrerequisites verifies that the user has completed every prerequisite of the scenario
+func (sm *SessionManager) checkPrerequisites(scenario *models.Scenario, userID string) error {
+	var unmet []string
+	for _, prerequisite := range scenario.Prerequisites {
+		if !sm.userProgress.HasCompleted(userID, prerequisite) {
+			unmet = append(unmet, prerequisite)
+		}
+	}
+
+	if len(unmet) > 0 {
+		sm.logger.WithFields(logrus.Fields{
+			"scenarioID": scenario.ID,
+			"userID":     userID,
+			"unmet":      unmet,
+		}).Info("Session creation rejected due to unmet prerequisites")
+		return &PrerequisitesNotMetError{ScenarioID: scenario.ID, Unmet: unmet}
+	}
+
+	return nil
+}
+
+// ScenarioDeprecatedError is returned when a session is requested for a deprecated scenario
+type ScenarioDeprecatedError struct {
+	ScenarioID string
+	Message    string
+}
+
+func (e *ScenarioDeprecatedError) Error() string {
+	return fmt.Sprintf("scenario %s is deprecated: %s", e.ScenarioID, e.Message)
 }
 
 // GetSession returns a session by ID
@@ -190,6 +372,138 @@ func (sm *SessionManager) GetSession(sessionID string) (*models.Session, error)
 	return session, nil
 }
 
+// GetSessionProgress computes task completion and timing information for a session
+func (sm *SessionManager) GetSessionProgress(sessionID string) (*models.SessionProgress, error) {
+	sm.lock.RLock()
+	defer sm.lock.RUnlock()
+
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	now := time.Now()
+	progress := &models.SessionProgress{
+		SessionID:      sessionID,
+		TotalTasks:     len(session.Tasks),
+		ElapsedSeconds: int64(now.Sub(session.StartTime).Seconds()),
+		TaskSummary:    make([]models.TaskProgressItem, 0, len(session.Tasks)),
+	}
+
+	if remaining := session.ExpirationTime.Sub(now); remaining > 0 {
+		progress.RemainingSeconds = int64(remaining.Seconds())
+	}
+
+	for _, task := range session.Tasks {
+		if task.Status == "completed" {
+			progress.CompletedTasks++
+		}
+
+		progress.TaskSummary = append(progress.TaskSummary, models.TaskProgressItem{
+			ID:             task.ID,
+			Status:         task.Status,
+			ValidationTime: task.ValidationTime,
+		})
+	}
+
+	if progress.TotalTasks > 0 {
+		progress.PercentComplete = float64(progress.CompletedTasks) / float64(progress.TotalTasks) * 100
+	}
+
+	return progress, nil
+}
+
+// GetScoreBreakdown returns the per-task points earned in a session
+func (sm *SessionManager) GetScoreBreakdown(sessionID string) (*models.ScoreBreakdown, error) {
+	sm.lock.RLock()
+	defer sm.lock.RUnlock()
+
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	return buildScoreBreakdown(session), nil
+}
+
+// GetTaskHints returns the hints of a task unlocked by the number of validation attempts
+func (sm *SessionManager) GetTaskHints(sessionID, taskID string) (*models.TaskHints, error) {
+	sm.lock.RLock()
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		sm.lock.RUnlock()
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+	scenarioID := session.ScenarioID
+	attempts := 0
+	for _, task := range session.Tasks {
+		if task.ID == taskID {
+			attempts = task.AttemptCount
+			break
+		}
+	}
+	sm.lock.RUnlock()
+
+	scenario, err := sm.scenarioManager.GetScenario(scenarioID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scenario: %w", err)
+	}
+
+	for _, task := range scenario.Tasks {
+		if task.ID == taskID {
+			return unlockedHints(task, attempts), nil
+		}
+	}
+
+	return nil, fmt.Errorf("task not found: %s", taskID)
+}
+
+// unlockedHints selects the hints shown after the given number of attempts. Every hint is
+// unlocked when the task has no unlock thresholds.
+func unlockedHints(task models.Task, attempts int) *models.TaskHints {
+	hints := &models.TaskHints{
+		Hints:        make([]string, 0, len(task.Hints)),
+		AttemptCount: attempts,
+	}
+
+	for i, hint := range task.Hints {
+		threshold := 0
+		if i < len(task.HintUnlockAfterAttempts) {
+			threshold = task.HintUnlockAfterAttempts[i]
+		}
+
+		if attempts >= threshold {
+			hints.Hints = append(hints.Hints, hint)
+		} else if hints.NextHintUnlocksAfterAttempts == nil || threshold < *hints.NextHintUnlocksAfterAttempts {
+			next := threshold
+			hints.NextHintUnlocksAfterAttempts = &next
+		}
+	}
+
+	return hints
+}
+
+// ListTerminals returns the terminals of a session ordered by ID
+func (sm *SessionManager) ListTerminals(sessionID string) ([]models.TerminalInfo, error) {
+	sm.lock.RLock()
+	defer sm.lock.RUnlock()
+
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	terminals := make([]models.TerminalInfo, 0, len(session.ActiveTerminals))
+	for _, terminalInfo := range session.ActiveTerminals {
+		terminals = append(terminals, terminalInfo)
+	}
+	sort.Slice(terminals, func(i, j int) bool {
+		return terminals[i].ID < terminals[j].ID
+	})
+
+	return terminals, nil
+}
+
 // ListSessions returns all active sessions
 func (sm *SessionManager) ListSessions() []*models.Session {
 	sm.lock.RLock()
@@ -212,10 +526,34 @@ func (sm *SessionManager) DeleteSession(ctx context.Context, sessionID string) e
 		return fmt.Errorf("session not found: %s", sessionID)
 	}
 
+	// Close terminal connections before the session disappears so none are left dangling
+	if sm.terminalCleanupFunc != nil {
+		sm.terminalCleanupFunc(sessionID)
+		sm.logger.WithField("sessionID", sessionID).Info("Cleaned up persistent terminal connections for deleted session")
+	}
+	session.ActiveTerminals = make(map[string]models.TerminalInfo)
+	session.TerminalSessions = make(map[string]string)
+
+	// Stop waiting for a cluster if the session is queued
+	if cancel, ok := sm.clusterWaits[sessionID]; ok {
+		cancel()
+		delete(sm.clusterWaits, sessionID)
+	}
+
 	// Remove from session map immediately
 	delete(sm.sessions, sessionID)
+	payload := newSessionEventPayload(session, "")
 	sm.lock.Unlock()
 
+	sm.notifyWebhook(EventSessionDeleted, payload)
+	sm.closeSubscribers(sessionID)
+	sm.deleteProvisioningLog(sessionID)
+
+	// User snapshots would otherwise be left behind in the namespace for the next session
+	if session.UserSnapshotName != "" {
+		go sm.deleteUserSnapshots(context.Background(), session.Namespace, session.UserSnapshotName)
+	}
+
 	sm.logger.WithFields(logrus.Fields{
 		"sessionID": sessionID,
 		"clusterID": session.AssignedCluster,
@@ -223,6 +561,13 @@ func (sm *SessionManager) DeleteSession(ctx context.Context, sessionID string) e
 
 	// Release cluster back to pool
 	if session.AssignedCluster != "" {
+		// Restore the default quota if the scenario changed it
+		if scenario, err := sm.loadScenario(ctx, session.ScenarioID); err == nil && scenario.Requirements.ResourceLimits != (models.ResourceLimits{}) {
+			if err := sm.setupResourceQuotas(ctx, session.Namespace); err != nil {
+				sm.logger.WithError(err).WithField("sessionID", sessionID).Warn("Failed to restore default resource quota")
+			}
+		}
+
 		err := sm.clusterPool.ReleaseCluster(sessionID)
 		if err != nil {
 			sm.logger.WithError(err).WithFields(logrus.Fields{
@@ -231,11 +576,6 @@ func (sm *SessionManager) DeleteSession(ctx context.Context, sessionID string) e
 			}).Error("Failed to release cluster")
 		}
 	}
-	// Clean up persistent terminal connections
-	if sm.terminalCleanupFunc != nil {
-		sm.terminalCleanupFunc(sessionID)
-		sm.logger.WithField("sessionID", sessionID).Info("Cleaned up persistent terminal connections for deleted session")
-	}
 	return nil
 }
 
@@ -260,6 +600,40 @@ func (sm *SessionManager) ExtendSession(sessionID string, duration time.Duration
 	return nil
 }
 
+// PatchSession updates the user-defined metadata of a session
+func (sm *SessionManager) PatchSession(sessionID string, patch models.SessionPatch) error {
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	for key, value := range patch.Tags {
+		if value == "" {
+			delete(session.Tags, key)
+			continue
+		}
+		if session.Tags == nil {
+			session.Tags = make(map[string]string)
+		}
+		session.Tags[key] = value
+	}
+
+	if patch.Notes != nil {
+		session.Notes = *patch.Notes
+	}
+
+	sm.logger.WithFields(logrus.Fields{
+		"sessionID": sessionID,
+		"tags":      len(patch.Tags),
+		"notes":     patch.Notes != nil,
+	}).Info("Session metadata updated")
+
+	return nil
+}
+
 // UpdateTaskStatus updates the status of a task in a session
 func (sm *SessionManager) UpdateTaskStatus(sessionID, taskID string, status string) error {
 	sm.lock.Lock()
@@ -274,6 +648,15 @@ func (sm *SessionManager) UpdateTaskStatus(sessionID, taskID string, status stri
 	found := false
 	for i, task := range session.Tasks {
 		if task.ID == taskID {
+			if status == "completed" && task.Status != "completed" {
+				session.Tasks[i].CompletedAt = time.Now()
+				if !task.StartedAt.IsZero() {
+					session.Tasks[i].DurationSeconds = session.Tasks[i].CompletedAt.Sub(task.StartedAt).Seconds()
+				}
+				if !task.DeadlineAt.IsZero() && session.Tasks[i].CompletedAt.After(task.DeadlineAt) {
+					session.Tasks[i].TimedOut = true
+				}
+			}
 			session.Tasks[i].Status = status
 			session.Tasks[i].ValidationTime = time.Now()
 			found = true
@@ -283,11 +666,15 @@ func (sm *SessionManager) UpdateTaskStatus(sessionID, taskID string, status stri
 
 	// Task not found, add it
 	if !found {
-		session.Tasks = append(session.Tasks, models.TaskStatus{
+		taskStatus := models.TaskStatus{
 			ID:             taskID,
 			Status:         status,
 			ValidationTime: time.Now(),
-		})
+		}
+		if status == "completed" {
+			taskStatus.CompletedAt = time.Now()
+		}
+		session.Tasks = append(session.Tasks, taskStatus)
 	}
 
 	sm.logger.WithFields(logrus.Fields{
@@ -296,11 +683,36 @@ func (sm *SessionManager) UpdateTaskStatus(sessionID, taskID string, status stri
 		"status":    status,
 	}).Info("Task status updated")
 
+	if status == "completed" {
+		sm.handleTaskCompleted(session, taskID)
+	}
+
+	if sm.taskResultObserver != nil {
+		sm.taskResultObserver(*session, taskID, status)
+	}
+
 	return nil
 }
 
-// Update ValidateTask method
+// ValidateTask validates a task of a session and records the result
 func (sm *SessionManager) ValidateTask(ctx context.Context, sessionID, taskID string) (*validation.ValidationResponse, error) {
+	ctx, span := tracing.Start(ctx, "SessionManager.ValidateTask",
+		attribute.String("session.id", sessionID),
+		attribute.String("task.id", taskID),
+	)
+	response, err := sm.validateTask(ctx, sessionID, taskID)
+	tracing.End(span, err)
+	return response, err
+}
+
+func (sm *SessionManager) validateTask(ctx context.Context, sessionID, taskID string) (*validation.ValidationResponse, error) {
+	release, err := sm.AcquireValidationSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	defer sm.TrackValidation()()
+
 	// Get session
 	session, err := sm.GetSession(sessionID)
 	if err != nil {
@@ -309,7 +721,7 @@ func (sm *SessionManager) ValidateTask(ctx context.Context, sessionID, taskID st
 
 	// Check if session has a scenario
 	if session.ScenarioID == "" {
-		return nil, fmt.Errorf("session has no associated scenario")
+		return nil, fmt.Errorf("%w: session has no associated scenario", ErrTaskNotFound)
 	}
 
 	sm.logger.WithFields(logrus.Fields{
@@ -385,7 +797,7 @@ func (sm *SessionManager) ValidateTask(ctx context.Context, sessionID, taskID st
 			}(),
 		}).Error("Task not found in scenario")
 
-		return nil, fmt.Errorf("task %s not found in scenario %s", taskID, session.ScenarioID)
+		return nil, fmt.Errorf("%w: %s in scenario %s", ErrTaskNotFound, taskID, session.ScenarioID)
 	}
 
 	sm.logger.WithFields(logrus.Fields{
@@ -411,6 +823,8 @@ func (sm *SessionManager) ValidateTask(ctx context.Context, sessionID, taskID st
 		}, nil
 	}
 
+	sm.markTaskStarted(sessionID, taskID, taskToValidate.TimeEstimateSeconds)
+
 	// Log each validation rule
 	for i, rule := range taskToValidate.Validation {
 		sm.logger.WithFields(logrus.Fields{
@@ -421,8 +835,13 @@ func (sm *SessionManager) ValidateTask(ctx context.Context, sessionID, taskID st
 		}).Debug("Validating rule")
 	}
 
-	// Validate task using the unified validator
-	result, err := sm.unifiedValidator.ValidateTask(ctx, session, taskToValidate.Validation)
+	// Validate task using the remote validation service if configured, else the unified validator
+	var result *validation.ValidationResponse
+	if sm.validationClient != nil {
+		result, err = sm.validationClient.ValidateTask(ctx, session, taskID, taskToValidate.Validation)
+	} else {
+		result, err = sm.unifiedValidator.ValidateTask(ctx, session, taskToValidate.Validation)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
@@ -455,6 +874,26 @@ func (sm *SessionManager) ValidateTask(ctx context.Context, sessionID, taskID st
 	return result, nil
 }
 
+// markTaskStarted records the first validation of a task as its start time. A task with a time
+// estimate gets a deadline that many seconds later.
+func (sm *SessionManager) markTaskStarted(sessionID, taskID string, estimateSeconds int) {
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		return
+	}
+	for i := range session.Tasks {
+		if session.Tasks[i].ID == taskID && session.Tasks[i].StartedAt.IsZero() {
+			session.Tasks[i].StartedAt = time.Now()
+			if estimateSeconds > 0 {
+				session.Tasks[i].DeadlineAt = session.Tasks[i].StartedAt.Add(time.Duration(estimateSeconds) * time.Second)
+			}
+		}
+	}
+}
+
 func (sm *SessionManager) UpdateTaskValidationResult(sessionID, taskID string, status string, validationResult *validation.ValidationResponse) error {
 	sm.lock.Lock()
 	defer sm.lock.Unlock()
@@ -468,7 +907,17 @@ func (sm *SessionManager) UpdateTaskValidationResult(sessionID, taskID string, s
 	found := false
 	for i, task := range session.Tasks {
 		if task.ID == taskID {
+			if status == "completed" && task.Status != "completed" {
+				session.Tasks[i].CompletedAt = time.Now()
+				if !task.StartedAt.IsZero() {
+					session.Tasks[i].DurationSeconds = session.Tasks[i].CompletedAt.Sub(task.StartedAt).Seconds()
+				}
+				if !task.DeadlineAt.IsZero() && session.Tasks[i].CompletedAt.After(task.DeadlineAt) {
+					session.Tasks[i].TimedOut = true
+				}
+			}
 			session.Tasks[i].Status = status
+			session.Tasks[i].AttemptCount++
 			session.Tasks[i].ValidationTime = time.Now()
 			session.Tasks[i].ValidationResult = &models.ValidationResponseRef{
 				Success:   validationResult.Success,
@@ -482,16 +931,22 @@ func (sm *SessionManager) UpdateTaskValidationResult(sessionID, taskID string, s
 
 	// Task not found, add it
 	if !found {
-		session.Tasks = append(session.Tasks, models.TaskStatus{
+		taskStatus := models.TaskStatus{
 			ID:             taskID,
 			Status:         status,
+			AttemptCount:   1,
+			StartedAt:      time.Now(),
 			ValidationTime: time.Now(),
 			ValidationResult: &models.ValidationResponseRef{
 				Success:   validationResult.Success,
 				Message:   validationResult.Message,
 				Timestamp: time.Now(),
 			},
-		})
+		}
+		if status == "completed" {
+			taskStatus.CompletedAt = time.Now()
+		}
+		session.Tasks = append(session.Tasks, taskStatus)
 	}
 
 	sm.logger.WithFields(logrus.Fields{
@@ -501,9 +956,84 @@ func (sm *SessionManager) UpdateTaskValidationResult(sessionID, taskID string, s
 		"success":   validationResult.Success,
 	}).Info("Task validation result stored in session")
 
+	if status == "completed" {
+		sm.handleTaskCompleted(session, taskID)
+	}
+
+	if sm.taskResultObserver != nil {
+		sm.taskResultObserver(*session, taskID, status)
+	}
+
 	return nil
 }
 
+// handleTaskCompleted updates the score and completion records after a task is completed.
+// Must be called with sm.lock held.
+func (sm *SessionManager) handleTaskCompleted(session *models.Session, taskID string) {
+	var scenario *models.Scenario
+	if session.ScenarioID != "" {
+		var err error
+		scenario, err = sm.loadScenario(context.Background(), session.ScenarioID)
+		if err != nil {
+			sm.logger.WithError(err).WithField("sessionID", session.ID).Warn("Failed to load scenario for scoring, using default scoring")
+		}
+	}
+	calculateScore(session, scenario)
+
+	sm.notifyWebhook(EventTaskCompleted, newSessionEventPayload(session, taskID))
+
+	sm.checkScenarioCompletion(session)
+}
+
+// checkScenarioCompletion marks the session completed once every task has been completed
+// and schedules its cleanup after a grace period. Must be called with sm.lock held.
+func (sm *SessionManager) checkScenarioCompletion(session *models.Session) bool {
+	if session.Status == models.SessionStatusCompleted || !allTasksCompleted(session.Tasks) {
+		return false
+	}
+
+	sm.setSessionStatus(session, models.SessionStatusCompleted, "All tasks completed")
+	session.CompletedAt = time.Now()
+
+	if session.UserID != "" && session.ScenarioID != "" {
+		sm.userProgress.MarkCompleted(session.UserID, session.ScenarioID)
+	}
+
+	sm.notifyWebhook(EventSessionCompleted, newSessionEventPayload(session, ""))
+
+	// Keep the session around so the user can review the results
+	sessionID := session.ID
+	time.AfterFunc(completedSessionGracePeriod, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		if err := sm.DeleteSession(ctx, sessionID); err != nil {
+			sm.logger.WithError(err).WithField("sessionID", sessionID).Debug("Completed session already cleaned up")
+		}
+	})
+
+	sm.logger.WithFields(logrus.Fields{
+		"sessionID":   sessionID,
+		"gracePeriod": completedSessionGracePeriod,
+	}).Info("All tasks completed, session scheduled for cleanup")
+
+	return true
+}
+
+// allTasksCompleted reports whether every task in the list has been completed
+func allTasksCompleted(tasks []models.TaskStatus) bool {
+	if len(tasks) == 0 {
+		return false
+	}
+
+	for _, task := range tasks {
+		if task.Status != "completed" {
+			return false
+		}
+	}
+	return true
+}
+
 // RegisterTerminalSession registers a terminal session for a VM
 func (sm *SessionManager) RegisterTerminalSession(sessionID, terminalID, target string) error {
 	sm.lock.Lock()
@@ -596,20 +1126,38 @@ func (sm *SessionManager) createNamespace(ctx context.Context, namespace string)
 	return nil
 }
 
+// setupResourceQuotas applies the configured default quota to a namespace
 func (sm *SessionManager) setupResourceQuotas(ctx context.Context, namespace string) error {
-	sm.logger.WithField("namespace", namespace).Info("Setting up resource quotas")
+	return sm.setupResourceQuotasForScenario(ctx, namespace, models.ResourceLimits{})
+}
+
+// setupResourceQuotasForScenario applies a quota using the scenario's limits, falling back
+// to the configured defaults for limits the scenario does not set
+func (sm *SessionManager) setupResourceQuotasForScenario(ctx context.Context, namespace string, limits models.ResourceLimits) error {
+	sm.logger.WithFields(logrus.Fields{
+		"namespace": namespace,
+		"limits":    limits,
+	}).Info("Setting up resource quotas")
+
+	hard := corev1.ResourceList{}
+	for name, value := range map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    firstNonEmpty(limits.CPU, sm.config.QuotaCPU),
+		corev1.ResourceMemory: firstNonEmpty(limits.Memory, sm.config.QuotaMemory),
+		corev1.ResourcePods:   firstNonEmpty(limits.Pods, sm.config.QuotaPods),
+	} {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s quota %q: %w", name, value, err)
+		}
+		hard[name] = quantity
+	}
 
-	// Create a resource quota with HIGHER limits for cluster pool
 	quota := &corev1.ResourceQuota{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "session-quota",
 		},
 		Spec: corev1.ResourceQuotaSpec{
-			Hard: corev1.ResourceList{
-				corev1.ResourceCPU:    resource.MustParse("16"),   // Increased from 4
-				corev1.ResourceMemory: resource.MustParse("16Gi"), // Increased from 8Gi
-				corev1.ResourcePods:   resource.MustParse("20"),   // Increased from 10
-			},
+			Hard: hard,
 		},
 	}
 
@@ -639,9 +1187,30 @@ func (sm *SessionManager) setupResourceQuotas(ctx context.Context, namespace str
 	return fmt.Errorf("failed to check existing quota: %w", err)
 }
 
+// firstNonEmpty returns value, or fallback when value is empty
+func firstNonEmpty(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
+}
+
 // loadScenario loads a scenario by ID
 func (sm *SessionManager) loadScenario(ctx context.Context, scenarioID string) (*models.Scenario, error) {
-	return sm.scenarioManager.GetScenario(scenarioID)
+	scenario, err := sm.scenarioManager.GetScenario(scenarioID)
+	if err != nil {
+		return nil, err
+	}
+
+	if scenario.Deprecated {
+		sm.logger.WithFields(logrus.Fields{
+			"scenarioID": scenario.ID,
+			"version":    scenario.Version,
+			"message":    scenario.DeprecationMessage,
+		}).Warn("Loaded deprecated scenario")
+	}
+
+	return scenario, nil
 }
 
 // Update initializeScenario method
@@ -671,6 +1240,13 @@ func (sm *SessionManager) initializeScenario(ctx context.Context, session *model
 
 	// Create scenario initializer
 	initializer := scenarios.NewScenarioInitializer(sm.clientset, sm.kubevirtClient, sm.logger)
+	initializer.OnProgress(func(current, total int, description string) {
+		sm.updateSetupProgress(session.ID, models.SetupProgress{
+			CurrentStep:            current,
+			TotalSteps:             total,
+			CurrentStepDescription: description,
+		})
+	})
 
 	// Run initialization with timeout
 	initCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
@@ -689,6 +1265,25 @@ func (sm *SessionManager) initializeScenario(ctx context.Context, session *model
 	return nil
 }
 
+// updateSetupProgress records the running setup step in the session status and notifies subscribers
+func (sm *SessionManager) updateSetupProgress(sessionID string, progress models.SetupProgress) {
+	sm.lock.Lock()
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		sm.lock.Unlock()
+		return
+	}
+	session.SetupProgress = &progress
+	sm.setSessionStatus(session, session.Status, fmt.Sprintf("Running setup step %d/%d: %s",
+		progress.CurrentStep, progress.TotalSteps, progress.CurrentStepDescription))
+	sm.lock.Unlock()
+
+	sm.publishEvent(sessionID, models.SessionEvent{
+		Type:          EventSetupProgress,
+		SetupProgress: &progress,
+	})
+}
+
 func (sm *SessionManager) GetSessionWithScenario(ctx context.Context, sessionID string) (*models.Session, *models.Scenario, error) {
 	session, err := sm.GetSession(sessionID)
 	if err != nil {
@@ -713,8 +1308,16 @@ func (sm *SessionManager) cleanupExpiredSessions() {
 	ticker := time.NewTicker(time.Duration(sm.config.CleanupIntervalMinutes) * time.Minute)
 	defer ticker.Stop()
 
+	// Expiry warnings need a finer resolution than the cleanup interval
+	warningTicker := time.NewTicker(expiryWarningCheckInterval)
+	defer warningTicker.Stop()
+
 	for {
 		select {
+		case <-warningTicker.C:
+			sm.sendExpiryWarnings()
+			sm.sendTaskDeadlineWarnings()
+
 		case <-ticker.C:
 			sm.logger.Debug("Running session cleanup")
 
@@ -733,7 +1336,8 @@ func (sm *SessionManager) cleanupExpiredSessions() {
 				// Find expired sessions
 				for id, session := range sm.sessions {
 					if now.After(session.ExpirationTime) &&
-						session.Status != models.SessionStatusFailed {
+						session.Status != models.SessionStatusFailed &&
+						session.Status != models.SessionStatusPaused {
 						expiredSessions = append(expiredSessions, id)
 
 						// Mark as failed to prevent race conditions
@@ -783,9 +1387,45 @@ func (sm *SessionManager) cleanupExpiredSessions() {
 // Stop stops the session manager and releases resources
 func (sm *SessionManager) Stop() {
 	close(sm.stopCh)
+
+	timeout := time.Duration(sm.config.GracefulShutdownTimeoutSeconds) * time.Second
+	sm.logger.WithFields(logrus.Fields{
+		"inFlightValidations": atomic.LoadInt64(&sm.activeValidations),
+		"timeout":             timeout,
+	}).Info("Waiting for in-flight validations")
+
+	done := make(chan struct{})
+	go func() {
+		sm.validations.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		sm.logger.WithField("inFlightValidations", atomic.LoadInt64(&sm.activeValidations)).Warn("Timed out waiting for in-flight validations")
+	}
+
+	if sm.validationClient != nil {
+		if err := sm.validationClient.Close(); err != nil {
+			sm.logger.WithError(err).Warn("Failed to close validation service connection")
+		}
+	}
+
 	sm.logger.Info("Session manager stopped")
 }
 
+// TrackValidation records a validation as in flight until the returned function is called
+func (sm *SessionManager) TrackValidation() func() {
+	sm.validations.Add(1)
+	atomic.AddInt64(&sm.activeValidations, 1)
+
+	return func() {
+		atomic.AddInt64(&sm.activeValidations, -1)
+		sm.validations.Done()
+	}
+}
+
 // CheckVMsStatus checks the status of VMs in a session including SSH readiness
 func (sm *SessionManager) CheckVMsStatus(ctx context.Context, session *models.Session) (string, error) {
 	controlPlaneStatus, err := sm.kubevirtClient.GetVMStatus(ctx, session.Namespace, session.ControlPlaneVM)
@@ -849,17 +1489,21 @@ func (sm *SessionManager) UpdateSessionStatus(sessionID string, status models.Se
 		return fmt.Errorf("session not found: %s", sessionID)
 	}
 
-	// Update status
+	sm.setSessionStatus(session, status, message)
+
+	return nil
+}
+
+// setSessionStatus updates the status of a session. Must be called with sm.lock held.
+func (sm *SessionManager) setSessionStatus(session *models.Session, status models.SessionStatus, message string) {
 	session.Status = status
 	session.StatusMessage = message
 
 	sm.logger.WithFields(logrus.Fields{
-		"sessionID": sessionID,
+		"sessionID": session.ID,
 		"status":    status,
 		"message":   message,
 	}).Info("Session status updated")
-
-	return nil
 }
 
 func (sm *SessionManager) GetOrCreateTerminalSession(sessionID, target string) (string, bool, error) {
@@ -975,28 +1619,51 @@ func (sm *SessionManager) MarkTerminalInactive(sessionID, terminalID string) err
 
 // CLUSTER POOL
 
-// BootstrapClusterPool creates 3 baseline clusters in static namespaces
+// BootstrapClusterPool creates the baseline clusters in static namespaces
 func (sm *SessionManager) BootstrapClusterPool(ctx context.Context) error {
-	clusterIDs := []string{"cluster1", "cluster2", "cluster3"}
+	clusterIDs := sm.clusterPool.ClusterIDs()
+
+	sm.logger.WithField("clusters", len(clusterIDs)).Info("Starting cluster pool bootstrap")
+	startTime := time.Now()
+
+	// Bootstrap clusters in parallel; each cluster lives in its own namespace
+	var wg sync.WaitGroup
+	errs := make([]error, len(clusterIDs))
+	for i, clusterID := range clusterIDs {
+		wg.Add(1)
+		go func(i int, clusterID string) {
+			defer wg.Done()
 
-	sm.logger.Info("Starting cluster pool bootstrap")
+			sm.logger.WithField("clusterID", clusterID).Info("Starting bootstrap for cluster")
 
-	// Bootstrap clusters SEQUENTIALLY to avoid resource conflicts
-	for _, clusterID := range clusterIDs {
-		sm.logger.WithField("clusterID", clusterID).Info("Starting bootstrap for cluster")
+			if err := sm.bootstrapClusterInNamespace(ctx, clusterID); err != nil {
+				sm.logger.WithError(err).WithField("clusterID", clusterID).Error("Cluster bootstrap failed")
+				errs[i] = err
+				return
+			}
+
+			sm.logger.WithField("clusterID", clusterID).Info("Cluster bootstrap completed")
+		}(i, clusterID)
+	}
+	wg.Wait()
 
-		err := sm.bootstrapClusterInNamespace(ctx, clusterID)
+	var failed []string
+	for i, err := range errs {
 		if err != nil {
-			return fmt.Errorf("failed to bootstrap cluster %s: %w", clusterID, err)
+			failed = append(failed, fmt.Sprintf("%s: %v", clusterIDs[i], err))
 		}
+	}
 
-		sm.logger.WithField("clusterID", clusterID).Info("Cluster bootstrap completed")
+	sm.logger.WithFields(logrus.Fields{
+		"clusters": len(clusterIDs),
+		"failed":   len(failed),
+		"duration": time.Since(startTime),
+	}).Info("Cluster pool bootstrap finished")
 
-		// Add delay between cluster bootstraps to avoid resource conflicts
-		time.Sleep(30 * time.Second)
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to bootstrap %d of %d clusters: %s", len(failed), len(clusterIDs), strings.Join(failed, "; "))
 	}
 
-	sm.logger.Info("All clusters bootstrapped successfully")
 	return nil
 }
 
@@ -1035,7 +1702,7 @@ func (sm *SessionManager) bootstrapClusterInNamespace(ctx context.Context, clust
 
 	sm.logger.WithField("clusterID", clusterID).Info("Cluster bootstrap completed")
 
-	// ADD THIS LINE - Mark cluster as available in the pool
+	// Mark cluster as available in the pool
 	err = sm.clusterPool.MarkClusterAvailable(clusterID)
 	if err != nil {
 		sm.logger.WithError(err).WithField("clusterID", clusterID).Error("Failed to mark cluster as available")
@@ -1063,6 +1730,16 @@ func (sm *SessionManager) cleanupExistingCluster(ctx context.Context, session *m
 
 // provisionFromBootstrapForClusterPool provisions a cluster for the pool (no session status updates)
 func (sm *SessionManager) provisionFromBootstrapForClusterPool(ctx context.Context, session *models.Session) error {
+	ctx, span := tracing.Start(ctx, "SessionManager.provisionFromBootstrap",
+		attribute.String("cluster.id", session.ID),
+		attribute.String("namespace", session.Namespace),
+	)
+	err := sm.provisionFromBootstrap(ctx, session)
+	tracing.End(span, err)
+	return err
+}
+
+func (sm *SessionManager) provisionFromBootstrap(ctx context.Context, session *models.Session) error {
 	sm.logger.WithField("clusterID", session.ID).Info("Provisioning cluster for pool using bootstrap method")
 
 	// Verify KubeVirt is available
@@ -1092,14 +1769,32 @@ func (sm *SessionManager) provisionFromBootstrapForClusterPool(ctx context.Conte
 		return fmt.Errorf("failed to set up resource quotas: %w", err)
 	}
 
+	if sm.config.NetworkIsolationEnabled {
+		policyCtx, cancelPolicy := context.WithTimeout(ctx, 2*time.Minute)
+		defer cancelPolicy()
+		if err := sm.createSessionNetworkPolicy(policyCtx, session.Namespace); err != nil {
+			return fmt.Errorf("failed to set up network isolation: %w", err)
+		}
+	}
+
 	// Add a short delay to ensure resource quotas are applied
 	time.Sleep(2 * time.Second)
 
+	// Pool clusters are bootstrapped before any scenario is known and use the default version
+	var cloudInitOpts kubevirt.CloudInitOptions
+	if session.ScenarioID != "" {
+		scenario, err := sm.loadScenario(ctx, session.ScenarioID)
+		if err != nil {
+			return fmt.Errorf("failed to load scenario: %w", err)
+		}
+		cloudInitOpts.K8sVersion = scenario.Requirements.K8sVersion
+	}
+
 	// Create KubeVirt VMs
 	vmCtx, cancelVM := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancelVM()
 	sm.logger.WithField("clusterID", session.ID).Info("Creating KubeVirt VMs")
-	err = sm.kubevirtClient.CreateCluster(vmCtx, session.Namespace, session.ControlPlaneVM, session.WorkerNodeVM)
+	err = sm.kubevirtClient.CreateCluster(vmCtx, session.Namespace, session.ControlPlaneVM, session.WorkerNodeVM, cloudInitOpts)
 	if err != nil {
 		return fmt.Errorf("failed to create VMs: %w", err)
 	}
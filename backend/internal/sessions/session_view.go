@@ -0,0 +1,67 @@
+// backend/internal/sessions/session_view.go - A session together with its scenario and tasks
+
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// GetSessionView returns a session with its scenario and, for every task status, the task's
+// title, description and the hints unlocked by its attempts, so clients need a single request.
+// The scenario and task details are left empty when the scenario can no longer be loaded.
+func (sm *SessionManager) GetSessionView(ctx context.Context, sessionID string) (*models.SessionView, error) {
+	sm.lock.RLock()
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		sm.lock.RUnlock()
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+	// Copy the session, since it is encoded after the lock is released
+	sessionCopy := *session
+	sessionCopy.Tasks = append([]models.TaskStatus(nil), session.Tasks...)
+	sessionCopy.TerminalSessions = maps.Clone(session.TerminalSessions)
+	sessionCopy.ActiveTerminals = maps.Clone(session.ActiveTerminals)
+	sessionCopy.Tags = maps.Clone(session.Tags)
+	sm.lock.RUnlock()
+
+	view := &models.SessionView{
+		Session: &sessionCopy,
+		Tasks:   make([]models.SessionTaskView, 0, len(sessionCopy.Tasks)),
+	}
+
+	tasks := make(map[string]models.Task)
+	if sessionCopy.ScenarioID != "" {
+		scenario, err := sm.scenarioManager.GetScenario(sessionCopy.ScenarioID)
+		if err != nil {
+			sm.logger.WithError(err).WithField("scenarioID", sessionCopy.ScenarioID).Warn("Failed to load scenario for session view")
+		} else {
+			view.Scenario = &models.SessionViewScenario{
+				ID:          scenario.ID,
+				Title:       scenario.Title,
+				Description: scenario.Description,
+				Difficulty:  scenario.Difficulty,
+			}
+			for _, task := range scenario.Tasks {
+				tasks[task.ID] = task
+			}
+		}
+	}
+
+	for _, status := range sessionCopy.Tasks {
+		taskView := models.SessionTaskView{TaskStatus: status}
+		if task, ok := tasks[status.ID]; ok {
+			hints := unlockedHints(task, status.AttemptCount)
+			taskView.Title = task.Title
+			taskView.Description = task.Description
+			taskView.Hints = hints.Hints
+			taskView.NextHintUnlocksAfterAttempts = hints.NextHintUnlocksAfterAttempts
+		}
+		view.Tasks = append(view.Tasks, taskView)
+	}
+
+	return view, nil
+}
@@ -0,0 +1,106 @@
+// backend/internal/sessions/solution.go - Revealing task solutions after repeated failed attempts
+
+package sessions
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// defaultSolutionUnlockAfterAttempts is used for tasks that do not set SolutionUnlockAfterAttempts
+const defaultSolutionUnlockAfterAttempts = 5
+
+// ErrSolutionNotFound is returned when a task has no solution
+var ErrSolutionNotFound = errors.New("solution not found")
+
+// GetSolution reveals the solution of a task once the task has had enough validation attempts.
+// The first reveal is recorded in the task's SolutionRevealed and SolutionRevealedAt, which
+// survive task resets, and a task completed afterwards earns fewer points. A locked solution
+// is reported without its text.
+func (sm *SessionManager) GetSolution(sessionID, taskID string) (*models.SolutionResponse, error) {
+	sm.lock.RLock()
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		sm.lock.RUnlock()
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+	scenarioID := session.ScenarioID
+	attempts := 0
+	revealed := false
+	for _, task := range session.Tasks {
+		if task.ID == taskID {
+			attempts = task.AttemptCount
+			revealed = task.SolutionRevealed
+			break
+		}
+	}
+	sm.lock.RUnlock()
+
+	scenario, err := sm.scenarioManager.GetScenario(scenarioID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scenario: %w", err)
+	}
+
+	var task *models.Task
+	for i := range scenario.Tasks {
+		if scenario.Tasks[i].ID == taskID {
+			task = &scenario.Tasks[i]
+			break
+		}
+	}
+	if task == nil {
+		return nil, fmt.Errorf("task not found: %s", taskID)
+	}
+	if task.Solution == "" {
+		return nil, fmt.Errorf("%w: task %s", ErrSolutionNotFound, taskID)
+	}
+
+	threshold := task.SolutionUnlockAfterAttempts
+	if threshold <= 0 {
+		threshold = defaultSolutionUnlockAfterAttempts
+	}
+	response := &models.SolutionResponse{
+		Unlocked:             revealed || attempts >= threshold,
+		AttemptCount:         attempts,
+		UnlocksAfterAttempts: threshold,
+		PenaltyPercent:       solutionPenaltyPercent(scenario.ScoreConfig),
+	}
+	if !response.Unlocked {
+		return response, nil
+	}
+	response.Solution = task.Solution
+
+	firstReveal := false
+	sm.lock.Lock()
+	if session, ok := sm.sessions[sessionID]; ok {
+		for i := range session.Tasks {
+			if session.Tasks[i].ID != taskID {
+				continue
+			}
+			status := &session.Tasks[i]
+			if !status.SolutionRevealed {
+				status.SolutionRevealed = true
+				status.SolutionRevealedAt = time.Now()
+				firstReveal = true
+			}
+			response.RevealedAt = status.SolutionRevealedAt
+			break
+		}
+	}
+	sm.lock.Unlock()
+
+	if firstReveal {
+		sm.logger.WithFields(logrus.Fields{
+			"sessionID": sessionID,
+			"taskID":    taskID,
+			"attempts":  attempts,
+		}).Info("Solution revealed")
+	}
+
+	return response, nil
+}
@@ -0,0 +1,74 @@
+// backend/internal/sessions/task_reset.go - Resetting tasks so they can be attempted again
+
+package sessions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// maxTaskResets is how many times a task may be reset in a session
+const maxTaskResets = 3
+
+// ErrTaskNotFound is returned when a session's scenario has no task with the given ID
+var ErrTaskNotFound = errors.New("task not found")
+
+// ErrTaskResetLimit is returned by ResetTask once a task has been reset maxTaskResets times
+var ErrTaskResetLimit = errors.New("task reset limit reached")
+
+// ResetTask sets a task back to pending, clearing its validation result, attempts and points
+func (sm *SessionManager) ResetTask(sessionID, taskID string) error {
+	sm.lock.Lock()
+
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		sm.lock.Unlock()
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+	if session.Status == models.SessionStatusCompleted {
+		sm.lock.Unlock()
+		return fmt.Errorf("%w: cannot reset a task of a completed session", ErrInvalidSessionState)
+	}
+
+	var task *models.TaskStatus
+	for i := range session.Tasks {
+		if session.Tasks[i].ID == taskID {
+			task = &session.Tasks[i]
+			break
+		}
+	}
+	if task == nil {
+		sm.lock.Unlock()
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	if task.ResetCount >= maxTaskResets {
+		sm.lock.Unlock()
+		return fmt.Errorf("%w: task %s was already reset %d times", ErrTaskResetLimit, taskID, task.ResetCount)
+	}
+
+	session.Score -= task.Points + task.TimeBonus
+	*task = models.TaskStatus{
+		ID:         task.ID,
+		Status:     "pending",
+		ResetCount: task.ResetCount + 1,
+		// A revealed solution still costs points after the reset
+		SolutionRevealed:   task.SolutionRevealed,
+		SolutionRevealedAt: task.SolutionRevealedAt,
+	}
+	resetCount := task.ResetCount
+	sm.lock.Unlock()
+
+	sm.logger.WithFields(logrus.Fields{
+		"sessionID":  sessionID,
+		"taskID":     taskID,
+		"resetCount": resetCount,
+	}).Info("Task reset to pending")
+
+	sm.publishEvent(sessionID, models.SessionEvent{Type: EventTaskReset, TaskID: taskID})
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// backend/internal/sessions/user_progress.go - Tracking of scenarios completed by users
+
+package sessions
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// UserProgressStore records which scenarios each user has completed
+type UserProgressStore interface {
+	HasCompleted(userID, scenarioID string) bool
+	MarkCompleted(userID, scenarioID string)
+	Completed(userID string) []string
+}
+
+// InMemoryUserProgressStore is a UserProgressStore kept in process memory
+type InMemoryUserProgressStore struct {
+	completed map[string]map[string]bool
+	order     map[string][]string // Completed scenario IDs, most recent last
+	lock      sync.RWMutex
+}
+
+// NewInMemoryUserProgressStore creates an empty in-memory progress store
+func NewInMemoryUserProgressStore() *InMemoryUserProgressStore {
+	return &InMemoryUserProgressStore{
+		completed: make(map[string]map[string]bool),
+		order:     make(map[string][]string),
+	}
+}
+
+// HasCompleted reports whether the user has completed the scenario
+func (s *InMemoryUserProgressStore) HasCompleted(userID, scenarioID string) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.completed[userID][scenarioID]
+}
+
+// MarkCompleted records the scenario as completed by the user
+func (s *InMemoryUserProgressStore) MarkCompleted(userID, scenarioID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.completed[userID] == nil {
+		s.completed[userID] = make(map[string]bool)
+	}
+
+	// A repeated completion moves the scenario to the end
+	if s.completed[userID][scenarioID] {
+		order := s.order[userID]
+		for i, id := range order {
+			if id == scenarioID {
+				s.order[userID] = append(order[:i:i], order[i+1:]...)
+				break
+			}
+		}
+	}
+	s.completed[userID][scenarioID] = true
+	s.order[userID] = append(s.order[userID], scenarioID)
+}
+
+// Completed returns the scenarios completed by the user, most recently completed last
+func (s *InMemoryUserProgressStore) Completed(userID string) []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return append([]string(nil), s.order[userID]...)
+}
+
+// PrerequisitesNotMetError is returned when a user has not completed the scenarios a scenario depends on
+type PrerequisitesNotMetError struct {
+	ScenarioID string
+	Unmet      []string
+}
+
+func (e *PrerequisitesNotMetError) Error() string {
+	return fmt.Sprintf("scenario %s has unmet prerequisites: %s", e.ScenarioID, strings.Join(e.Unmet, ", "))
+}
@@ -0,0 +1,197 @@
+// backend/internal/sessions/user_snapshot.go - Snapshots of a session's cluster taken by the user
+
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// States of a session's user snapshot
+const (
+	UserSnapshotCreating  = "creating"
+	UserSnapshotReady     = "ready"
+	UserSnapshotRestoring = "restoring"
+	UserSnapshotFailed    = "failed"
+)
+
+// userSnapshotTimeout bounds creating or restoring a user snapshot, including VM restarts
+const userSnapshotTimeout = 20 * time.Minute
+
+var (
+	// ErrUserSnapshotExists is returned when a session already has a user snapshot
+	ErrUserSnapshotExists = errors.New("session already has a snapshot")
+	// ErrNoUserSnapshot is returned when restoring a session that has no ready user snapshot
+	ErrNoUserSnapshot = errors.New("session has no snapshot")
+)
+
+// userSnapshotNames returns the snapshot names of the control plane and worker VMs for a snapshot prefix
+func userSnapshotNames(prefix string) (string, string) {
+	return prefix + "-cp-snap", prefix + "-wk-snap"
+}
+
+// CreateSessionSnapshot starts snapshotting both VMs of a running session so the user can
+// return to this point later. A session has at most one user snapshot. The VMs are stopped
+// while the snapshot is taken and started again afterwards; the work continues in the
+// background and its progress is reported in the session's UserSnapshotStatus.
+func (sm *SessionManager) CreateSessionSnapshot(ctx context.Context, sessionID string) error {
+	sm.lock.Lock()
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		sm.lock.Unlock()
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+	if session.Status != models.SessionStatusRunning {
+		sm.lock.Unlock()
+		return fmt.Errorf("%w: cannot snapshot session in status %s", ErrInvalidSessionState, session.Status)
+	}
+	if session.UserSnapshotName != "" {
+		sm.lock.Unlock()
+		return ErrUserSnapshotExists
+	}
+
+	prefix := "user-" + sessionID
+	session.UserSnapshotName = prefix
+	session.UserSnapshotStatus = UserSnapshotCreating
+	namespace := session.Namespace
+	controlPlaneVM, workerNodeVM := session.ControlPlaneVM, session.WorkerNodeVM
+	sm.lock.Unlock()
+
+	go func() {
+		snapshotCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), userSnapshotTimeout)
+		defer cancel()
+
+		logger := sm.logger.WithFields(logrus.Fields{
+			"sessionID": sessionID,
+			"namespace": namespace,
+		})
+		logger.Info("Creating user snapshot")
+
+		cpSnapshot, wkSnapshot := userSnapshotNames(prefix)
+		err := sm.kubevirtClient.StopVMs(snapshotCtx, namespace, controlPlaneVM, workerNodeVM)
+		if err == nil {
+			err = sm.snapshotStoppedVMs(snapshotCtx, namespace, map[string]string{
+				controlPlaneVM: cpSnapshot,
+				workerNodeVM:   wkSnapshot,
+			})
+		}
+
+		// Restart the VMs whatever the outcome, so the session stays usable
+		for _, vmName := range []string{controlPlaneVM, workerNodeVM} {
+			if startErr := sm.kubevirtClient.StartVM(snapshotCtx, namespace, vmName); startErr != nil {
+				logger.WithError(startErr).WithField("vmName", vmName).Error("Failed to restart VM after user snapshot")
+			}
+		}
+
+		status := UserSnapshotReady
+		if err != nil {
+			logger.WithError(err).Error("Failed to create user snapshot")
+			sm.deleteUserSnapshots(snapshotCtx, namespace, prefix)
+			status = UserSnapshotFailed
+		} else {
+			logger.Info("User snapshot created")
+		}
+
+		sm.lock.Lock()
+		defer sm.lock.Unlock()
+		if session, ok := sm.sessions[sessionID]; ok {
+			session.UserSnapshotStatus = status
+			if err != nil {
+				session.UserSnapshotName = ""
+			}
+		}
+	}()
+
+	return nil
+}
+
+// RestoreSessionSnapshot starts restoring both VMs of a session from its user snapshot. Terminal
+// connections are closed once the VMs are back, since their SSH connections do not survive the
+// restore. The restore continues in the background.
+func (sm *SessionManager) RestoreSessionSnapshot(ctx context.Context, sessionID string) error {
+	sm.lock.Lock()
+	session, ok := sm.sessions[sessionID]
+	if !ok {
+		sm.lock.Unlock()
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+	if session.UserSnapshotName == "" {
+		sm.lock.Unlock()
+		return ErrNoUserSnapshot
+	}
+	if session.UserSnapshotStatus != UserSnapshotReady {
+		sm.lock.Unlock()
+		return fmt.Errorf("%w: snapshot is %s", ErrInvalidSessionState, session.UserSnapshotStatus)
+	}
+	if session.Status != models.SessionStatusRunning {
+		sm.lock.Unlock()
+		return fmt.Errorf("%w: cannot restore session in status %s", ErrInvalidSessionState, session.Status)
+	}
+
+	session.UserSnapshotStatus = UserSnapshotRestoring
+	prefix := session.UserSnapshotName
+	namespace := session.Namespace
+	controlPlaneVM, workerNodeVM := session.ControlPlaneVM, session.WorkerNodeVM
+	sm.lock.Unlock()
+
+	go func() {
+		restoreCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), userSnapshotTimeout)
+		defer cancel()
+
+		logger := sm.logger.WithFields(logrus.Fields{
+			"sessionID": sessionID,
+			"namespace": namespace,
+		})
+		logger.Info("Restoring user snapshot")
+
+		cpSnapshot, wkSnapshot := userSnapshotNames(prefix)
+		errs := make(chan error, 2)
+		go func() {
+			errs <- sm.kubevirtClient.RestoreVMFromSnapshot(restoreCtx, namespace, controlPlaneVM, cpSnapshot)
+		}()
+		go func() {
+			errs <- sm.kubevirtClient.RestoreVMFromSnapshot(restoreCtx, namespace, workerNodeVM, wkSnapshot)
+		}()
+		err := errors.Join(<-errs, <-errs)
+
+		if sm.terminalCleanupFunc != nil {
+			sm.terminalCleanupFunc(sessionID)
+		}
+
+		sm.lock.Lock()
+		defer sm.lock.Unlock()
+		session, ok := sm.sessions[sessionID]
+		if !ok {
+			return
+		}
+		// The snapshot itself is untouched, so it can be restored again after a failed restore
+		session.UserSnapshotStatus = UserSnapshotReady
+		if err != nil {
+			logger.WithError(err).Error("Failed to restore user snapshot")
+			session.StatusMessage = fmt.Sprintf("Snapshot restore failed: %v", err)
+			return
+		}
+		logger.Info("User snapshot restored")
+	}()
+
+	return nil
+}
+
+// deleteUserSnapshots deletes the user snapshots of a session, logging failures
+func (sm *SessionManager) deleteUserSnapshots(ctx context.Context, namespace, prefix string) {
+	cpSnapshot, wkSnapshot := userSnapshotNames(prefix)
+	for _, snapshotName := range []string{cpSnapshot, wkSnapshot} {
+		if err := sm.kubevirtClient.DeleteVMSnapshot(ctx, namespace, snapshotName); err != nil {
+			sm.logger.WithError(err).WithFields(logrus.Fields{
+				"namespace":    namespace,
+				"snapshotName": snapshotName,
+			}).Warn("Failed to delete user snapshot")
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// backend/internal/sessions/validation_queue.go - Limit on validations running at once
+
+package sessions
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// validationQueueTimeout is how long a validation waits for a free slot
+const validationQueueTimeout = 10 * time.Second
+
+// ErrValidationQueueFull is returned when no validation slot frees up in time
+var ErrValidationQueueFull = errors.New("validation queue full, try again shortly")
+
+var validationQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "cks_validation_queue_depth",
+	Help: "Number of validations currently holding a validation slot",
+})
+
+// AcquireValidationSlot waits for one of the MaxConcurrentValidations slots. Every validation
+// runs commands in the session VMs, so running too many at once makes the VMs unresponsive.
+// The returned function releases the slot.
+func (sm *SessionManager) AcquireValidationSlot(ctx context.Context) (func(), error) {
+	timer := time.NewTimer(validationQueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case sm.validationSlots <- struct{}{}:
+	case <-timer.C:
+		return nil, ErrValidationQueueFull
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	validationQueueDepth.Inc()
+
+	return func() {
+		<-sm.validationSlots
+		validationQueueDepth.Dec()
+	}, nil
+}
@@ -0,0 +1,232 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/config"
+	"github.com/fullstack-pw/cks/backend/internal/models"
+	"github.com/fullstack-pw/cks/backend/internal/scenarios"
+	"github.com/fullstack-pw/cks/backend/internal/validation"
+)
+
+// fakeExecutor answers every VM command with the same output and error
+type fakeExecutor struct {
+	output string
+	err    error
+}
+
+func (f *fakeExecutor) ExecuteCommandInVM(ctx context.Context, namespace, vmName, command string, retry ...bool) (string, error) {
+	return f.output, f.err
+}
+
+// newTestSessionManager creates a session manager for a single session of scenario, whose
+// validation commands are answered by executor
+func newTestSessionManager(t *testing.T, scenario *models.Scenario, executor *fakeExecutor) *SessionManager {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	scenarioManager, err := scenarios.NewScenarioManager(t.TempDir(), logger)
+	if err != nil {
+		t.Fatalf("NewScenarioManager: %v", err)
+	}
+	if err := scenarioManager.CreateScenario(scenario); err != nil {
+		t.Fatalf("CreateScenario: %v", err)
+	}
+
+	session := &models.Session{
+		ID:             "test-session",
+		Namespace:      "cluster1",
+		ScenarioID:     scenario.ID,
+		Status:         models.SessionStatusRunning,
+		ControlPlaneVM: "cp-cluster1",
+		WorkerNodeVM:   "wk-cluster1",
+	}
+	for _, task := range scenario.Tasks {
+		session.Tasks = append(session.Tasks, models.TaskStatus{ID: task.ID, Status: "pending"})
+	}
+
+	return &SessionManager{
+		sessions:                 map[string]*models.Session{session.ID: session},
+		config:                   &config.Config{MaxConcurrentValidations: 1},
+		unifiedValidator:         validation.NewUnifiedValidator(executor, logger),
+		logger:                   logger,
+		stopCh:                   make(chan struct{}),
+		scenarioManager:          scenarioManager,
+		validationSlots:          make(chan struct{}, 1),
+		clusterWaits:             make(map[string]context.CancelFunc),
+		subscribers:              make(map[string]map[chan models.SessionEvent]struct{}),
+		expiryWarningsSent:       make(map[string]map[int]bool),
+		taskDeadlineWarningsSent: make(map[string]map[string]taskDeadlineWarning),
+		provisioningLogs:         make(map[string]*provisioningLog),
+	}
+}
+
+func testScenario() *models.Scenario {
+	rule := models.ValidationRule{
+		ID:        "check",
+		Type:      "command",
+		Command:   &models.CommandTarget{Command: "kubectl get pod web", Target: "control-plane"},
+		Condition: "success",
+	}
+	return &models.Scenario{
+		ID:          "test-scenario",
+		Title:       "Test scenario",
+		Description: "Scenario used by the session tests",
+		Difficulty:  "beginner",
+		Tasks: []models.Task{
+			{
+				ID:                      "01",
+				Title:                   "First task",
+				Description:             "Create the web pod",
+				Validation:              []models.ValidationRule{rule},
+				Hints:                   []string{"first hint", "second hint", "third hint"},
+				HintUnlockAfterAttempts: []int{0, 1, 2},
+			},
+			{
+				ID:          "02",
+				Title:       "Second task",
+				Description: "Keeps the scenario from completing",
+				Validation:  []models.ValidationRule{rule},
+			},
+		},
+	}
+}
+
+func TestValidateTaskCountsAttemptsAndUnlocksHints(t *testing.T) {
+	executor := &fakeExecutor{err: errors.New("pod not found")}
+	sm := newTestSessionManager(t, testScenario(), executor)
+	ctx := context.Background()
+
+	hints, err := sm.GetTaskHints("test-session", "01")
+	if err != nil {
+		t.Fatalf("GetTaskHints: %v", err)
+	}
+	if len(hints.Hints) != 1 || hints.AttemptCount != 0 {
+		t.Fatalf("before validating: got %d hints after %d attempts, want 1 after 0", len(hints.Hints), hints.AttemptCount)
+	}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		response, err := sm.ValidateTask(ctx, "test-session", "01")
+		if err != nil {
+			t.Fatalf("ValidateTask attempt %d: %v", attempt, err)
+		}
+		if response.Success {
+			t.Fatalf("ValidateTask attempt %d succeeded, want failure", attempt)
+		}
+
+		hints, err := sm.GetTaskHints("test-session", "01")
+		if err != nil {
+			t.Fatalf("GetTaskHints: %v", err)
+		}
+		if hints.AttemptCount != attempt {
+			t.Errorf("after attempt %d: AttemptCount = %d", attempt, hints.AttemptCount)
+		}
+		if want := attempt + 1; len(hints.Hints) != want {
+			t.Errorf("after attempt %d: got %d hints, want %d", attempt, len(hints.Hints), want)
+		}
+	}
+
+	session, err := sm.GetSession("test-session")
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if session.Tasks[0].Status != "failed" || session.Tasks[0].StartedAt.IsZero() {
+		t.Errorf("task status = %q, startedAt = %v; want failed with a start time", session.Tasks[0].Status, session.Tasks[0].StartedAt)
+	}
+}
+
+func TestValidateTaskCompletesTask(t *testing.T) {
+	executor := &fakeExecutor{}
+	sm := newTestSessionManager(t, testScenario(), executor)
+
+	response, err := sm.ValidateTask(context.Background(), "test-session", "01")
+	if err != nil {
+		t.Fatalf("ValidateTask: %v", err)
+	}
+	if !response.Success {
+		t.Fatalf("ValidateTask failed: %s", response.Message)
+	}
+
+	session, err := sm.GetSession("test-session")
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	task := session.Tasks[0]
+	if task.Status != "completed" || task.AttemptCount != 1 || task.Points == 0 {
+		t.Errorf("task = %+v; want completed after 1 attempt with points", task)
+	}
+}
+
+func TestValidateTaskUnknownTask(t *testing.T) {
+	sm := newTestSessionManager(t, testScenario(), &fakeExecutor{})
+
+	if _, err := sm.ValidateTask(context.Background(), "test-session", "99"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("ValidateTask of unknown task: err = %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestValidateTaskRecordsDuration(t *testing.T) {
+	executor := &fakeExecutor{err: errors.New("pod not found")}
+	sm := newTestSessionManager(t, testScenario(), executor)
+	sm.SetTaskResultObserver(sm.scenarioManager.RecordTaskResult)
+	ctx := context.Background()
+
+	if _, err := sm.ValidateTask(ctx, "test-session", "01"); err != nil {
+		t.Fatalf("ValidateTask: %v", err)
+	}
+
+	// Move the start of the task, set by the first validation, a minute back
+	sm.lock.Lock()
+	task := &sm.sessions["test-session"].Tasks[0]
+	if task.StartedAt.IsZero() {
+		sm.lock.Unlock()
+		t.Fatal("StartedAt not set by the first validation")
+	}
+	task.StartedAt = task.StartedAt.Add(-time.Minute)
+	sm.lock.Unlock()
+
+	executor.err = nil
+	if _, err := sm.ValidateTask(ctx, "test-session", "01"); err != nil {
+		t.Fatalf("ValidateTask: %v", err)
+	}
+
+	session, err := sm.GetSession("test-session")
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if duration := session.Tasks[0].DurationSeconds; duration < 60 || duration > 70 {
+		t.Errorf("DurationSeconds = %v, want about 60", duration)
+	}
+
+	stats := sm.scenarioManager.GetStats()["test-scenario"]
+	if average := stats.AverageTaskSeconds["01"]; average < 60 || average > 70 {
+		t.Errorf("AverageTaskSeconds[01] = %v, want about 60", average)
+	}
+}
+
+func TestValidateTaskStartsCountdown(t *testing.T) {
+	scenario := testScenario()
+	scenario.Tasks[0].TimeEstimateSeconds = 600
+	sm := newTestSessionManager(t, scenario, &fakeExecutor{err: errors.New("pod not found")})
+
+	if _, err := sm.ValidateTask(context.Background(), "test-session", "01"); err != nil {
+		t.Fatalf("ValidateTask: %v", err)
+	}
+
+	session, err := sm.GetSession("test-session")
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	task := session.Tasks[0]
+	if got := task.DeadlineAt.Sub(task.StartedAt); got != 10*time.Minute {
+		t.Errorf("DeadlineAt - StartedAt = %v, want 10m", got)
+	}
+}
@@ -0,0 +1,138 @@
+// backend/internal/sessions/webhook.go - Outgoing webhook notifications for session events
+
+package sessions
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// Webhook event types
+const (
+	EventSessionCreated   = "session.created"
+	EventSessionDeleted   = "session.deleted"
+	EventSessionCompleted = "session.completed"
+	EventTaskCompleted    = "task.completed"
+)
+
+// webhookTimeout bounds a single webhook delivery
+const webhookTimeout = 5 * time.Second
+
+// webhookSignatureHeader carries the hex encoded HMAC-SHA256 of the request body
+const webhookSignatureHeader = "X-CKS-Signature"
+
+// webhookEnvelope is the JSON body posted to the webhook URL
+type webhookEnvelope struct {
+	Event     string      `json:"event"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// sessionEventPayload is the data sent with session and task events
+type sessionEventPayload struct {
+	SessionID  string               `json:"sessionId"`
+	ScenarioID string               `json:"scenarioId,omitempty"`
+	UserID     string               `json:"userId,omitempty"`
+	Status     models.SessionStatus `json:"status"`
+	Score      int                  `json:"score"`
+	TaskID     string               `json:"taskId,omitempty"`
+}
+
+// newSessionEventPayload copies the fields sent with an event so the payload can be
+// serialised after the session lock is released
+func newSessionEventPayload(session *models.Session, taskID string) sessionEventPayload {
+	return sessionEventPayload{
+		SessionID:  session.ID,
+		ScenarioID: session.ScenarioID,
+		UserID:     session.UserID,
+		Status:     session.Status,
+		Score:      session.Score,
+		TaskID:     taskID,
+	}
+}
+
+// webhookEnabled reports whether the event type should be delivered
+func (sm *SessionManager) webhookEnabled(eventType string) bool {
+	if sm.config.Webhook.URL == "" {
+		return false
+	}
+	return containsEvent(sm.config.Webhook.Events, eventType)
+}
+
+// containsEvent reports whether events contains eventType
+func containsEvent(events []string, eventType string) bool {
+	for _, event := range events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyWebhook posts the event to the configured webhook in the background.
+// Delivery failures are logged and never reported to the caller.
+func (sm *SessionManager) notifyWebhook(eventType string, payload interface{}) {
+	if !sm.webhookEnabled(eventType) {
+		return
+	}
+
+	body, err := json.Marshal(webhookEnvelope{
+		Event:     eventType,
+		Timestamp: time.Now(),
+		Data:      payload,
+	})
+	if err != nil {
+		sm.logger.WithError(err).WithField("event", eventType).Warn("Failed to serialise webhook payload")
+		return
+	}
+
+	go func() {
+		if err := sm.deliverWebhook(body); err != nil {
+			sm.logger.WithError(err).WithFields(logrus.Fields{
+				"event": eventType,
+				"url":   sm.config.Webhook.URL,
+			}).Warn("Webhook delivery failed")
+		}
+	}()
+}
+
+// deliverWebhook signs and posts a serialised event
+func (sm *SessionManager) deliverWebhook(body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sm.config.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if sm.config.Webhook.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(sm.config.Webhook.Secret))
+		mac.Write(body)
+		req.Header.Set(webhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+// backend/internal/terminal/broadcast.go - Fan-out of SSH terminal output to attached WebSockets
+
+package terminal
+
+import (
+	"io"
+
+	"github.com/sirupsen/logrus"
+)
+
+// outputBufferSize is the number of output chunks queued per WebSocket; when a slow
+// WebSocket falls behind, its oldest chunks are dropped
+const outputBufferSize = 64
+
+// TerminalModeObserve attaches in the "mode" query parameter as a read-only observer,
+// whose input and resize messages are ignored
+const TerminalModeObserve = "observe"
+
+// subscribeOutput registers a channel that receives the output of the connection's pty and
+// starts the broadcaster on first use. The channel is closed when the pty can no longer be read.
+func (tm *Manager) subscribeOutput(sshConn *PersistentSSHConnection) chan []byte {
+	ch := make(chan []byte, outputBufferSize)
+
+	sshConn.Mutex.Lock()
+	if sshConn.outputClosed {
+		close(ch)
+	} else {
+		sshConn.subscribers = append(sshConn.subscribers, ch)
+	}
+	sshConn.Mutex.Unlock()
+
+	sshConn.broadcastOnce.Do(func() {
+		go tm.broadcastOutput(sshConn)
+	})
+
+	return ch
+}
+
+// unsubscribeOutput removes a channel registered with subscribeOutput
+func (tm *Manager) unsubscribeOutput(sshConn *PersistentSSHConnection, ch chan []byte) {
+	sshConn.Mutex.Lock()
+	defer sshConn.Mutex.Unlock()
+
+	for i, subscriber := range sshConn.subscribers {
+		if subscriber == ch {
+			sshConn.subscribers = append(sshConn.subscribers[:i], sshConn.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// broadcastOutput reads the connection's pty until it fails and sends every chunk to all subscribers
+func (tm *Manager) broadcastOutput(sshConn *PersistentSSHConnection) {
+	defer func() {
+		sshConn.Mutex.Lock()
+		for _, ch := range sshConn.subscribers {
+			close(ch)
+		}
+		sshConn.subscribers = nil
+		sshConn.outputClosed = true
+		sshConn.Mutex.Unlock()
+	}()
+
+	buffer := make([]byte, 4096)
+	for {
+		n, err := sshConn.PTY.Read(buffer)
+		if n > 0 {
+			tm.bytesRead.Add(uint64(n))
+
+			// Subscribers share the chunk, so it must not alias the read buffer
+			data := append([]byte(nil), buffer[:n]...)
+
+			sshConn.Mutex.Lock()
+			for _, ch := range sshConn.subscribers {
+				select {
+				case ch <- data:
+				default:
+					// Drop the oldest chunk to make room
+					select {
+					case <-ch:
+					default:
+					}
+					select {
+					case ch <- data:
+					default:
+					}
+				}
+			}
+			sshConn.Mutex.Unlock()
+		}
+		if err != nil {
+			if err != io.EOF {
+				tm.logger.WithError(err).WithFields(logrus.Fields{
+					"connectionID": sshConn.ID,
+				}).Debug("Error reading from persistent SSH pty")
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// backend/internal/terminal/console.go - Terminals attached to the VM serial console
+
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+// Terminal types. A console terminal attaches to the VM serial console instead of SSH; the
+// attach request's "type" query parameter also selects the console for an SSH terminal.
+const (
+	TerminalTypeSSH     = "ssh"
+	TerminalTypeConsole = "console"
+)
+
+// handleConsoleConnection bridges a WebSocket to the serial console of the session's VM.
+// Console connections are not shared between WebSockets and do not record command history.
+func (tm *Manager) handleConsoleConnection(ws *websocket.Conn, session *Session) {
+	webSocketConnectionsTotal.Inc()
+	webSocketConnectionsActive.Inc()
+	defer webSocketConnectionsActive.Dec()
+
+	logger := tm.logger.WithFields(logrus.Fields{
+		"terminalID": session.ID,
+		"sessionID":  session.SessionID,
+		"namespace":  session.Namespace,
+		"target":     session.Target,
+	})
+
+	vmName, err := tm.getVMNameForTarget(session.SessionID, session.Namespace, session.Target)
+	if err != nil {
+		logger.WithError(err).Error("Failed to resolve VM for console connection")
+		ws.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("Failed to create console connection: %v", err)))
+		return
+	}
+
+	conn, err := tm.kubevirtClient.SerialConsole(session.Namespace, vmName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to connect to serial console")
+		ws.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("Failed to create console connection: %v", err)))
+		return
+	}
+
+	var closeOnce sync.Once
+	closeConn := func() { closeOnce.Do(func() { conn.Close() }) }
+	defer closeConn()
+
+	logger.WithField("vmName", vmName).Info("Serial console connection established")
+
+	// Console output to the WebSocket; closing the WebSocket ends the read loop below
+	go func() {
+		defer ws.Close()
+
+		buffer := make([]byte, 4096)
+		for {
+			n, err := conn.Read(buffer)
+			if n > 0 {
+				if err := ws.WriteMessage(websocket.BinaryMessage, buffer[:n]); err != nil {
+					logger.WithError(err).Debug("Error writing console output to WebSocket")
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					logger.WithError(err).Debug("Error reading from serial console")
+				}
+				return
+			}
+		}
+	}()
+
+	// WebSocket input to the console
+	for {
+		messageType, p, err := ws.ReadMessage()
+		if err != nil {
+			logger.WithError(err).Debug("WebSocket read error in console bridge")
+			break
+		}
+
+		// The serial console has a fixed size, so resize messages are ignored
+		if messageType == websocket.BinaryMessage && len(p) >= 5 && p[0] == 1 {
+			continue
+		}
+
+		if _, err := conn.Write(p); err != nil {
+			logger.WithError(err).Warn("Error writing to serial console")
+			break
+		}
+	}
+
+	logger.Info("Serial console session ended")
+}
@@ -0,0 +1,193 @@
+// backend/internal/terminal/history.go - Command history of persistent SSH terminals
+
+package terminal
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxCommandHistory is the number of commands kept per terminal
+const maxCommandHistory = 100
+
+// redactedPlaceholder replaces text matching a redact pattern
+const redactedPlaceholder = "[REDACTED]"
+
+// commandHistory reconstructs command lines from raw pty input. It is a heuristic: input
+// edited with tab completion or history recall is recorded as typed, not as executed.
+type commandHistory struct {
+	line     []byte
+	escape   bool // inside an ANSI escape sequence such as an arrow key
+	commands []historyEntry
+}
+
+// historyEntry is a recorded command line
+type historyEntry struct {
+	command    string
+	recordedAt time.Time
+}
+
+// record feeds pty input to the history and returns the command lines it completed
+func (h *commandHistory) record(input []byte) []string {
+	var completed []string
+
+	for _, b := range input {
+		if h.escape {
+			// CSI and SS3 sequences end with a letter or '~'
+			if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || b == '~' {
+				h.escape = false
+			}
+			continue
+		}
+
+		switch {
+		case b == '\r' || b == '\n':
+			if line := strings.TrimSpace(string(h.line)); line != "" {
+				completed = append(completed, line)
+			}
+			h.line = h.line[:0]
+		case b == 0x7f || b == 0x08: // backspace
+			if len(h.line) > 0 {
+				h.line = h.line[:len(h.line)-1]
+			}
+		case b == 0x03 || b == 0x15: // Ctrl-C, Ctrl-U
+			h.line = h.line[:0]
+		case b == 0x1b:
+			h.escape = true
+		case b >= 0x20 || b == '\t':
+			h.line = append(h.line, b)
+		}
+	}
+
+	return completed
+}
+
+// add appends a command, dropping the oldest once the history is full
+func (h *commandHistory) add(command string) {
+	h.commands = append(h.commands, historyEntry{command: command, recordedAt: time.Now()})
+	if len(h.commands) > maxCommandHistory {
+		h.commands = append([]historyEntry(nil), h.commands[len(h.commands)-maxCommandHistory:]...)
+	}
+}
+
+// pruneBefore drops the commands recorded before cutoff
+func (h *commandHistory) pruneBefore(cutoff time.Time) {
+	i := 0
+	for i < len(h.commands) && h.commands[i].recordedAt.Before(cutoff) {
+		i++
+	}
+	if i > 0 {
+		h.commands = append([]historyEntry(nil), h.commands[i:]...)
+	}
+}
+
+// SetHistoryRedactPatterns sets the regular expressions whose matches are redacted from
+// recorded commands
+func (tm *Manager) SetHistoryRedactPatterns(patterns []string) error {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid history redact pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+
+	tm.lock.Lock()
+	defer tm.lock.Unlock()
+	tm.historyRedactPatterns = compiled
+	return nil
+}
+
+// recordInput adds the command lines completed by pty input to the terminal's history
+func (tm *Manager) recordInput(sshConn *PersistentSSHConnection, input []byte) {
+	sshConn.Mutex.Lock()
+	completed := sshConn.history.record(input)
+	sshConn.Mutex.Unlock()
+	if len(completed) == 0 {
+		return
+	}
+
+	tm.lock.RLock()
+	patterns := tm.historyRedactPatterns
+	tm.lock.RUnlock()
+
+	sshConn.Mutex.Lock()
+	defer sshConn.Mutex.Unlock()
+	for _, command := range completed {
+		for _, re := range patterns {
+			command = re.ReplaceAllString(command, redactedPlaceholder)
+		}
+		sshConn.history.add(command)
+	}
+}
+
+// CommandHistory returns the commands entered in a session's terminal, oldest first
+func (tm *Manager) CommandHistory(sessionID, target string) []string {
+	normalizedTarget, ok := normalizeTarget(target)
+	if !ok {
+		return []string{}
+	}
+
+	tm.persistentSSHLock.RLock()
+	conn, exists := tm.persistentSSH[fmt.Sprintf("%s-%s", sessionID, normalizedTarget)]
+	tm.persistentSSHLock.RUnlock()
+	if !exists {
+		return []string{}
+	}
+
+	conn.Mutex.Lock()
+	defer conn.Mutex.Unlock()
+	commands := make([]string, 0, len(conn.history.commands))
+	for _, entry := range conn.history.commands {
+		commands = append(commands, entry.command)
+	}
+	return commands
+}
+
+// ClearTerminalHistory discards the commands recorded for a session's terminal
+func (tm *Manager) ClearTerminalHistory(sessionID, terminalID string) error {
+	tm.lock.RLock()
+	session, exists := tm.sessions[terminalID]
+	tm.lock.RUnlock()
+	if !exists || session.SessionID != sessionID {
+		return fmt.Errorf("terminal not found: %s", terminalID)
+	}
+
+	normalizedTarget, ok := normalizeTarget(session.Target)
+	if !ok {
+		return fmt.Errorf("invalid terminal target: %s", session.Target)
+	}
+
+	tm.persistentSSHLock.RLock()
+	conn, exists := tm.persistentSSH[fmt.Sprintf("%s-%s", sessionID, normalizedTarget)]
+	tm.persistentSSHLock.RUnlock()
+	if !exists {
+		return nil
+	}
+
+	conn.Mutex.Lock()
+	defer conn.Mutex.Unlock()
+	conn.history.commands = nil
+	return nil
+}
+
+// pruneHistory drops commands older than HistoryRetention from every terminal. The caller
+// must hold persistentSSHLock.
+func (tm *Manager) pruneHistory() {
+	if tm.HistoryRetention <= 0 {
+		return
+	}
+
+	cutoff := time.Now().Add(-tm.HistoryRetention)
+	for _, conn := range tm.persistentSSH {
+		conn.Mutex.Lock()
+		conn.history.pruneBefore(cutoff)
+		conn.Mutex.Unlock()
+	}
+}
@@ -0,0 +1,97 @@
+// backend/internal/terminal/metrics.go - Terminal usage statistics
+
+package terminal
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	webSocketConnectionsActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "cks_terminal_websocket_connections_active",
+		Help: "Number of open terminal WebSocket connections",
+	})
+	webSocketConnectionsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "cks_terminal_websocket_connections_total",
+		Help: "Number of terminal WebSocket connections opened",
+	})
+)
+
+// TerminalMetrics summarizes terminal usage since the server started
+type TerminalMetrics struct {
+	ActiveSessions           int     `json:"activeSessions"`
+	PersistentSSHConnections int     `json:"persistentSSHConnections"`
+	TotalBytesRead           uint64  `json:"totalBytesRead"`    // Read from SSH terminals and sent to clients
+	TotalBytesWritten        uint64  `json:"totalBytesWritten"` // Received from clients and written to SSH terminals
+	AverageSessionAgeSeconds float64 `json:"averageSessionAgeSeconds"`
+}
+
+// GetMetrics returns the current terminal statistics
+func (tm *Manager) GetMetrics() TerminalMetrics {
+	metrics := TerminalMetrics{
+		TotalBytesRead:    tm.bytesRead.Load(),
+		TotalBytesWritten: tm.bytesWritten.Load(),
+	}
+
+	tm.lock.RLock()
+	metrics.ActiveSessions = len(tm.sessions)
+	var totalAge time.Duration
+	for _, session := range tm.sessions {
+		totalAge += time.Since(session.Created)
+	}
+	tm.lock.RUnlock()
+
+	if metrics.ActiveSessions > 0 {
+		metrics.AverageSessionAgeSeconds = totalAge.Seconds() / float64(metrics.ActiveSessions)
+	}
+
+	tm.persistentSSHLock.RLock()
+	metrics.PersistentSSHConnections = len(tm.persistentSSH)
+	tm.persistentSSHLock.RUnlock()
+
+	return metrics
+}
+
+// metricsCollector exports GetMetrics to Prometheus on every scrape
+type metricsCollector struct {
+	tm *Manager
+
+	activeSessions *prometheus.Desc
+	persistentSSH  *prometheus.Desc
+	bytesRead      *prometheus.Desc
+	bytesWritten   *prometheus.Desc
+	averageAge     *prometheus.Desc
+}
+
+// NewMetricsCollector returns a Prometheus collector for the manager's terminal statistics
+func NewMetricsCollector(tm *Manager) prometheus.Collector {
+	return &metricsCollector{
+		tm:             tm,
+		activeSessions: prometheus.NewDesc("cks_terminal_sessions", "Number of terminal sessions", nil, nil),
+		persistentSSH:  prometheus.NewDesc("cks_persistent_ssh_connections_active", "Number of open persistent SSH connections", nil, nil),
+		bytesRead:      prometheus.NewDesc("cks_terminal_bytes_read_total", "Bytes read from SSH terminals", nil, nil),
+		bytesWritten:   prometheus.NewDesc("cks_terminal_bytes_written_total", "Bytes written to SSH terminals", nil, nil),
+		averageAge:     prometheus.NewDesc("cks_terminal_session_age_average_seconds", "Average age of terminal sessions", nil, nil),
+	}
+}
+
+func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.activeSessions
+	ch <- c.persistentSSH
+	ch <- c.bytesRead
+	ch <- c.bytesWritten
+	ch <- c.averageAge
+}
+
+func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	metrics := c.tm.GetMetrics()
+
+	ch <- prometheus.MustNewConstMetric(c.activeSessions, prometheus.GaugeValue, float64(metrics.ActiveSessions))
+	ch <- prometheus.MustNewConstMetric(c.persistentSSH, prometheus.GaugeValue, float64(metrics.PersistentSSHConnections))
+	ch <- prometheus.MustNewConstMetric(c.bytesRead, prometheus.CounterValue, float64(metrics.TotalBytesRead))
+	ch <- prometheus.MustNewConstMetric(c.bytesWritten, prometheus.CounterValue, float64(metrics.TotalBytesWritten))
+	ch <- prometheus.MustNewConstMetric(c.averageAge, prometheus.GaugeValue, metrics.AverageSessionAgeSeconds)
+}
@@ -6,13 +6,13 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -26,6 +26,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// DefaultIdleTimeout is how long a terminal may go without input before it is disconnected
+	DefaultIdleTimeout = 10 * time.Minute
+
+	// idleWarningBefore is how long before the idle deadline clients are warned
+	idleWarningBefore = 60 * time.Second
+
+	// idleCheckInterval is how often attached WebSockets check for idleness
+	idleCheckInterval = 5 * time.Second
+)
+
 type PersistentSSHConnection struct {
 	ID          string
 	SessionID   string
@@ -37,6 +48,13 @@ type PersistentSSHConnection struct {
 	LastUsed    time.Time
 	ActiveConns int // Number of active WebSocket connections
 	Mutex       sync.Mutex
+
+	history commandHistory
+
+	// Output of the pty is read by one broadcaster and fanned out to every attached WebSocket
+	subscribers   []chan []byte
+	outputClosed  bool
+	broadcastOnce sync.Once
 }
 
 type Manager struct {
@@ -49,6 +67,22 @@ type Manager struct {
 	config            *rest.Config
 	sessionExpiry     time.Duration
 	logger            *logrus.Logger
+
+	// IdleTimeout disconnects terminals that receive no input for this long
+	IdleTimeout time.Duration
+
+	// HistoryRetention is how long recorded commands are kept; zero keeps them
+	HistoryRetention time.Duration
+
+	// namespaceResolver maps a session ID to the namespace of its assigned cluster
+	namespaceResolver func(sessionID string) string
+
+	// historyRedactPatterns are replaced in recorded terminal commands
+	historyRedactPatterns []*regexp.Regexp
+
+	// Bytes read from and written to SSH ptys, reported by GetMetrics
+	bytesRead    atomic.Uint64
+	bytesWritten atomic.Uint64
 }
 
 type Session struct {
@@ -56,6 +90,7 @@ type Session struct {
 	SessionID        string
 	Target           string // VM name
 	Namespace        string
+	Type             string // TerminalTypeSSH or TerminalTypeConsole
 	Created          time.Time
 	LastUsed         time.Time
 	ActiveConnection bool
@@ -71,6 +106,7 @@ func NewManager(kubeClient kubernetes.Interface, kubevirtClient *kubevirt.Client
 		config:         config,
 		sessionExpiry:  30 * time.Minute,
 		logger:         logger,
+		IdleTimeout:    DefaultIdleTimeout,
 	}
 
 	// Start cleanup goroutine
@@ -80,19 +116,29 @@ func NewManager(kubeClient kubernetes.Interface, kubevirtClient *kubevirt.Client
 }
 
 // CreateSession creates a new terminal session or reuses existing one
-func (tm *Manager) CreateSession(sessionID, namespace, target string) (string, error) {
+func (tm *Manager) CreateSession(sessionID, namespace, target, terminalType string) (string, error) {
+	switch terminalType {
+	case "":
+		terminalType = TerminalTypeSSH
+	case TerminalTypeSSH, TerminalTypeConsole:
+	default:
+		return "", fmt.Errorf("invalid terminal type %q", terminalType)
+	}
+
 	tm.lock.Lock()
 	defer tm.lock.Unlock()
 
 	// Generate deterministic terminal ID based on session and target
 	// Normalize target names to be consistent
-	normalizedTarget := target
-	if strings.HasPrefix(target, "cp-") {
-		normalizedTarget = "control-plane"
-	} else if strings.HasPrefix(target, "wk-") {
-		normalizedTarget = "worker-node"
+	normalizedTarget, ok := normalizeTarget(target)
+	if !ok {
+		normalizedTarget = target
 	}
 	terminalID := fmt.Sprintf("%s-%s", sessionID, normalizedTarget)
+	// A console terminal of a VM lives next to its SSH terminal
+	if terminalType == TerminalTypeConsole {
+		terminalID += "-" + TerminalTypeConsole
+	}
 	// Check if terminal session already exists
 	if existingSession, exists := tm.sessions[terminalID]; exists {
 		// Update last used time
@@ -113,6 +159,7 @@ func (tm *Manager) CreateSession(sessionID, namespace, target string) (string, e
 		SessionID:        sessionID,
 		Target:           target,
 		Namespace:        namespace,
+		Type:             terminalType,
 		Created:          time.Now(),
 		LastUsed:         time.Now(),
 		ActiveConnection: false,
@@ -124,6 +171,7 @@ func (tm *Manager) CreateSession(sessionID, namespace, target string) (string, e
 		"terminalID": terminalID,
 		"namespace":  namespace,
 		"target":     target,
+		"type":       terminalType,
 	}).Info("New terminal session created with deterministic ID")
 
 	return terminalID, nil
@@ -154,6 +202,11 @@ func (tm *Manager) GetSession(terminalID string) (*Session, error) {
 	}
 
 	sessionID := parts[0]
+	terminalType := TerminalTypeSSH
+	if parts[len(parts)-1] == TerminalTypeConsole {
+		terminalType = TerminalTypeConsole
+		parts = parts[:len(parts)-1]
+	}
 	target := strings.Join(parts[1:], "-") // Handle "control-plane" and "worker-node"
 
 	tm.logger.WithFields(logrus.Fields{
@@ -162,9 +215,7 @@ func (tm *Manager) GetSession(terminalID string) (*Session, error) {
 		"target":     target,
 	}).Info("Auto-creating terminal session for reconnection")
 
-	// We need namespace info, but we can derive it from the pattern
-	// For cluster pool, namespace is "cluster1", "cluster2", or "cluster3"
-	// We'll need to get this from somewhere... for now, let's add a method to find it
+	// Resolve the namespace of the cluster assigned to the session
 	namespace := tm.findNamespaceForSession(sessionID)
 	if namespace == "" {
 		return nil, fmt.Errorf("cannot determine namespace for session: %s", sessionID)
@@ -186,6 +237,7 @@ func (tm *Manager) GetSession(terminalID string) (*Session, error) {
 		SessionID:        sessionID,
 		Target:           target,
 		Namespace:        namespace,
+		Type:             terminalType,
 		Created:          time.Now(),
 		LastUsed:         time.Now(),
 		ActiveConnection: false,
@@ -203,28 +255,35 @@ func (tm *Manager) GetSession(terminalID string) (*Session, error) {
 
 // isValidTerminalID validates terminal ID format
 func (tm *Manager) isValidTerminalID(terminalID string) bool {
-	// Must match pattern: 8chars-target where target is "control-plane" or "worker-node"
-	pattern := `^[a-f0-9]{8}-(control-plane|worker-node)$`
+	// Must match pattern: 8chars-target where target is "control-plane" or "worker-node",
+	// followed by "-console" for console terminals
+	pattern := `^[a-f0-9]{8}-(control-plane|worker-node)(-console)?$`
 	matched, _ := regexp.MatchString(pattern, terminalID)
 	return matched
 }
 
-// Add helper method to find namespace for a session
-func (tm *Manager) findNamespaceForSession(sessionID string) string {
-	// For cluster pool implementation, we need to check which cluster the session is assigned to
-	// This is a simplified version - in production, you'd query the session service
+// SetNamespaceResolver sets the lookup used to find the cluster namespace of a session
+func (tm *Manager) SetNamespaceResolver(resolver func(sessionID string) string) {
+	tm.namespaceResolver = resolver
+}
 
-	// Try cluster1, cluster2, cluster3 (for cluster pool)
-	namespaces := []string{"cluster1", "cluster2", "cluster3"}
+// findNamespaceForSession returns the namespace holding the VMs of a session
+func (tm *Manager) findNamespaceForSession(sessionID string) string {
+	// Ask the cluster pool which cluster the session is assigned to
+	if tm.namespaceResolver != nil {
+		if ns := tm.namespaceResolver(sessionID); ns != "" {
+			return ns
+		}
+	}
 
-	// Also try the session-based namespace pattern
-	namespaces = append(namespaces, fmt.Sprintf("cks-%s", sessionID))
+	// Fall back to the session-based namespace pattern
+	namespaces := []string{fmt.Sprintf("cks-%s", sessionID)}
 
 	// Check if any VMs exist in these namespaces
 	for _, ns := range namespaces {
 		// Quick check if namespace exists and has VMs
-		vms, err := tm.kubevirtClient.VirtClient().VirtualMachine(ns).List(context.Background(), metav1.ListOptions{})
-		if err == nil && len(vms.Items) > 0 {
+		vms, err := tm.kubevirtClient.ListVMs(context.Background(), ns, "")
+		if err == nil && len(vms) > 0 {
 			tm.logger.WithFields(logrus.Fields{
 				"sessionID": sessionID,
 				"namespace": ns,
@@ -304,6 +363,12 @@ func (tm *Manager) HandleTerminal(w http.ResponseWriter, r *http.Request, termin
 		"namespace":  session.Namespace,
 	}).Info("Handling persistent terminal connection")
 
+	// The serial console works before the VM's SSH daemon is up
+	if session.Type == TerminalTypeConsole || r.URL.Query().Get("type") == TerminalTypeConsole {
+		tm.handleConsoleConnection(ws, session)
+		return
+	}
+
 	// Get or create persistent SSH connection
 	tm.logger.WithFields(logrus.Fields{
 		"terminalID": terminalID,
@@ -334,7 +399,8 @@ func (tm *Manager) HandleTerminal(w http.ResponseWriter, r *http.Request, termin
 	}).Info("Successfully established persistent SSH connection")
 
 	// Attach WebSocket to persistent SSH connection
-	err = tm.AttachToPersistentSSH(sshConn, ws)
+	readOnly := r.URL.Query().Get("mode") == TerminalModeObserve
+	err = tm.AttachToPersistentSSH(sshConn, ws, readOnly)
 	if err != nil {
 		tm.logger.WithError(err).Error("Failed to attach to persistent SSH connection")
 		ws.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("Failed to attach to terminal: %v", err)))
@@ -392,6 +458,8 @@ func (tm *Manager) cleanupExpiredPersistentSSH() {
 	tm.persistentSSHLock.Lock()
 	defer tm.persistentSSHLock.Unlock()
 
+	tm.pruneHistory()
+
 	expireTime := time.Now().Add(-tm.sessionExpiry)
 	expiredConnections := make([]string, 0)
 
@@ -415,6 +483,21 @@ func (tm *Manager) cleanupExpiredPersistentSSH() {
 		}
 	}
 
+	// Find idle persistent SSH connections nobody is attached to
+	if tm.IdleTimeout > 0 {
+		idleTime := time.Now().Add(-tm.IdleTimeout)
+		for connectionKey, conn := range tm.persistentSSH {
+			conn.Mutex.Lock()
+			idle := conn.ActiveConns == 0 && conn.LastUsed.Before(idleTime)
+			conn.Mutex.Unlock()
+
+			if idle && !containsKey(expiredConnections, connectionKey) {
+				tm.logger.WithField("connectionKey", connectionKey).Info("Persistent SSH connection idle with no active connections")
+				expiredConnections = append(expiredConnections, connectionKey)
+			}
+		}
+	}
+
 	// Clean up expired connections
 	for _, connectionKey := range expiredConnections {
 		if conn, exists := tm.persistentSSH[connectionKey]; exists {
@@ -429,8 +512,27 @@ func (tm *Manager) cleanupExpiredPersistentSSH() {
 	}
 }
 
+// containsKey reports whether keys contains key
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
 // CleanupSessionSSH cleans up all persistent SSH connections for a session
 func (tm *Manager) CleanupSessionSSH(sessionID string) {
+	// Forget the terminal sessions so they cannot be reattached
+	tm.lock.Lock()
+	for terminalID, session := range tm.sessions {
+		if session.SessionID == sessionID {
+			delete(tm.sessions, terminalID)
+		}
+	}
+	tm.lock.Unlock()
+
 	tm.persistentSSHLock.Lock()
 	defer tm.persistentSSHLock.Unlock()
 
@@ -468,13 +570,13 @@ func (tm *Manager) CleanupSessionSSH(sessionID string) {
 // GetOrCreatePersistentSSH gets existing or creates new persistent SSH connection
 func (tm *Manager) GetOrCreatePersistentSSH(sessionID, namespace, target string) (*PersistentSSHConnection, error) {
 	// Support both control-plane and worker nodes
-	isValidTarget := target == "control-plane" || target == "worker-node" ||
-		strings.HasPrefix(target, "cp-") || strings.HasPrefix(target, "wk-")
-	if !isValidTarget {
+	normalizedTarget, ok := normalizeTarget(target)
+	if !ok {
 		return nil, fmt.Errorf("unsupported target type: %s", target)
 	}
 
-	connectionKey := fmt.Sprintf("%s-%s", sessionID, target)
+	// Key by normalized target so a VM name and its generic target share one connection
+	connectionKey := fmt.Sprintf("%s-%s", sessionID, normalizedTarget)
 
 	tm.persistentSSHLock.Lock()
 	defer tm.persistentSSHLock.Unlock()
@@ -514,6 +616,38 @@ func (tm *Manager) GetOrCreatePersistentSSH(sessionID, namespace, target string)
 	return conn, nil
 }
 
+// ActiveConnections returns the number of WebSockets attached to the persistent SSH
+// connection of a session's terminal, or 0 if there is none
+func (tm *Manager) ActiveConnections(sessionID, target string) int {
+	normalizedTarget, ok := normalizeTarget(target)
+	if !ok {
+		return 0
+	}
+
+	tm.persistentSSHLock.RLock()
+	conn, exists := tm.persistentSSH[fmt.Sprintf("%s-%s", sessionID, normalizedTarget)]
+	tm.persistentSSHLock.RUnlock()
+	if !exists {
+		return 0
+	}
+
+	conn.Mutex.Lock()
+	defer conn.Mutex.Unlock()
+	return conn.ActiveConns
+}
+
+// normalizeTarget maps a VM name or target alias to "control-plane" or "worker-node"
+func normalizeTarget(target string) (string, bool) {
+	switch {
+	case target == "control-plane" || strings.HasPrefix(target, "cp-"):
+		return "control-plane", true
+	case target == "worker-node" || target == "worker" || strings.HasPrefix(target, "wk-"):
+		return "worker-node", true
+	default:
+		return "", false
+	}
+}
+
 // buildVirtctlSSHArgs builds standardized virtctl ssh arguments for terminal connections
 func (tm *Manager) buildVirtctlSSHArgs(namespace, vmName, username string) []string {
 	return []string{
@@ -631,7 +765,7 @@ func (tm *Manager) getVMNameForTarget(sessionID, namespace, target string) (stri
 
 	// Handle generic target names
 	var vmPrefix string
-	switch target {
+	switch normalizedTarget, _ := normalizeTarget(target); normalizedTarget {
 	case "control-plane":
 		vmPrefix = "cp-"
 	case "worker-node":
@@ -640,24 +774,14 @@ func (tm *Manager) getVMNameForTarget(sessionID, namespace, target string) (stri
 		return "", fmt.Errorf("unknown target type: %s", target)
 	}
 
-	// Try cluster pool patterns first: cp-cluster1, cp-cluster2, cp-cluster3
-	clusterPatterns := []string{
-		vmPrefix + "cluster1",
-		vmPrefix + "cluster2",
-		vmPrefix + "cluster3",
-	}
-
-	for _, vmName := range clusterPatterns {
-		// Check if VM exists in this namespace
-		_, err := tm.kubevirtClient.VirtClient().VirtualMachine(namespace).Get(context.Background(), vmName, metav1.GetOptions{})
-		if err == nil {
-			return vmName, nil
-		}
+	// Try the cluster pool pattern first: the namespace matches the cluster ID (cp-clusterX, wk-clusterX)
+	vmName := vmPrefix + namespace
+	if _, err := tm.kubevirtClient.VirtClient().VirtualMachine(namespace).Get(context.Background(), vmName, metav1.GetOptions{}); err == nil {
+		return vmName, nil
 	}
 
 	// Fallback: try session-based naming
-	vmName := fmt.Sprintf("%s%s", vmPrefix, sessionID)
-	return vmName, nil
+	return fmt.Sprintf("%s%s", vmPrefix, sessionID), nil
 }
 
 // isSSHProcessAlive checks if the SSH process is still running
@@ -671,31 +795,40 @@ func (tm *Manager) isSSHProcessAlive(conn *PersistentSSHConnection) bool {
 	return err == nil
 }
 
-// AttachToPersistentSSH attaches a WebSocket to existing SSH connection
-func (tm *Manager) AttachToPersistentSSH(sshConn *PersistentSSHConnection, ws *websocket.Conn) error {
+// AttachToPersistentSSH attaches a WebSocket to existing SSH connection. Every attached
+// WebSocket receives the full output; read-only WebSockets cannot send input.
+func (tm *Manager) AttachToPersistentSSH(sshConn *PersistentSSHConnection, ws *websocket.Conn, readOnly bool) error {
 	sshConn.Mutex.Lock()
 	sshConn.ActiveConns++
 	sshConn.LastUsed = time.Now()
 	activeConns := sshConn.ActiveConns
 	sshConn.Mutex.Unlock()
 
+	webSocketConnectionsTotal.Inc()
+	webSocketConnectionsActive.Inc()
+	output := tm.subscribeOutput(sshConn)
+
 	tm.logger.WithFields(logrus.Fields{
 		"connectionID": sshConn.ID,
 		"activeConns":  activeConns,
+		"readOnly":     readOnly,
 	}).Info("WebSocket attached to persistent SSH")
 
 	// Set up communication between WebSocket and SSH
-	return tm.bridgeWebSocketToSSH(sshConn, ws)
+	return tm.bridgeWebSocketToSSH(sshConn, ws, output, readOnly)
 }
 
-// DetachFromPersistentSSH detaches WebSocket from SSH connection
-func (tm *Manager) DetachFromPersistentSSH(sshConn *PersistentSSHConnection) {
+// DetachFromPersistentSSH detaches a WebSocket and its output channel from SSH connection
+func (tm *Manager) DetachFromPersistentSSH(sshConn *PersistentSSHConnection, output chan []byte) {
+	tm.unsubscribeOutput(sshConn, output)
+
 	sshConn.Mutex.Lock()
 	if sshConn.ActiveConns > 0 {
 		sshConn.ActiveConns--
 	}
 	activeConns := sshConn.ActiveConns
 	sshConn.Mutex.Unlock()
+	webSocketConnectionsActive.Dec()
 
 	tm.logger.WithFields(logrus.Fields{
 		"connectionID": sshConn.ID,
@@ -735,36 +868,42 @@ func (tm *Manager) cleanupDeadSSHConnection(conn *PersistentSSHConnection) {
 	}
 }
 
-// bridgeWebSocketToSSH handles communication between WebSocket and SSH
-func (tm *Manager) bridgeWebSocketToSSH(sshConn *PersistentSSHConnection, ws *websocket.Conn) error {
+// bridgeWebSocketToSSH forwards the pty output delivered on output to the WebSocket and,
+// unless readOnly, the WebSocket input to the pty
+func (tm *Manager) bridgeWebSocketToSSH(sshConn *PersistentSSHConnection, ws *websocket.Conn, output chan []byte, readOnly bool) error {
 	// Create a channel to signal when the connection is done
 	done := make(chan struct{})
 	defer close(done)
 
 	// Ensure we detach when done
-	defer tm.DetachFromPersistentSSH(sshConn)
+	defer tm.DetachFromPersistentSSH(sshConn, output)
+
+	// The pty reader and the idle watcher both write to the WebSocket
+	var writeLock sync.Mutex
+	writeMessage := func(messageType int, data []byte) error {
+		writeLock.Lock()
+		defer writeLock.Unlock()
+		return ws.WriteMessage(messageType, data)
+	}
 
-	// Set up a goroutine to handle reading from the SSH pty
+	if tm.IdleTimeout > 0 {
+		go tm.watchIdle(sshConn, ws, &writeLock, done)
+	}
+
+	// Forward the broadcast pty output to this WebSocket
 	go func() {
-		buffer := make([]byte, 4096)
 		for {
 			select {
 			case <-done:
 				return
-			default:
-				n, err := sshConn.PTY.Read(buffer)
-				if err != nil {
-					if err != io.EOF {
-						tm.logger.WithError(err).Debug("Error reading from persistent SSH pty")
-					}
+			case data, ok := <-output:
+				if !ok {
+					// The pty can no longer be read
 					return
 				}
-
-				if n > 0 {
-					if err := ws.WriteMessage(websocket.BinaryMessage, buffer[:n]); err != nil {
-						tm.logger.WithError(err).Warn("Error writing to WebSocket from persistent SSH")
-						return
-					}
+				if err := writeMessage(websocket.BinaryMessage, data); err != nil {
+					tm.logger.WithError(err).Warn("Error writing to WebSocket from persistent SSH")
+					return
 				}
 			}
 		}
@@ -778,6 +917,15 @@ func (tm *Manager) bridgeWebSocketToSSH(sshConn *PersistentSSHConnection, ws *we
 			return nil
 		}
 
+		// Observers only watch the output
+		if readOnly {
+			continue
+		}
+
+		sshConn.Mutex.Lock()
+		sshConn.LastUsed = time.Now()
+		sshConn.Mutex.Unlock()
+
 		// Handle terminal resize messages
 		if messageType == websocket.BinaryMessage && len(p) >= 5 && p[0] == 1 {
 			width := uint16(p[1])<<8 | uint16(p[2])
@@ -800,14 +948,72 @@ func (tm *Manager) bridgeWebSocketToSSH(sshConn *PersistentSSHConnection, ws *we
 			continue
 		}
 
+		tm.recordInput(sshConn, p)
+
 		// Write data to pty
-		if _, err := sshConn.PTY.Write(p); err != nil {
+		n, err := sshConn.PTY.Write(p)
+		tm.bytesWritten.Add(uint64(n))
+		if err != nil {
 			tm.logger.WithError(err).Warn("Error writing to persistent SSH pty")
 			return nil
 		}
 	}
 }
 
+// watchIdle warns the client before the idle deadline and closes the WebSocket once it passes
+func (tm *Manager) watchIdle(sshConn *PersistentSSHConnection, ws *websocket.Conn, writeLock *sync.Mutex, done <-chan struct{}) {
+	ticker := time.NewTicker(idleCheckInterval)
+	defer ticker.Stop()
+
+	warned := false
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		sshConn.Mutex.Lock()
+		remaining := time.Until(sshConn.LastUsed.Add(tm.IdleTimeout))
+		sshConn.Mutex.Unlock()
+
+		switch {
+		case remaining <= 0:
+			tm.logger.WithFields(logrus.Fields{
+				"connectionID": sshConn.ID,
+				"idleTimeout":  tm.IdleTimeout,
+			}).Info("Disconnecting idle terminal")
+
+			writeLock.Lock()
+			ws.WriteMessage(websocket.TextMessage, []byte(`{"type":"idle_disconnect"}`))
+			ws.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, "idle timeout"),
+				time.Now().Add(time.Second))
+			writeLock.Unlock()
+
+			// Closing unblocks the bridge's read loop, which detaches from the SSH connection
+			ws.Close()
+			return
+
+		case remaining <= idleWarningBefore && !warned:
+			warned = true
+			message := fmt.Sprintf(`{"type":"idle_warning","secondsRemaining":%d}`, int(idleWarningBefore.Seconds()))
+
+			writeLock.Lock()
+			err := ws.WriteMessage(websocket.TextMessage, []byte(message))
+			writeLock.Unlock()
+			if err != nil {
+				tm.logger.WithError(err).Debug("Failed to send idle warning")
+				return
+			}
+
+		case remaining > idleWarningBefore:
+			// Input arrived after the warning
+			warned = false
+		}
+	}
+}
+
 // testSSHConnection tests if SSH connection to a VM is working
 func (tm *Manager) testSSHConnection(ctx context.Context, namespace, vmName string) error {
 	tm.logger.WithFields(logrus.Fields{
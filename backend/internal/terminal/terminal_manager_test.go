@@ -0,0 +1,102 @@
+package terminal
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestNormalizeTarget(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+		ok     bool
+	}{
+		{target: "control-plane", want: "control-plane", ok: true},
+		{target: "cp-cluster1", want: "control-plane", ok: true},
+		{target: "worker", want: "worker-node", ok: true},
+		{target: "worker-node", want: "worker-node", ok: true},
+		{target: "wk-cluster1", want: "worker-node", ok: true},
+		{target: "etcd", ok: false},
+		{target: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := normalizeTarget(tt.target)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("normalizeTarget(%q) = %q, %v, want %q, %v", tt.target, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// fakeVirtctl puts a virtctl on PATH that answers the connectivity test and otherwise
+// stays running like an SSH session
+func fakeVirtctl(t *testing.T) {
+	t.Helper()
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\ncase \"$*\" in\n*--command=*) echo 'SSH connection test successful' ;;\n*) exec sleep 60 ;;\nesac\n"
+	if err := os.WriteFile(filepath.Join(dir, "virtctl"), []byte(script), 0o755); err != nil {
+		t.Fatalf("write fake virtctl: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestGetOrCreatePersistentSSHSharesConnectionPerRole(t *testing.T) {
+	fakeVirtctl(t)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	tm := &Manager{
+		persistentSSH: make(map[string]*PersistentSSHConnection),
+		logger:        logger,
+	}
+	t.Cleanup(func() {
+		for _, conn := range tm.persistentSSH {
+			tm.cleanupDeadSSHConnection(conn)
+		}
+	})
+
+	roles := []struct {
+		vmName  string
+		aliases []string
+	}{
+		{vmName: "cp-cluster1", aliases: []string{"control-plane"}},
+		{vmName: "wk-cluster1", aliases: []string{"worker", "worker-node"}},
+	}
+
+	for _, role := range roles {
+		conn, err := tm.GetOrCreatePersistentSSH("s1", "cluster1", role.vmName)
+		if err != nil {
+			t.Fatalf("GetOrCreatePersistentSSH(%q): %v", role.vmName, err)
+		}
+		for _, alias := range role.aliases {
+			shared, err := tm.GetOrCreatePersistentSSH("s1", "cluster1", alias)
+			if err != nil {
+				t.Fatalf("GetOrCreatePersistentSSH(%q): %v", alias, err)
+			}
+			if shared != conn {
+				t.Errorf("%s and %s use different connections", role.vmName, alias)
+			}
+		}
+	}
+
+	if len(tm.persistentSSH) != 2 {
+		t.Errorf("got %d persistent connections, want one per role", len(tm.persistentSSH))
+	}
+	for _, key := range []string{"s1-control-plane", "s1-worker-node"} {
+		if _, ok := tm.persistentSSH[key]; !ok {
+			t.Errorf("no persistent connection under %q", key)
+		}
+	}
+}
+
+func TestGetOrCreatePersistentSSHRejectsUnknownTarget(t *testing.T) {
+	tm := &Manager{persistentSSH: make(map[string]*PersistentSSHConnection), logger: logrus.New()}
+	if _, err := tm.GetOrCreatePersistentSSH("s1", "cluster1", "etcd"); err == nil {
+		t.Error("unknown target accepted")
+	}
+}
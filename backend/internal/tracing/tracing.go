@@ -0,0 +1,71 @@
+// backend/internal/tracing/tracing.go - OpenTelemetry tracing of requests and long operations
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fullstack-pw/cks/backend/internal/config"
+)
+
+// serviceName identifies the backend in exported traces
+const serviceName = "cks-backend"
+
+// tracerName is the instrumentation scope of the backend's spans
+const tracerName = "github.com/fullstack-pw/cks/backend"
+
+// Init installs the global tracer provider exporting spans to cfg.OTLPEndpoint. Without an
+// endpoint spans are not recorded. The returned function flushes and stops the exporter.
+func Init(ctx context.Context, cfg *config.Config, logger *logrus.Logger) (func(context.Context) error, error) {
+	// W3C trace context is propagated even when tracing is disabled
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	if cfg.OTLPEndpoint == "" {
+		logger.Info("Tracing disabled, no OTLP endpoint configured")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+			semconv.DeploymentEnvironment(cfg.Environment),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	logger.WithField("endpoint", cfg.OTLPEndpoint).Info("Tracing enabled")
+	return provider.Shutdown, nil
+}
+
+// Start starts a span that is a child of any span in ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err, if any, on the span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
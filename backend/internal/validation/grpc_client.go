@@ -0,0 +1,69 @@
+// backend/internal/validation/grpc_client.go - Client of a remote ValidationService
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+	"github.com/fullstack-pw/cks/backend/internal/validation/validationpb"
+)
+
+// GRPCClient validates tasks through a ValidationService running as a separate service
+type GRPCClient struct {
+	conn   *grpc.ClientConn
+	client validationpb.ValidationServiceClient
+}
+
+// NewGRPCClient creates a client of the ValidationService at endpoint (host:port) that
+// authenticates with token. The service certificate is verified against the CA in caFile; an
+// empty caFile connects without TLS. The connection is established lazily, so an unreachable
+// service fails the first validation.
+func NewGRPCClient(endpoint, token, caFile string) (*GRPCClient, error) {
+	if token == "" {
+		return nil, errors.New("a token is required to use the validation service")
+	}
+
+	transport := insecure.NewCredentials()
+	if caFile != "" {
+		creds, err := credentials.NewClientTLSFromFile(caFile, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load validation service CA: %w", err)
+		}
+		transport = creds
+	}
+
+	conn, err := grpc.NewClient(endpoint,
+		grpc.WithTransportCredentials(transport),
+		grpc.WithPerRPCCredentials(tokenCredentials{token: token, requireTLS: caFile != ""}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validation service client: %w", err)
+	}
+	return &GRPCClient{conn: conn, client: validationpb.NewValidationServiceClient(conn)}, nil
+}
+
+// ValidateTask validates the rules of a task against the session on the remote service
+func (c *GRPCClient) ValidateTask(ctx context.Context, session *models.Session, taskID string, rules []models.ValidationRule) (*ValidationResponse, error) {
+	req, err := requestToProto(session, taskID, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := c.client.ValidateTask(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("validation service call failed: %w", err)
+	}
+	return responseFromProto(msg)
+}
+
+// Close closes the connection to the validation service
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,134 @@
+// backend/internal/validation/grpc_convert.go - Conversion between the validation types and the ValidationService messages
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+	"github.com/fullstack-pw/cks/backend/internal/validation/validationpb"
+)
+
+// requestToProto builds the ValidateTaskRequest for the rules of a task of a session
+func requestToProto(session *models.Session, taskID string, rules []models.ValidationRule) (*validationpb.ValidateTaskRequest, error) {
+	req := &validationpb.ValidateTaskRequest{
+		SessionId:      session.ID,
+		TaskId:         taskID,
+		Namespace:      session.Namespace,
+		ControlPlaneVm: session.ControlPlaneVM,
+		WorkerNodeVm:   session.WorkerNodeVM,
+	}
+	for _, rule := range rules {
+		ruleJSON, err := json.Marshal(rule)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode rule %s: %w", rule.ID, err)
+		}
+		req.Rules = append(req.Rules, &validationpb.ValidationRule{
+			Id:   rule.ID,
+			Type: rule.Type,
+			Json: ruleJSON,
+		})
+	}
+	return req, nil
+}
+
+// requestFromProto returns the session and rules described by a ValidateTaskRequest
+func requestFromProto(req *validationpb.ValidateTaskRequest) (*models.Session, []models.ValidationRule, error) {
+	session := &models.Session{
+		ID:             req.GetSessionId(),
+		Namespace:      req.GetNamespace(),
+		ControlPlaneVM: req.GetControlPlaneVm(),
+		WorkerNodeVM:   req.GetWorkerNodeVm(),
+	}
+	rules := make([]models.ValidationRule, 0, len(req.GetRules()))
+	for _, r := range req.GetRules() {
+		var rule models.ValidationRule
+		if err := json.Unmarshal(r.GetJson(), &rule); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode rule %s: %w", r.GetId(), err)
+		}
+		rules = append(rules, rule)
+	}
+	return session, rules, nil
+}
+
+// responseToProto converts a validation response to its message. Expected and actual values
+// travel as JSON.
+func responseToProto(response *ValidationResponse) (*validationpb.ValidationResponse, error) {
+	msg := &validationpb.ValidationResponse{
+		Success: response.Success,
+		Message: response.Message,
+	}
+	if !response.Timestamp.IsZero() {
+		msg.Timestamp = timestamppb.New(response.Timestamp)
+	}
+	for _, result := range response.Results {
+		expected, err := encodeResultValue(result.Expected)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode expected value of rule %s: %w", result.RuleID, err)
+		}
+		actual, err := encodeResultValue(result.Actual)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode actual value of rule %s: %w", result.RuleID, err)
+		}
+		msg.Results = append(msg.Results, &validationpb.ValidationResult{
+			RuleId:       result.RuleID,
+			RuleType:     result.RuleType,
+			Passed:       result.Passed,
+			Message:      result.Message,
+			ExpectedJson: expected,
+			ActualJson:   actual,
+			ErrorCode:    result.ErrorCode,
+			Description:  result.Description,
+		})
+	}
+	return msg, nil
+}
+
+// responseFromProto converts a ValidationResponse message back to a validation response
+func responseFromProto(msg *validationpb.ValidationResponse) (*ValidationResponse, error) {
+	response := &ValidationResponse{
+		Success: msg.GetSuccess(),
+		Message: msg.GetMessage(),
+		Results: make([]ValidationResult, 0, len(msg.GetResults())),
+	}
+	if msg.GetTimestamp() != nil {
+		response.Timestamp = msg.GetTimestamp().AsTime()
+	}
+	for _, r := range msg.GetResults() {
+		result := ValidationResult{
+			RuleID:      r.GetRuleId(),
+			RuleType:    r.GetRuleType(),
+			Passed:      r.GetPassed(),
+			Message:     r.GetMessage(),
+			ErrorCode:   r.GetErrorCode(),
+			Description: r.GetDescription(),
+		}
+		if err := decodeResultValue(r.GetExpectedJson(), &result.Expected); err != nil {
+			return nil, fmt.Errorf("failed to decode expected value of rule %s: %w", result.RuleID, err)
+		}
+		if err := decodeResultValue(r.GetActualJson(), &result.Actual); err != nil {
+			return nil, fmt.Errorf("failed to decode actual value of rule %s: %w", result.RuleID, err)
+		}
+		response.Results = append(response.Results, result)
+	}
+	return response, nil
+}
+
+// encodeResultValue returns the JSON encoding of an expected or actual value; nil stays empty
+func encodeResultValue(v interface{}) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// decodeResultValue decodes a value encoded by encodeResultValue; empty leaves dst nil
+func decodeResultValue(b []byte, dst *interface{}) error {
+	if len(b) == 0 {
+		return nil
+	}
+	return json.Unmarshal(b, dst)
+}
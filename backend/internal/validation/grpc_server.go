@@ -0,0 +1,88 @@
+// backend/internal/validation/grpc_server.go - ValidationService gRPC server
+
+//go:generate protoc --proto_path=../.. --go_out=../.. --go_opt=module=github.com/fullstack-pw/cks/backend --go-grpc_out=../.. --go-grpc_opt=module=github.com/fullstack-pw/cks/backend proto/validation.proto
+
+package validation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+	"github.com/fullstack-pw/cks/backend/internal/validation/validationpb"
+)
+
+// SessionVerifier confirms that a session exists and owns the cluster a request names
+type SessionVerifier interface {
+	VerifySessionCluster(ctx context.Context, session *models.Session) error
+}
+
+// grpcValidationService serves ValidationService with the local validator
+type grpcValidationService struct {
+	validationpb.UnimplementedValidationServiceServer
+	validator *UnifiedValidator
+	sessions  SessionVerifier
+	logger    *logrus.Logger
+}
+
+// NewGRPCServer returns a gRPC server exposing the validator as ValidationService. Calls must
+// carry token as a bearer token, and only sessions accepted by sessions are validated. The
+// server uses TLS when creds is not nil.
+func NewGRPCServer(validator *UnifiedValidator, sessions SessionVerifier, token string, creds credentials.TransportCredentials, logger *logrus.Logger) (*grpc.Server, error) {
+	if token == "" {
+		return nil, errors.New("a token is required to serve the validation service")
+	}
+
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(tokenAuthInterceptor(token))}
+	if creds != nil {
+		opts = append(opts, grpc.Creds(creds))
+	}
+	server := grpc.NewServer(opts...)
+	validationpb.RegisterValidationServiceServer(server, &grpcValidationService{
+		validator: validator,
+		sessions:  sessions,
+		logger:    logger,
+	})
+	return server, nil
+}
+
+// ValidateTask validates the rules of a task against the session described in the request
+func (s *grpcValidationService) ValidateTask(ctx context.Context, req *validationpb.ValidateTaskRequest) (*validationpb.ValidationResponse, error) {
+	if req.GetSessionId() == "" || req.GetNamespace() == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id and namespace are required")
+	}
+
+	session, rules, err := requestFromProto(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	logger := s.logger.WithFields(logrus.Fields{
+		"sessionID": session.ID,
+		"taskID":    req.GetTaskId(),
+		"namespace": session.Namespace,
+	})
+
+	if err := s.sessions.VerifySessionCluster(ctx, session); err != nil {
+		logger.WithError(err).Warn("Rejected gRPC validation of unknown session")
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	response, err := s.validator.ValidateTask(ctx, session, rules)
+	if err != nil {
+		logger.WithError(err).Error("gRPC task validation failed")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	msg, err := responseToProto(response)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return msg, nil
+}
@@ -0,0 +1,143 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+	"github.com/fullstack-pw/cks/backend/internal/validation/validationpb"
+)
+
+// stubExecutor answers every VM command with the same output
+type stubExecutor struct {
+	output string
+}
+
+func (s stubExecutor) ExecuteCommandInVM(ctx context.Context, namespace, vmName, command string, retry ...bool) (string, error) {
+	return s.output, nil
+}
+
+// knownSession accepts only its own session
+type knownSession struct {
+	session models.Session
+}
+
+func (k knownSession) VerifySessionCluster(ctx context.Context, session *models.Session) error {
+	if session.ID != k.session.ID || session.Namespace != k.session.Namespace ||
+		session.ControlPlaneVM != k.session.ControlPlaneVM || session.WorkerNodeVM != k.session.WorkerNodeVM {
+		return errors.New("cluster not assigned to session")
+	}
+	return nil
+}
+
+// startGRPCTest serves the validation service on an in-memory listener and returns a client
+// sending token
+func startGRPCTest(t *testing.T, session models.Session, token string) *GRPCClient {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	server, err := NewGRPCServer(NewUnifiedValidator(stubExecutor{output: "Running"}, logger), knownSession{session: session}, "secret", nil, logger)
+	if err != nil {
+		t.Fatalf("NewGRPCServer: %v", err)
+	}
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///validation",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(tokenCredentials{token: token}),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &GRPCClient{conn: conn, client: validationpb.NewValidationServiceClient(conn)}
+}
+
+func testSession() models.Session {
+	return models.Session{
+		ID:             "s1",
+		Namespace:      "cluster1",
+		ControlPlaneVM: "cp-cluster1",
+		WorkerNodeVM:   "wk-cluster1",
+	}
+}
+
+func testRules() []models.ValidationRule {
+	return []models.ValidationRule{{
+		ID:        "pod-running",
+		Type:      "command",
+		Command:   &models.CommandTarget{Command: "kubectl get pod web -o jsonpath='{.status.phase}'", Target: "control-plane"},
+		Condition: "output_equals",
+		Value:     "Running",
+	}}
+}
+
+func TestGRPCValidateTask(t *testing.T) {
+	session := testSession()
+	client := startGRPCTest(t, session, "secret")
+
+	response, err := client.ValidateTask(context.Background(), &session, "01", testRules())
+	if err != nil {
+		t.Fatalf("ValidateTask: %v", err)
+	}
+	if !response.Success || len(response.Results) != 1 {
+		t.Fatalf("response = %+v, want one passed result", response)
+	}
+	result := response.Results[0]
+	if result.RuleID != "pod-running" || !result.Passed || result.Expected != "Running" || result.Actual != "Running" {
+		t.Errorf("result = %+v", result)
+	}
+	if response.Timestamp.IsZero() {
+		t.Error("response has no timestamp")
+	}
+}
+
+func TestGRPCValidateTaskRejectsInvalidToken(t *testing.T) {
+	session := testSession()
+	client := startGRPCTest(t, session, "wrong")
+
+	_, err := client.ValidateTask(context.Background(), &session, "01", testRules())
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("err = %v, want Unauthenticated", err)
+	}
+}
+
+func TestGRPCValidateTaskRejectsUnknownSession(t *testing.T) {
+	client := startGRPCTest(t, testSession(), "secret")
+
+	other := testSession()
+	other.ID = "s2"
+	if _, err := client.ValidateTask(context.Background(), &other, "01", testRules()); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("unknown session: err = %v, want PermissionDenied", err)
+	}
+
+	moved := testSession()
+	moved.Namespace = "kube-system"
+	if _, err := client.ValidateTask(context.Background(), &moved, "01", testRules()); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("other namespace: err = %v, want PermissionDenied", err)
+	}
+}
+
+func TestNewGRPCServerRequiresToken(t *testing.T) {
+	if _, err := NewGRPCServer(nil, knownSession{}, "", nil, logrus.New()); err == nil {
+		t.Error("NewGRPCServer without a token succeeded")
+	}
+}
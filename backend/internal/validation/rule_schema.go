@@ -0,0 +1,206 @@
+// backend/internal/validation/rule_schema.go - Static checks for validation rule definitions
+
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// ruleConditions lists the conditions accepted by each rule type.
+// An empty list means the rule type ignores Condition.
+var ruleConditions = map[string][]string{
+	"resource_exists":          {},
+	"resource_count":           {"equals", "gte", "lte"},
+	"command":                  {"success", "output_equals", "output_empty", "output_not_empty", "output_line_count_equals", "output_line_count_gte", "output_line_count_lte"},
+	"script":                   {},
+	"file_exists":              {},
+	"file_content":             {"contains", "output_empty", "output_not_empty"},
+	"admission_controller":     {"enabled", "disabled"},
+	"certificate_valid":        {"contains", "matches"},
+	"helm_release":             {"", "installed", "version_equals", "status_equals"},
+	"etcd_key_exists":          {"", "exists", "not_exists", "value_matches"},
+	"rbac_allowed":             {"allowed", "denied"},
+	"network_policy_effective": {"allowed", "blocked"},
+	"configmap_data":           {"equals", "contains", "matches", "not_contains", "keys_exist"},
+	"opa_constraint":           {}, // Uses opa.violationCountCondition
+	"service_account_token":    {"", "mounted", "not_mounted"},
+	"pod_security_admission":   {"configured", "not_configured"},
+}
+
+// certificateProperties lists the property keys understood by certificate_valid rules
+var certificateProperties = map[string]bool{
+	"issuer":         true,
+	"subject":        true,
+	"san":            true,
+	"not_after_days": true,
+}
+
+// Pod Security Admission levels and modes understood by pod_security_admission rules
+var (
+	psaLevels = []string{"privileged", "baseline", "restricted"}
+	psaModes  = []string{"enforce", "audit", "warn"}
+)
+
+// ValidateRule checks that a rule has the fields required by its type and a supported condition
+func ValidateRule(rule models.ValidationRule) error {
+	if rule.ID == "" {
+		return fmt.Errorf("missing required field: id")
+	}
+
+	conditions, known := ruleConditions[rule.Type]
+	if !known {
+		return fmt.Errorf("unknown validation type: %q", rule.Type)
+	}
+
+	if len(conditions) > 0 && !containsString(conditions, rule.Condition) {
+		return fmt.Errorf("invalid condition %q for type %s (expected one of %v)", rule.Condition, rule.Type, conditions)
+	}
+
+	switch rule.Type {
+	case "resource_exists":
+		if rule.Resource == nil || rule.Resource.Kind == "" || rule.Resource.Name == "" {
+			return fmt.Errorf("resource_exists requires resource.kind and resource.name")
+		}
+
+	case "resource_count":
+		if rule.ResourceCount == nil || rule.ResourceCount.Kind == "" {
+			return fmt.Errorf("resource_count requires resourceCount.kind")
+		}
+		if _, err := strconv.Atoi(fmt.Sprintf("%v", rule.Value)); err != nil {
+			return fmt.Errorf("resource_count requires an integer value, got %v", rule.Value)
+		}
+
+	case "command":
+		if rule.Command == nil || rule.Command.Command == "" {
+			return fmt.Errorf("command requires command.command")
+		}
+		if rule.Condition == "output_equals" && rule.Value == nil {
+			return fmt.Errorf("output_equals requires a value")
+		}
+		if strings.HasPrefix(rule.Condition, "output_line_count_") {
+			if _, err := strconv.Atoi(fmt.Sprintf("%v", rule.Value)); err != nil {
+				return fmt.Errorf("%s requires an integer value, got %v", rule.Condition, rule.Value)
+			}
+		}
+
+	case "script":
+		if rule.Script == nil || rule.Script.Script == "" {
+			return fmt.Errorf("script requires script.script")
+		}
+
+	case "file_exists", "file_content":
+		if rule.File == nil || rule.File.Path == "" {
+			return fmt.Errorf("%s requires file.path", rule.Type)
+		}
+		if rule.Condition == "contains" && rule.Value == nil {
+			return fmt.Errorf("contains requires a value")
+		}
+
+	case "admission_controller":
+		if rule.Admission == nil || rule.Admission.PluginName == "" {
+			return fmt.Errorf("admission_controller requires admission.pluginName")
+		}
+
+	case "certificate_valid":
+		if rule.Certificate == nil || rule.Certificate.Path == "" {
+			return fmt.Errorf("certificate_valid requires certificate.path")
+		}
+		if len(rule.Certificate.Properties) == 0 {
+			return fmt.Errorf("certificate_valid requires at least one certificate property")
+		}
+		for key := range rule.Certificate.Properties {
+			if !certificateProperties[key] {
+				return fmt.Errorf("unknown certificate property: %s", key)
+			}
+		}
+
+	case "helm_release":
+		if rule.Helm == nil || rule.Helm.ReleaseName == "" {
+			return fmt.Errorf("helm_release requires helm.releaseName")
+		}
+		if rule.Condition == "status_equals" && rule.Value == nil {
+			return fmt.Errorf("status_equals requires a value")
+		}
+
+	case "etcd_key_exists":
+		if rule.Etcd == nil || rule.Etcd.Key == "" {
+			return fmt.Errorf("etcd_key_exists requires etcd.key")
+		}
+		if rule.Condition == "value_matches" && rule.Etcd.ValuePattern == "" {
+			return fmt.Errorf("value_matches requires etcd.valuePattern")
+		}
+
+	case "rbac_allowed":
+		if rule.RBAC == nil || rule.RBAC.Verb == "" || rule.RBAC.Resource == "" || rule.RBAC.ServiceAccount == "" {
+			return fmt.Errorf("rbac_allowed requires rbac.verb, rbac.resource and rbac.serviceAccount")
+		}
+
+	case "network_policy_effective":
+		np := rule.NetworkPolicy
+		if np == nil || np.SourceNamespace == "" || np.SourcePod == "" || np.DestIP == "" {
+			return fmt.Errorf("network_policy_effective requires networkPolicy.sourceNamespace, networkPolicy.sourcePod and networkPolicy.destIP")
+		}
+		if np.DestPort <= 0 || np.DestPort > 65535 {
+			return fmt.Errorf("networkPolicy.destPort must be between 1 and 65535")
+		}
+		if np.Protocol != "" && !strings.EqualFold(np.Protocol, "tcp") && !strings.EqualFold(np.Protocol, "udp") {
+			return fmt.Errorf("networkPolicy.protocol must be tcp or udp")
+		}
+
+	case "configmap_data":
+		if rule.ConfigMap == nil || rule.ConfigMap.Name == "" || rule.ConfigMap.Key == "" {
+			return fmt.Errorf("configmap_data requires configMap.name and configMap.key")
+		}
+		if rule.Condition != "keys_exist" && rule.Value == nil {
+			return fmt.Errorf("%s requires a value", rule.Condition)
+		}
+		if rule.Condition == "matches" {
+			if _, err := regexp.Compile(fmt.Sprintf("%v", rule.Value)); err != nil {
+				return fmt.Errorf("invalid pattern %v: %w", rule.Value, err)
+			}
+		}
+
+	case "opa_constraint":
+		if rule.OPA == nil || rule.OPA.ConstraintKind == "" || rule.OPA.ConstraintName == "" {
+			return fmt.Errorf("opa_constraint requires opa.constraintKind and opa.constraintName")
+		}
+		switch rule.OPA.ViolationCountCondition {
+		case "zero", "nonzero", "gte":
+		default:
+			return fmt.Errorf("invalid opa.violationCountCondition %q (expected one of [zero nonzero gte])", rule.OPA.ViolationCountCondition)
+		}
+		if rule.OPA.ViolationCountValue < 0 {
+			return fmt.Errorf("opa.violationCountValue must not be negative")
+		}
+
+	case "service_account_token":
+		if rule.ServiceAccount == nil || rule.ServiceAccount.PodName == "" {
+			return fmt.Errorf("service_account_token requires serviceAccount.podName")
+		}
+
+	case "pod_security_admission":
+		if rule.PodSecurity == nil || rule.PodSecurity.Namespace == "" {
+			return fmt.Errorf("pod_security_admission requires podSecurity.namespace")
+		}
+		if !containsString(psaLevels, rule.PodSecurity.Level) {
+			return fmt.Errorf("podSecurity.level must be one of %v, got %q", psaLevels, rule.PodSecurity.Level)
+		}
+		if !containsString(psaModes, rule.PodSecurity.Mode) {
+			return fmt.Errorf("podSecurity.mode must be one of %v, got %q", psaModes, rule.PodSecurity.Mode)
+		}
+	}
+
+	if rule.TimeoutSeconds < 0 {
+		return fmt.Errorf("timeoutSeconds must not be negative")
+	}
+	if rule.RetryCount < 0 || rule.RetryIntervalSeconds < 0 {
+		return fmt.Errorf("retryCount and retryIntervalSeconds must not be negative")
+	}
+
+	return nil
+}
@@ -2,18 +2,27 @@ package validation
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/fullstack-pw/cks/backend/internal/kubevirt"
 	"github.com/fullstack-pw/cks/backend/internal/models"
+	"github.com/fullstack-pw/cks/backend/internal/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// defaultRetryInterval is the pause between attempts of a rule with RetryCount set
+const defaultRetryInterval = 5 * time.Second
+
 // UnifiedValidator handles all validation logic in a single, clean interface
 type UnifiedValidator struct {
-	kubevirtClient *kubevirt.Client
+	kubevirtClient kubevirt.VMExecutor
 	logger         *logrus.Logger
 }
 
@@ -45,7 +54,7 @@ type ValidationResult struct {
 }
 
 // NewUnifiedValidator creates a new validation service
-func NewUnifiedValidator(kubevirtClient *kubevirt.Client, logger *logrus.Logger) *UnifiedValidator {
+func NewUnifiedValidator(kubevirtClient kubevirt.VMExecutor, logger *logrus.Logger) *UnifiedValidator {
 	return &UnifiedValidator{
 		kubevirtClient: kubevirtClient,
 		logger:         logger,
@@ -54,6 +63,19 @@ func NewUnifiedValidator(kubevirtClient *kubevirt.Client, logger *logrus.Logger)
 
 // ValidateTask performs all validations for a task and returns clean results
 func (uv *UnifiedValidator) ValidateTask(ctx context.Context, session *models.Session, rules []models.ValidationRule) (*ValidationResponse, error) {
+	ctx, span := tracing.Start(ctx, "UnifiedValidator.ValidateTask",
+		attribute.String("session.id", session.ID),
+		attribute.Int("validation.rules", len(rules)),
+	)
+	response, err := uv.validateTask(ctx, session, rules)
+	if response != nil {
+		span.SetAttributes(attribute.Bool("validation.success", response.Success))
+	}
+	tracing.End(span, err)
+	return response, err
+}
+
+func (uv *UnifiedValidator) validateTask(ctx context.Context, session *models.Session, rules []models.ValidationRule) (*ValidationResponse, error) {
 	response := &ValidationResponse{
 		Success:   true,
 		Message:   "All validations passed",
@@ -88,6 +110,74 @@ func (uv *UnifiedValidator) ValidateTask(ctx context.Context, session *models.Se
 
 // validateRule processes a single validation rule with clean error handling
 func (uv *UnifiedValidator) validateRule(ctx context.Context, session *models.Session, rule models.ValidationRule) ValidationResult {
+	var result ValidationResult
+
+	uv.logger.WithFields(logrus.Fields{
+		"ruleID":   rule.ID,
+		"ruleType": rule.Type,
+		"session":  session.ID,
+	}).Debug("Processing validation rule")
+
+	attempts := 1
+	if rule.RetryCount > 0 {
+		attempts += rule.RetryCount
+	}
+	retryInterval := defaultRetryInterval
+	if rule.RetryIntervalSeconds > 0 {
+		retryInterval = time.Duration(rule.RetryIntervalSeconds) * time.Second
+	}
+
+	attempt := 1
+	for ; ; attempt++ {
+		result = uv.runRule(ctx, session, rule)
+		if result.Passed || attempt >= attempts {
+			break
+		}
+
+		uv.logger.WithFields(logrus.Fields{
+			"ruleID":  rule.ID,
+			"attempt": attempt,
+			"message": result.Message,
+		}).Debug("Rule failed, retrying")
+
+		// Stop retrying once the caller's deadline has passed
+		if !waitForRetry(ctx, retryInterval) {
+			break
+		}
+	}
+
+	if attempts > 1 {
+		outcome := "failed"
+		if result.Passed {
+			outcome = "passed"
+		}
+		result.Message = fmt.Sprintf("%s (%s on attempt %d of %d)", result.Message, outcome, attempt, attempts)
+	}
+
+	uv.logger.WithFields(logrus.Fields{
+		"ruleID":  rule.ID,
+		"passed":  result.Passed,
+		"message": result.Message,
+	}).Debug("Rule validation completed")
+
+	return result
+}
+
+// waitForRetry sleeps for the retry interval and reports whether the context is still live
+func waitForRetry(ctx context.Context, interval time.Duration) bool {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// runRule runs a single attempt of a validation rule
+func (uv *UnifiedValidator) runRule(ctx context.Context, session *models.Session, rule models.ValidationRule) ValidationResult {
 	result := ValidationResult{
 		RuleID:      rule.ID,
 		RuleType:    rule.Type,
@@ -95,16 +185,19 @@ func (uv *UnifiedValidator) validateRule(ctx context.Context, session *models.Se
 		Description: rule.Description,
 	}
 
-	uv.logger.WithFields(logrus.Fields{
-		"ruleID":   rule.ID,
-		"ruleType": rule.Type,
-		"session":  session.ID,
-	}).Debug("Processing validation rule")
+	// Apply the per-rule deadline; the caller's context remains the hard ceiling
+	if rule.TimeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(rule.TimeoutSeconds)*time.Second)
+		defer cancel()
+	}
 
 	// Route to appropriate validator based on rule type
 	switch rule.Type {
 	case "resource_exists":
 		uv.validateResourceExists(ctx, session, rule, &result)
+	case "resource_count":
+		uv.validateResourceCount(ctx, session, rule, &result)
 	case "command":
 		uv.validateCommand(ctx, session, rule, &result)
 	case "script":
@@ -113,16 +206,36 @@ func (uv *UnifiedValidator) validateRule(ctx context.Context, session *models.Se
 		uv.validateFileExists(ctx, session, rule, &result)
 	case "file_content":
 		uv.validateFileContent(ctx, session, rule, &result)
+	case "admission_controller":
+		uv.validateAdmissionController(ctx, session, rule, &result)
+	case "certificate_valid":
+		uv.validateCertificate(ctx, session, rule, &result)
+	case "helm_release":
+		uv.validateHelmRelease(ctx, session, rule, &result)
+	case "etcd_key_exists":
+		uv.validateEtcdKey(ctx, session, rule, &result)
+	case "rbac_allowed":
+		uv.validateRBACAllowed(ctx, session, rule, &result)
+	case "network_policy_effective":
+		uv.validateNetworkPolicyEffective(ctx, session, rule, &result)
+	case "configmap_data":
+		uv.validateConfigMapData(ctx, session, rule, &result)
+	case "opa_constraint":
+		uv.validateOPAConstraint(ctx, session, rule, &result)
+	case "service_account_token":
+		uv.validateServiceAccountToken(ctx, session, rule, &result)
+	case "pod_security_admission":
+		uv.validatePodSecurityAdmission(ctx, session, rule, &result)
 	default:
 		result.Message = fmt.Sprintf("Unknown validation type: %s", rule.Type)
 		result.ErrorCode = "UNKNOWN_VALIDATION_TYPE"
 	}
 
-	uv.logger.WithFields(logrus.Fields{
-		"ruleID":  rule.ID,
-		"passed":  result.Passed,
-		"message": result.Message,
-	}).Debug("Rule validation completed")
+	if rule.TimeoutSeconds > 0 && ctx.Err() == context.DeadlineExceeded {
+		result.Passed = false
+		result.Message = fmt.Sprintf("validation timed out after %ds", rule.TimeoutSeconds)
+		result.ErrorCode = "VALIDATION_TIMEOUT"
+	}
 
 	return result
 }
@@ -141,9 +254,9 @@ func (uv *UnifiedValidator) validateResourceExists(ctx context.Context, session
 	}
 
 	cmd := fmt.Sprintf("kubectl get %s %s -n %s",
-		strings.ToLower(rule.Resource.Kind),
-		rule.Resource.Name,
-		namespace)
+		shellQuote(strings.ToLower(rule.Resource.Kind)),
+		shellQuote(rule.Resource.Name),
+		shellQuote(namespace))
 
 	output, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, cmd, false)
 
@@ -161,6 +274,72 @@ func (uv *UnifiedValidator) validateResourceExists(ctx context.Context, session
 		rule.Resource.Kind, rule.Resource.Name, namespace)
 }
 
+// validateResourceCount counts Kubernetes resources and compares the count against the expected value
+func (uv *UnifiedValidator) validateResourceCount(ctx context.Context, session *models.Session, rule models.ValidationRule, result *ValidationResult) {
+	if rule.ResourceCount == nil {
+		result.Message = "Resource count specification is missing"
+		result.ErrorCode = "MISSING_RESOURCE_COUNT_SPEC"
+		return
+	}
+
+	expectedCount, err := strconv.Atoi(strings.TrimSpace(fmt.Sprintf("%v", rule.Value)))
+	if err != nil {
+		result.Message = fmt.Sprintf("Invalid expected count: %v", rule.Value)
+		result.ErrorCode = "INVALID_EXPECTED_VALUE"
+		return
+	}
+
+	namespace := rule.ResourceCount.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	cmd := fmt.Sprintf("kubectl get %s -n %s", shellQuote(strings.ToLower(rule.ResourceCount.Kind)), shellQuote(namespace))
+	if rule.ResourceCount.LabelSelector != "" {
+		cmd += " -l " + shellQuote(rule.ResourceCount.LabelSelector)
+	}
+	cmd += " --no-headers 2>/dev/null | wc -l"
+
+	output, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, cmd, false)
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to count %s resources: %v", rule.ResourceCount.Kind, err)
+		result.ErrorCode = "COMMAND_FAILED"
+		return
+	}
+
+	actualCount, err := strconv.Atoi(strings.TrimSpace(output))
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to parse resource count: %v", err)
+		result.ErrorCode = "INVALID_COUNT_OUTPUT"
+		return
+	}
+
+	result.Actual = actualCount
+
+	switch rule.Condition {
+	case "equals":
+		result.Expected = expectedCount
+		result.Passed = actualCount == expectedCount
+	case "gte":
+		result.Expected = fmt.Sprintf(">= %d", expectedCount)
+		result.Passed = actualCount >= expectedCount
+	case "lte":
+		result.Expected = fmt.Sprintf("<= %d", expectedCount)
+		result.Passed = actualCount <= expectedCount
+	default:
+		result.Message = fmt.Sprintf("Unknown condition: %s", rule.Condition)
+		result.ErrorCode = "UNKNOWN_CONDITION"
+		return
+	}
+
+	if result.Passed {
+		result.Message = fmt.Sprintf("Found %d %s resources in namespace '%s'", actualCount, rule.ResourceCount.Kind, namespace)
+	} else {
+		result.Message = fmt.Sprintf("Expected %v %s resources in namespace '%s', found %d", result.Expected, rule.ResourceCount.Kind, namespace, actualCount)
+		result.ErrorCode = "RESOURCE_COUNT_MISMATCH"
+	}
+}
+
 // validateCommand executes a command and validates the result
 func (uv *UnifiedValidator) validateCommand(ctx context.Context, session *models.Session, rule models.ValidationRule, result *ValidationResult) {
 	if rule.Command == nil {
@@ -200,12 +379,85 @@ func (uv *UnifiedValidator) validateCommand(ctx context.Context, session *models
 			result.ErrorCode = "OUTPUT_MISMATCH"
 		}
 
+	case "output_empty":
+		result.Expected = "Command should succeed with no output"
+
+		if err != nil {
+			result.Message = "Command execution failed"
+			result.ErrorCode = "COMMAND_FAILED"
+		} else if strings.TrimSpace(output) == "" {
+			result.Passed = true
+			result.Message = "Command produced no output"
+		} else {
+			result.Message = fmt.Sprintf("Expected no output, got '%s'", strings.TrimSpace(output))
+			result.ErrorCode = "OUTPUT_NOT_EMPTY"
+		}
+
+	case "output_not_empty":
+		result.Expected = "Command should succeed with output"
+
+		if err != nil {
+			result.Message = "Command execution failed"
+			result.ErrorCode = "COMMAND_FAILED"
+		} else if strings.TrimSpace(output) != "" {
+			result.Passed = true
+			result.Message = "Command produced output"
+		} else {
+			result.Message = "Expected output, but command produced none"
+			result.ErrorCode = "OUTPUT_EMPTY"
+		}
+
+	case "output_line_count_equals", "output_line_count_gte", "output_line_count_lte":
+		expectedLines, convErr := strconv.Atoi(strings.TrimSpace(fmt.Sprintf("%v", rule.Value)))
+		if convErr != nil {
+			result.Message = fmt.Sprintf("Invalid expected line count: %v", rule.Value)
+			result.ErrorCode = "INVALID_EXPECTED_VALUE"
+			return
+		}
+
+		actualLines := countNonEmptyLines(output)
+		result.Expected = fmt.Sprintf("%d lines", expectedLines)
+		result.Actual = fmt.Sprintf("%d lines", actualLines)
+
+		switch rule.Condition {
+		case "output_line_count_equals":
+			result.Passed = actualLines == expectedLines
+		case "output_line_count_gte":
+			result.Expected = fmt.Sprintf(">= %d lines", expectedLines)
+			result.Passed = actualLines >= expectedLines
+		case "output_line_count_lte":
+			result.Expected = fmt.Sprintf("<= %d lines", expectedLines)
+			result.Passed = actualLines <= expectedLines
+		}
+
+		if err != nil {
+			result.Passed = false
+			result.Message = "Command execution failed"
+			result.ErrorCode = "COMMAND_FAILED"
+		} else if result.Passed {
+			result.Message = fmt.Sprintf("Command produced %d lines of output", actualLines)
+		} else {
+			result.Message = fmt.Sprintf("Expected %v of output, got %d lines", result.Expected, actualLines)
+			result.ErrorCode = "LINE_COUNT_MISMATCH"
+		}
+
 	default:
 		result.Message = fmt.Sprintf("Unknown condition: %s", rule.Condition)
 		result.ErrorCode = "UNKNOWN_CONDITION"
 	}
 }
 
+// countNonEmptyLines returns the number of lines in output that are not blank
+func countNonEmptyLines(output string) int {
+	count := 0
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count
+}
+
 // validateScript executes a script and validates the result
 func (uv *UnifiedValidator) validateScript(ctx context.Context, session *models.Session, rule models.ValidationRule, result *ValidationResult) {
 	if rule.Script == nil {
@@ -294,7 +546,7 @@ func (uv *UnifiedValidator) validateFileExists(ctx context.Context, session *mod
 	}
 
 	// Check if file exists
-	cmd := fmt.Sprintf("test -f %s", rule.File.Path)
+	cmd := fmt.Sprintf("test -f %s", shellQuote(rule.File.Path))
 	_, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, target, cmd, false)
 
 	result.Expected = "File should exist"
@@ -324,7 +576,7 @@ func (uv *UnifiedValidator) validateFileContent(ctx context.Context, session *mo
 	}
 
 	// Get file content
-	cmd := fmt.Sprintf("cat %s", rule.File.Path)
+	cmd := fmt.Sprintf("cat %s", shellQuote(rule.File.Path))
 	output, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, target, cmd, false)
 
 	if err != nil {
@@ -349,8 +601,998 @@ func (uv *UnifiedValidator) validateFileContent(ctx context.Context, session *mo
 			result.ErrorCode = "CONTENT_NOT_FOUND"
 		}
 
+	case "output_empty":
+		result.Expected = "File should be empty"
+
+		if strings.TrimSpace(output) == "" {
+			result.Passed = true
+			result.Message = fmt.Sprintf("File %s is empty", rule.File.Path)
+		} else {
+			result.Message = fmt.Sprintf("File %s is not empty", rule.File.Path)
+			result.ErrorCode = "OUTPUT_NOT_EMPTY"
+		}
+
+	case "output_not_empty":
+		result.Expected = "File should not be empty"
+
+		if strings.TrimSpace(output) != "" {
+			result.Passed = true
+			result.Message = fmt.Sprintf("File %s has content", rule.File.Path)
+		} else {
+			result.Message = fmt.Sprintf("File %s is empty", rule.File.Path)
+			result.ErrorCode = "OUTPUT_EMPTY"
+		}
+
+	default:
+		result.Message = fmt.Sprintf("Unknown condition: %s", rule.Condition)
+		result.ErrorCode = "UNKNOWN_CONDITION"
+	}
+}
+
+// validateCertificate inspects an X.509 certificate on the target VM and checks its properties
+func (uv *UnifiedValidator) validateCertificate(ctx context.Context, session *models.Session, rule models.ValidationRule, result *ValidationResult) {
+	if rule.Certificate == nil || rule.Certificate.Path == "" {
+		result.Message = "Certificate specification is missing"
+		result.ErrorCode = "MISSING_CERTIFICATE_SPEC"
+		return
+	}
+
+	if rule.Condition != "contains" && rule.Condition != "matches" {
+		result.Message = fmt.Sprintf("Unknown condition: %s", rule.Condition)
+		result.ErrorCode = "UNKNOWN_CONDITION"
+		return
+	}
+
+	// Determine target VM
+	target := session.ControlPlaneVM
+	if rule.Certificate.Target == "worker" {
+		target = session.WorkerNodeVM
+	}
+
+	cmd := fmt.Sprintf("sudo openssl x509 -in %s -noout -text", shellQuote(rule.Certificate.Path))
+	output, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, target, cmd, false)
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to read certificate %s: %v", rule.Certificate.Path, err)
+		result.ErrorCode = "CERTIFICATE_READ_FAILED"
+		return
+	}
+
+	actual := parseCertificateText(output)
+	result.Actual = actual
+	result.Expected = rule.Certificate.Properties
+
+	// Check properties in a stable order so failure messages are deterministic
+	keys := make([]string, 0, len(rule.Certificate.Properties))
+	for key := range rule.Certificate.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var failures []string
+	for _, key := range keys {
+		expected := rule.Certificate.Properties[key]
+
+		if key == "not_after_days" {
+			// not_after_days is the minimum number of days the certificate must remain valid
+			minDays, err := strconv.Atoi(expected)
+			if err != nil {
+				result.Message = fmt.Sprintf("Invalid not_after_days value: %s", expected)
+				result.ErrorCode = "INVALID_EXPECTED_VALUE"
+				return
+			}
+
+			remainingDays, err := strconv.Atoi(actual["not_after_days"])
+			if err != nil || remainingDays < minDays {
+				failures = append(failures, fmt.Sprintf("certificate expires in %s days, expected at least %d", actual["not_after_days"], minDays))
+			}
+			continue
+		}
+
+		value, known := actual[key]
+		if !known {
+			result.Message = fmt.Sprintf("Unknown certificate property: %s", key)
+			result.ErrorCode = "UNKNOWN_CERTIFICATE_PROPERTY"
+			return
+		}
+
+		matched := strings.Contains(value, expected)
+		verb := "contain"
+		if rule.Condition == "matches" {
+			verb = "match"
+			re, err := regexp.Compile(expected)
+			if err != nil {
+				result.Message = fmt.Sprintf("Invalid pattern for %s: %v", key, err)
+				result.ErrorCode = "INVALID_PATTERN"
+				return
+			}
+			matched = re.MatchString(value)
+		}
+
+		if !matched {
+			failures = append(failures, fmt.Sprintf("%s '%s' does not %s '%s'", key, value, verb, expected))
+		}
+	}
+
+	if len(failures) > 0 {
+		result.Message = fmt.Sprintf("Certificate %s: %s", rule.Certificate.Path, strings.Join(failures, "; "))
+		result.ErrorCode = "CERTIFICATE_PROPERTY_MISMATCH"
+		return
+	}
+
+	result.Passed = true
+	result.Message = fmt.Sprintf("Certificate %s has the expected properties", rule.Certificate.Path)
+}
+
+// parseCertificateText extracts issuer, subject, SANs and days until expiry from `openssl x509 -text` output
+func parseCertificateText(output string) map[string]string {
+	properties := map[string]string{
+		"issuer":         "",
+		"subject":        "",
+		"san":            "",
+		"not_after_days": "",
+	}
+
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, "Issuer:"):
+			properties["issuer"] = strings.TrimSpace(strings.TrimPrefix(line, "Issuer:"))
+		case strings.HasPrefix(line, "Subject:"):
+			properties["subject"] = strings.TrimSpace(strings.TrimPrefix(line, "Subject:"))
+		case strings.HasPrefix(line, "Not After"):
+			_, value, _ := strings.Cut(line, ":")
+			notAfter, err := time.Parse("Jan _2 15:04:05 2006 MST", strings.Join(strings.Fields(value), " "))
+			if err == nil {
+				properties["not_after_days"] = strconv.Itoa(int(time.Until(notAfter).Hours() / 24))
+			}
+		case strings.HasPrefix(line, "X509v3 Subject Alternative Name") && i+1 < len(lines):
+			properties["san"] = strings.TrimSpace(lines[i+1])
+		}
+	}
+
+	return properties
+}
+
+// validateRBACAllowed checks whether a service account may perform an action using kubectl auth can-i
+func (uv *UnifiedValidator) validateRBACAllowed(ctx context.Context, session *models.Session, rule models.ValidationRule, result *ValidationResult) {
+	if rule.RBAC == nil || rule.RBAC.Verb == "" || rule.RBAC.Resource == "" || rule.RBAC.ServiceAccount == "" {
+		result.Message = "RBAC specification is missing"
+		result.ErrorCode = "MISSING_RBAC_SPEC"
+		return
+	}
+
+	namespace := rule.RBAC.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	saNamespace := rule.RBAC.ServiceAccountNamespace
+	if saNamespace == "" {
+		saNamespace = namespace
+	}
+	subject := fmt.Sprintf("system:serviceaccount:%s:%s", saNamespace, rule.RBAC.ServiceAccount)
+
+	// can-i prints "yes" and exits 0 when the action is allowed, and prints "no" and exits 1
+	// when it is denied; the last line is the exit code, so a denial is not a command failure
+	cmd := fmt.Sprintf("kubectl auth can-i %s %s -n %s --as=%s 2>&1; echo $?",
+		shellQuote(rule.RBAC.Verb), shellQuote(rule.RBAC.Resource), shellQuote(namespace), shellQuote(subject))
+	output, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, cmd, false)
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to check permissions: %v", err)
+		result.ErrorCode = "COMMAND_FAILED"
+		return
+	}
+	answer, exitCode, err := splitExitCode(output)
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to parse exit code: %v", err)
+		result.ErrorCode = "INVALID_EXIT_CODE"
+		return
+	}
+	result.Actual = answer
+
+	allowed := exitCode == 0 && answer == "yes"
+	denied := exitCode == 1 && answer == "no"
+	if !allowed && !denied {
+		result.Message = fmt.Sprintf("Failed to check permissions: kubectl auth can-i exited with %d: %s", exitCode, answer)
+		result.ErrorCode = "COMMAND_FAILED"
+		return
+	}
+
+	action := fmt.Sprintf("%s %s in namespace %s", rule.RBAC.Verb, rule.RBAC.Resource, namespace)
+
+	switch rule.Condition {
+	case "allowed":
+		result.Expected = "yes"
+		if allowed {
+			result.Passed = true
+			result.Message = fmt.Sprintf("%s can %s", subject, action)
+		} else {
+			result.Message = fmt.Sprintf("%s cannot %s", subject, action)
+			result.ErrorCode = "RBAC_DENIED"
+		}
+
+	case "denied":
+		result.Expected = "no"
+		if allowed {
+			result.Message = fmt.Sprintf("%s can %s", subject, action)
+			result.ErrorCode = "RBAC_ALLOWED"
+		} else {
+			result.Passed = true
+			result.Message = fmt.Sprintf("%s cannot %s", subject, action)
+		}
+
+	default:
+		result.Message = fmt.Sprintf("Unknown condition: %s", rule.Condition)
+		result.ErrorCode = "UNKNOWN_CONDITION"
+	}
+}
+
+// validateNetworkPolicyEffective attempts a connection with nc from a source pod and checks
+// whether it succeeds or is blocked
+func (uv *UnifiedValidator) validateNetworkPolicyEffective(ctx context.Context, session *models.Session, rule models.ValidationRule, result *ValidationResult) {
+	target := rule.NetworkPolicy
+	if target == nil || target.SourceNamespace == "" || target.SourcePod == "" || target.DestIP == "" || target.DestPort <= 0 {
+		result.Message = "Network policy specification is missing"
+		result.ErrorCode = "MISSING_NETWORK_POLICY_SPEC"
+		return
+	}
+
+	// The source pod must exist and be running for the connection attempt to mean anything
+	podCmd := fmt.Sprintf("kubectl get pod %s -n %s -o jsonpath='{.status.phase}'", shellQuote(target.SourcePod), shellQuote(target.SourceNamespace))
+	phase, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, podCmd, false)
+	if err != nil {
+		result.Message = fmt.Sprintf("Source pod %s/%s not found", target.SourceNamespace, target.SourcePod)
+		result.ErrorCode = "SOURCE_POD_NOT_FOUND"
+		return
+	}
+	if phase = strings.TrimSpace(phase); phase != "Running" {
+		result.Message = fmt.Sprintf("Source pod %s/%s is %s, not Running", target.SourceNamespace, target.SourcePod, phase)
+		result.ErrorCode = "SOURCE_POD_NOT_RUNNING"
+		return
+	}
+
+	ncFlags := "-z -w2"
+	if strings.EqualFold(target.Protocol, "udp") {
+		ncFlags = "-z -u -w2"
+	}
+
+	cmd := fmt.Sprintf("kubectl exec -n %s %s -- nc %s %s %d 2>&1; echo $?",
+		shellQuote(target.SourceNamespace), shellQuote(target.SourcePod), ncFlags, shellQuote(target.DestIP), target.DestPort)
+	output, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, cmd, false)
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to run connection test: %v", err)
+		result.ErrorCode = "COMMAND_FAILED"
+		return
+	}
+
+	// The last line is the exit code of kubectl exec, which is the exit code of nc
+	ncOutput, exitCode, err := splitExitCode(output)
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to parse exit code: %v", err)
+		result.ErrorCode = "INVALID_EXIT_CODE"
+		return
+	}
+	result.Actual = ncOutput
+
+	// 126 and 127 mean nc could not be run, which says nothing about the policy
+	if exitCode == 126 || exitCode == 127 {
+		result.Message = fmt.Sprintf("nc is not available in pod %s/%s", target.SourceNamespace, target.SourcePod)
+		result.ErrorCode = "NC_NOT_AVAILABLE"
+		return
+	}
+
+	destination := fmt.Sprintf("%s:%d", target.DestIP, target.DestPort)
+	connected := exitCode == 0
+
+	switch rule.Condition {
+	case "allowed":
+		result.Expected = "connection succeeds"
+		if connected {
+			result.Passed = true
+			result.Message = fmt.Sprintf("Connection to %s is allowed", destination)
+		} else {
+			result.Message = fmt.Sprintf("Connection to %s is blocked", destination)
+			result.ErrorCode = "CONNECTION_BLOCKED"
+		}
+
+	case "blocked":
+		result.Expected = "connection fails"
+		if connected {
+			result.Message = fmt.Sprintf("Connection to %s is allowed", destination)
+			result.ErrorCode = "CONNECTION_ALLOWED"
+		} else {
+			result.Passed = true
+			result.Message = fmt.Sprintf("Connection to %s is blocked", destination)
+		}
+
 	default:
 		result.Message = fmt.Sprintf("Unknown condition: %s", rule.Condition)
 		result.ErrorCode = "UNKNOWN_CONDITION"
 	}
 }
+
+// validateConfigMapData checks the value of a ConfigMap key with the equals, contains, matches
+// and not_contains conditions, or only that the key is present with keys_exist
+func (uv *UnifiedValidator) validateConfigMapData(ctx context.Context, session *models.Session, rule models.ValidationRule, result *ValidationResult) {
+	if rule.ConfigMap == nil || rule.ConfigMap.Name == "" || rule.ConfigMap.Key == "" {
+		result.Message = "ConfigMap specification is missing"
+		result.ErrorCode = "MISSING_CONFIGMAP_SPEC"
+		return
+	}
+
+	namespace := rule.ConfigMap.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	configMap := fmt.Sprintf("%s/%s", namespace, rule.ConfigMap.Name)
+
+	// jsonpath cannot tell an empty value from a missing key, so keys_exist reads the whole ConfigMap
+	var cmd string
+	if rule.Condition == "keys_exist" {
+		cmd = fmt.Sprintf("kubectl get configmap %s -n %s -o json", shellQuote(rule.ConfigMap.Name), shellQuote(namespace))
+	} else {
+		// The key is part of the jsonpath expression, so the whole expression is quoted
+		jsonPath := fmt.Sprintf("jsonpath={.data.%s}", strings.ReplaceAll(rule.ConfigMap.Key, ".", `\.`))
+		cmd = fmt.Sprintf("kubectl get configmap %s -n %s -o %s", shellQuote(rule.ConfigMap.Name), shellQuote(namespace), shellQuote(jsonPath))
+	}
+
+	output, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, cmd, false)
+	if err != nil {
+		if strings.Contains(output, "NotFound") || strings.Contains(err.Error(), "NotFound") {
+			result.Message = fmt.Sprintf("ConfigMap %s does not exist", configMap)
+			result.ErrorCode = "CONFIGMAP_NOT_FOUND"
+			return
+		}
+		result.Message = fmt.Sprintf("Failed to read ConfigMap %s: %v", configMap, err)
+		result.ErrorCode = "COMMAND_FAILED"
+		return
+	}
+
+	if rule.Condition == "keys_exist" {
+		var object struct {
+			Data map[string]string `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(output), &object); err != nil {
+			result.Message = fmt.Sprintf("Failed to parse ConfigMap %s: %v", configMap, err)
+			result.ErrorCode = "PARSE_FAILED"
+			return
+		}
+
+		_, exists := object.Data[rule.ConfigMap.Key]
+		result.Expected = fmt.Sprintf("Key %s should exist", rule.ConfigMap.Key)
+		result.Actual = exists
+		if exists {
+			result.Passed = true
+			result.Message = fmt.Sprintf("ConfigMap %s has key %s", configMap, rule.ConfigMap.Key)
+		} else {
+			result.Message = fmt.Sprintf("ConfigMap %s has no key %s", configMap, rule.ConfigMap.Key)
+			result.ErrorCode = "CONFIGMAP_KEY_NOT_FOUND"
+		}
+		return
+	}
+
+	value := output
+	expectedValue := fmt.Sprintf("%v", rule.Value)
+	result.Actual = value
+
+	switch rule.Condition {
+	case "equals":
+		result.Expected = expectedValue
+		if strings.TrimSpace(value) == strings.TrimSpace(expectedValue) {
+			result.Passed = true
+			result.Message = fmt.Sprintf("ConfigMap %s key %s has the expected value", configMap, rule.ConfigMap.Key)
+		} else {
+			result.Message = fmt.Sprintf("ConfigMap %s key %s does not equal '%s'", configMap, rule.ConfigMap.Key, expectedValue)
+			result.ErrorCode = "CONFIGMAP_VALUE_MISMATCH"
+		}
+
+	case "contains":
+		result.Expected = fmt.Sprintf("Value should contain '%s'", expectedValue)
+		if strings.Contains(value, expectedValue) {
+			result.Passed = true
+			result.Message = fmt.Sprintf("ConfigMap %s key %s contains '%s'", configMap, rule.ConfigMap.Key, expectedValue)
+		} else {
+			result.Message = fmt.Sprintf("ConfigMap %s key %s does not contain '%s'", configMap, rule.ConfigMap.Key, expectedValue)
+			result.ErrorCode = "CONTENT_NOT_FOUND"
+		}
+
+	case "not_contains":
+		result.Expected = fmt.Sprintf("Value should not contain '%s'", expectedValue)
+		if strings.Contains(value, expectedValue) {
+			result.Message = fmt.Sprintf("ConfigMap %s key %s contains '%s'", configMap, rule.ConfigMap.Key, expectedValue)
+			result.ErrorCode = "CONTENT_FOUND"
+		} else {
+			result.Passed = true
+			result.Message = fmt.Sprintf("ConfigMap %s key %s does not contain '%s'", configMap, rule.ConfigMap.Key, expectedValue)
+		}
+
+	case "matches":
+		result.Expected = fmt.Sprintf("Value should match '%s'", expectedValue)
+		pattern, err := regexp.Compile(expectedValue)
+		if err != nil {
+			result.Message = fmt.Sprintf("Invalid pattern: %v", err)
+			result.ErrorCode = "INVALID_PATTERN"
+			return
+		}
+		if pattern.MatchString(value) {
+			result.Passed = true
+			result.Message = fmt.Sprintf("ConfigMap %s key %s matches '%s'", configMap, rule.ConfigMap.Key, expectedValue)
+		} else {
+			result.Message = fmt.Sprintf("ConfigMap %s key %s does not match '%s'", configMap, rule.ConfigMap.Key, expectedValue)
+			result.ErrorCode = "CONFIGMAP_VALUE_MISMATCH"
+		}
+
+	default:
+		result.Message = fmt.Sprintf("Unknown condition: %s", rule.Condition)
+		result.ErrorCode = "UNKNOWN_CONDITION"
+	}
+}
+
+// validateOPAConstraint compares the number of audit violations Gatekeeper reports for a
+// constraint with the zero, nonzero or gte (at least ViolationCountValue) condition
+func (uv *UnifiedValidator) validateOPAConstraint(ctx context.Context, session *models.Session, rule models.ValidationRule, result *ValidationResult) {
+	opa := rule.OPA
+	if opa == nil || opa.ConstraintKind == "" || opa.ConstraintName == "" {
+		result.Message = "OPA constraint specification is missing"
+		result.ErrorCode = "MISSING_OPA_SPEC"
+		return
+	}
+	constraint := fmt.Sprintf("%s/%s", opa.ConstraintKind, opa.ConstraintName)
+
+	// The violation count and the first violation message, one per line
+	cmd := fmt.Sprintf(`kubectl get %s %s -o jsonpath='{.status.totalViolations}{"\n"}{.status.violations[0].message}'`,
+		shellQuote(strings.ToLower(opa.ConstraintKind)), shellQuote(opa.ConstraintName))
+
+	output, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, cmd, false)
+	if err != nil {
+		if strings.Contains(output, "NotFound") || strings.Contains(err.Error(), "NotFound") {
+			result.Message = fmt.Sprintf("Constraint %s does not exist", constraint)
+			result.ErrorCode = "CONSTRAINT_NOT_FOUND"
+			return
+		}
+		result.Message = fmt.Sprintf("Failed to read constraint %s: %v", constraint, err)
+		result.ErrorCode = "COMMAND_FAILED"
+		return
+	}
+
+	countLine, sampleViolation, _ := strings.Cut(output, "\n")
+	countLine = strings.TrimSpace(countLine)
+	if countLine == "" {
+		result.Message = fmt.Sprintf("Constraint %s has not been audited yet", constraint)
+		result.ErrorCode = "CONSTRAINT_NOT_AUDITED"
+		return
+	}
+	violations, err := strconv.Atoi(countLine)
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to parse violation count %q of constraint %s", countLine, constraint)
+		result.ErrorCode = "PARSE_FAILED"
+		return
+	}
+
+	actual := map[string]interface{}{"totalViolations": violations}
+	if sampleViolation = strings.TrimSpace(sampleViolation); sampleViolation != "" {
+		actual["sampleViolation"] = sampleViolation
+	}
+	result.Actual = actual
+
+	switch opa.ViolationCountCondition {
+	case "zero":
+		result.Expected = "No violations"
+		result.Passed = violations == 0
+	case "nonzero":
+		result.Expected = "At least one violation"
+		result.Passed = violations > 0
+	case "gte":
+		result.Expected = fmt.Sprintf("At least %d violations", opa.ViolationCountValue)
+		result.Passed = violations >= opa.ViolationCountValue
+	default:
+		result.Message = fmt.Sprintf("Unknown violation count condition: %s", opa.ViolationCountCondition)
+		result.ErrorCode = "UNKNOWN_CONDITION"
+		return
+	}
+
+	result.Message = fmt.Sprintf("Constraint %s has %d violations", constraint, violations)
+	if !result.Passed {
+		result.ErrorCode = "VIOLATION_COUNT_MISMATCH"
+	}
+}
+
+// serviceAccountTokenDir is where the kubelet mounts the service account token in containers
+const serviceAccountTokenDir = "/var/run/secrets/kubernetes.io/serviceaccount/"
+
+// validateServiceAccountToken checks whether a pod has its service account token mounted with
+// the mounted and not_mounted conditions; without a condition, ExpectMounted decides. Both the
+// pod's automountServiceAccountToken field and the pod's filesystem are checked, since the
+// field may be unset and the service account decide instead.
+func (uv *UnifiedValidator) validateServiceAccountToken(ctx context.Context, session *models.Session, rule models.ValidationRule, result *ValidationResult) {
+	target := rule.ServiceAccount
+	if target == nil || target.PodName == "" {
+		result.Message = "Service account specification is missing"
+		result.ErrorCode = "MISSING_SERVICE_ACCOUNT_SPEC"
+		return
+	}
+	namespace := target.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	pod := fmt.Sprintf("%s/%s", namespace, target.PodName)
+
+	cmd := fmt.Sprintf("kubectl get pod %s -n %s -o jsonpath='{.spec.automountServiceAccountToken}'", shellQuote(target.PodName), shellQuote(namespace))
+	automount, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, cmd, false)
+	if err != nil {
+		result.Message = fmt.Sprintf("Pod %s not found", pod)
+		result.ErrorCode = "POD_NOT_FOUND"
+		return
+	}
+	automount = strings.TrimSpace(automount)
+	if automount == "" {
+		automount = "unset"
+	}
+
+	// The last line is the exit code of kubectl exec, which is the exit code of ls
+	cmd = fmt.Sprintf("kubectl exec -n %s %s -- ls %s 2>&1; echo $?", shellQuote(namespace), shellQuote(target.PodName), serviceAccountTokenDir)
+	output, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, cmd, false)
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to check service account token mount: %v", err)
+		result.ErrorCode = "COMMAND_FAILED"
+		return
+	}
+	lsOutput, exitCode, err := splitExitCode(output)
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to parse exit code: %v", err)
+		result.ErrorCode = "INVALID_EXIT_CODE"
+		return
+	}
+
+	result.Actual = map[string]interface{}{
+		"automountServiceAccountToken": automount,
+		"tokenDirectory":               lsOutput,
+	}
+
+	// 126 and 127 mean ls could not be run, which says nothing about the mount
+	if exitCode == 126 || exitCode == 127 {
+		result.Message = fmt.Sprintf("ls is not available in pod %s", pod)
+		result.ErrorCode = "LS_NOT_AVAILABLE"
+		return
+	}
+	mounted := exitCode == 0 && strings.Contains(lsOutput, "token")
+
+	expectMounted := target.ExpectMounted
+	switch rule.Condition {
+	case "mounted":
+		expectMounted = true
+	case "not_mounted":
+		expectMounted = false
+	case "":
+	default:
+		result.Message = fmt.Sprintf("Unknown condition: %s", rule.Condition)
+		result.ErrorCode = "UNKNOWN_CONDITION"
+		return
+	}
+
+	if expectMounted {
+		result.Expected = "service account token mounted"
+	} else {
+		result.Expected = "service account token not mounted"
+	}
+	result.Passed = mounted == expectMounted
+
+	if mounted {
+		result.Message = fmt.Sprintf("Pod %s has the service account token mounted (automountServiceAccountToken: %s)", pod, automount)
+	} else {
+		result.Message = fmt.Sprintf("Pod %s does not have the service account token mounted (automountServiceAccountToken: %s)", pod, automount)
+	}
+	if !result.Passed {
+		if mounted {
+			result.ErrorCode = "TOKEN_MOUNTED"
+		} else {
+			result.ErrorCode = "TOKEN_NOT_MOUNTED"
+		}
+	}
+}
+
+// validatePodSecurityAdmission checks the pod-security.kubernetes.io/<mode> label of a namespace.
+// configured passes when the label is set to the expected level, not_configured when it is not.
+func (uv *UnifiedValidator) validatePodSecurityAdmission(ctx context.Context, session *models.Session, rule models.ValidationRule, result *ValidationResult) {
+	target := rule.PodSecurity
+	if target == nil || target.Namespace == "" {
+		result.Message = "Pod security specification is missing"
+		result.ErrorCode = "MISSING_POD_SECURITY_SPEC"
+		return
+	}
+
+	cmd := fmt.Sprintf("kubectl get namespace %s -o jsonpath='{.metadata.labels}'", shellQuote(target.Namespace))
+	output, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, cmd, false)
+	if err != nil {
+		result.Message = fmt.Sprintf("Namespace %s not found", target.Namespace)
+		result.ErrorCode = "NAMESPACE_NOT_FOUND"
+		return
+	}
+
+	labels := map[string]string{}
+	if output = strings.TrimSpace(output); output != "" {
+		if err := json.Unmarshal([]byte(output), &labels); err != nil {
+			result.Message = fmt.Sprintf("Failed to parse namespace labels: %v", err)
+			result.ErrorCode = "INVALID_LABELS"
+			return
+		}
+	}
+	result.Actual = labels
+
+	label := "pod-security.kubernetes.io/" + target.Mode
+	level, labelled := labels[label]
+	configured := labelled && level == target.Level
+	result.Expected = fmt.Sprintf("%s=%s", label, target.Level)
+
+	switch rule.Condition {
+	case "configured":
+		result.Passed = configured
+	case "not_configured":
+		result.Passed = !configured
+		result.Expected = fmt.Sprintf("%s not set to %s", label, target.Level)
+	default:
+		result.Message = fmt.Sprintf("Unknown condition: %s", rule.Condition)
+		result.ErrorCode = "UNKNOWN_CONDITION"
+		return
+	}
+
+	switch {
+	case configured:
+		result.Message = fmt.Sprintf("Namespace %s has %s=%s", target.Namespace, label, level)
+	case labelled:
+		result.Message = fmt.Sprintf("Namespace %s has %s=%s, not %s", target.Namespace, label, level, target.Level)
+	default:
+		result.Message = fmt.Sprintf("Namespace %s has no %s label", target.Namespace, label)
+	}
+	if !result.Passed {
+		if configured {
+			result.ErrorCode = "PSA_CONFIGURED"
+		} else {
+			result.ErrorCode = "PSA_NOT_CONFIGURED"
+		}
+	}
+}
+
+// etcdctlGetCommand reads a key from the control plane etcd using the kubeadm health check client certificate
+const etcdctlGetCommand = "sudo ETCDCTL_API=3 etcdctl --endpoints=https://127.0.0.1:2379 " +
+	"--cacert=/etc/kubernetes/pki/etcd/ca.crt " +
+	"--cert=/etc/kubernetes/pki/etcd/healthcheck-client.crt " +
+	"--key=/etc/kubernetes/pki/etcd/healthcheck-client.key get %s"
+
+// validateEtcdKey checks whether an etcd key exists and optionally whether its value matches a pattern
+func (uv *UnifiedValidator) validateEtcdKey(ctx context.Context, session *models.Session, rule models.ValidationRule, result *ValidationResult) {
+	if rule.Etcd == nil || rule.Etcd.Key == "" {
+		result.Message = "Etcd key specification is missing"
+		result.ErrorCode = "MISSING_ETCD_SPEC"
+		return
+	}
+
+	var valuePattern *regexp.Regexp
+	if rule.Etcd.ValuePattern != "" {
+		var err error
+		valuePattern, err = regexp.Compile(rule.Etcd.ValuePattern)
+		if err != nil {
+			result.Message = fmt.Sprintf("Invalid value pattern: %v", err)
+			result.ErrorCode = "INVALID_PATTERN"
+			return
+		}
+	}
+
+	cmd := fmt.Sprintf(etcdctlGetCommand, shellQuote(rule.Etcd.Key))
+	output, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, cmd, false)
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to query etcd: %v", err)
+		result.ErrorCode = "COMMAND_FAILED"
+		return
+	}
+
+	// etcdctl prints the key on the first line and the value after it; nothing when the key is absent
+	exists := strings.TrimSpace(output) != ""
+	value := ""
+	if _, rest, found := strings.Cut(output, "\n"); found {
+		value = rest
+	}
+
+	switch rule.Condition {
+	case "exists", "":
+		result.Expected = fmt.Sprintf("Key %s should exist", rule.Etcd.Key)
+		result.Actual = exists
+
+		if !exists {
+			result.Message = fmt.Sprintf("Etcd key %s does not exist", rule.Etcd.Key)
+			result.ErrorCode = "ETCD_KEY_NOT_FOUND"
+		} else if valuePattern != nil && !valuePattern.MatchString(value) {
+			result.Message = fmt.Sprintf("Etcd key %s exists but its value does not match '%s'", rule.Etcd.Key, rule.Etcd.ValuePattern)
+			result.ErrorCode = "ETCD_VALUE_MISMATCH"
+		} else {
+			result.Passed = true
+			result.Message = fmt.Sprintf("Etcd key %s exists", rule.Etcd.Key)
+		}
+
+	case "not_exists":
+		result.Expected = fmt.Sprintf("Key %s should not exist", rule.Etcd.Key)
+		result.Actual = exists
+
+		if exists {
+			result.Message = fmt.Sprintf("Etcd key %s exists", rule.Etcd.Key)
+			result.ErrorCode = "ETCD_KEY_EXISTS"
+		} else {
+			result.Passed = true
+			result.Message = fmt.Sprintf("Etcd key %s does not exist", rule.Etcd.Key)
+		}
+
+	case "value_matches":
+		if valuePattern == nil {
+			result.Message = "value_matches requires etcd.valuePattern"
+			result.ErrorCode = "MISSING_ETCD_SPEC"
+			return
+		}
+		result.Expected = fmt.Sprintf("Value should match '%s'", rule.Etcd.ValuePattern)
+
+		if !exists {
+			result.Message = fmt.Sprintf("Etcd key %s does not exist", rule.Etcd.Key)
+			result.ErrorCode = "ETCD_KEY_NOT_FOUND"
+		} else if valuePattern.MatchString(value) {
+			result.Passed = true
+			result.Message = fmt.Sprintf("Etcd key %s value matches '%s'", rule.Etcd.Key, rule.Etcd.ValuePattern)
+		} else {
+			result.Message = fmt.Sprintf("Etcd key %s value does not match '%s'", rule.Etcd.Key, rule.Etcd.ValuePattern)
+			result.ErrorCode = "ETCD_VALUE_MISMATCH"
+		}
+
+	default:
+		result.Message = fmt.Sprintf("Unknown condition: %s", rule.Condition)
+		result.ErrorCode = "UNKNOWN_CONDITION"
+	}
+}
+
+// helmRelease is a single entry of `helm list -o json`
+type helmRelease struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Revision   string `json:"revision"`
+	Status     string `json:"status"`
+	Chart      string `json:"chart"` // "<chart name>-<chart version>"
+	AppVersion string `json:"app_version"`
+}
+
+// validateHelmRelease checks that a Helm release is installed with the expected chart, version or status
+func (uv *UnifiedValidator) validateHelmRelease(ctx context.Context, session *models.Session, rule models.ValidationRule, result *ValidationResult) {
+	if rule.Helm == nil || rule.Helm.ReleaseName == "" {
+		result.Message = "Helm release specification is missing"
+		result.ErrorCode = "MISSING_HELM_SPEC"
+		return
+	}
+
+	namespace := rule.Helm.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	cmd := fmt.Sprintf("helm list -n %s -a -o json", shellQuote(namespace))
+	output, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, cmd, false)
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to list Helm releases: %v", err)
+		result.ErrorCode = "COMMAND_FAILED"
+		return
+	}
+
+	var releases []helmRelease
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &releases); err != nil {
+		result.Message = fmt.Sprintf("Failed to parse Helm output: %v", err)
+		result.ErrorCode = "INVALID_HELM_OUTPUT"
+		return
+	}
+
+	var release *helmRelease
+	for i := range releases {
+		if releases[i].Name == rule.Helm.ReleaseName {
+			release = &releases[i]
+			break
+		}
+	}
+
+	if release == nil {
+		result.Message = fmt.Sprintf("Helm release '%s' is not installed in namespace '%s'", rule.Helm.ReleaseName, namespace)
+		result.ErrorCode = "HELM_RELEASE_NOT_FOUND"
+		result.Expected = "Release should be installed"
+		result.Actual = "Release not found"
+		return
+	}
+
+	chartName, chartVersion := splitHelmChart(release.Chart)
+	result.Actual = map[string]string{
+		"chart":   chartName,
+		"version": chartVersion,
+		"status":  release.Status,
+	}
+
+	if rule.Helm.ChartName != "" && chartName != rule.Helm.ChartName {
+		result.Message = fmt.Sprintf("Helm release '%s' uses chart '%s', expected '%s'", release.Name, chartName, rule.Helm.ChartName)
+		result.ErrorCode = "HELM_CHART_MISMATCH"
+		result.Expected = rule.Helm.ChartName
+		return
+	}
+
+	switch rule.Condition {
+	case "installed", "":
+		result.Expected = "Release should be installed"
+		result.Passed = true
+		result.Message = fmt.Sprintf("Helm release '%s' is installed (chart %s, status %s)", release.Name, release.Chart, release.Status)
+
+	case "version_equals":
+		expectedVersion := rule.Helm.Version
+		if expectedVersion == "" {
+			expectedVersion = fmt.Sprintf("%v", rule.Value)
+		}
+		result.Expected = expectedVersion
+
+		if chartVersion == expectedVersion {
+			result.Passed = true
+			result.Message = fmt.Sprintf("Helm release '%s' is at chart version %s", release.Name, chartVersion)
+		} else {
+			result.Message = fmt.Sprintf("Helm release '%s' is at chart version %s, expected %s", release.Name, chartVersion, expectedVersion)
+			result.ErrorCode = "HELM_VERSION_MISMATCH"
+		}
+
+	case "status_equals":
+		expectedStatus := fmt.Sprintf("%v", rule.Value)
+		result.Expected = expectedStatus
+
+		if strings.EqualFold(release.Status, expectedStatus) {
+			result.Passed = true
+			result.Message = fmt.Sprintf("Helm release '%s' has status %s", release.Name, release.Status)
+		} else {
+			result.Message = fmt.Sprintf("Helm release '%s' has status %s, expected %s", release.Name, release.Status, expectedStatus)
+			result.ErrorCode = "HELM_STATUS_MISMATCH"
+		}
+
+	default:
+		result.Message = fmt.Sprintf("Unknown condition: %s", rule.Condition)
+		result.ErrorCode = "UNKNOWN_CONDITION"
+	}
+}
+
+// splitHelmChart splits helm's "<name>-<version>" chart field, allowing dashes in
+// both the chart name and a pre-release version (e.g. "my-chart-1.0.0-rc1")
+func splitHelmChart(chart string) (name, version string) {
+	for idx := strings.LastIndex(chart, "-"); idx >= 0; idx = strings.LastIndex(chart[:idx], "-") {
+		rest := chart[idx+1:]
+		if rest != "" && rest[0] >= '0' && rest[0] <= '9' && strings.Contains(rest, ".") {
+			return chart[:idx], rest
+		}
+	}
+	return chart, ""
+}
+
+// kubeAPIServerManifest is the static pod manifest holding the API server flags
+const kubeAPIServerManifest = "/etc/kubernetes/manifests/kube-apiserver.yaml"
+
+// validateAdmissionController checks whether an admission plugin is enabled or disabled on the API server
+func (uv *UnifiedValidator) validateAdmissionController(ctx context.Context, session *models.Session, rule models.ValidationRule, result *ValidationResult) {
+	if rule.Admission == nil || rule.Admission.PluginName == "" {
+		result.Message = "Admission plugin specification is missing"
+		result.ErrorCode = "MISSING_ADMISSION_SPEC"
+		return
+	}
+
+	// The last line is the exit code of grep: 1 means neither flag is set, which leaves the
+	// API server defaults, while anything above means the manifest could not be read
+	cmd := fmt.Sprintf("sudo grep -E -- '--(enable|disable)-admission-plugins' %s 2>&1; echo $?", kubeAPIServerManifest)
+	output, err := uv.kubevirtClient.ExecuteCommandInVM(ctx, session.Namespace, session.ControlPlaneVM, cmd, false)
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to read %s: %v", kubeAPIServerManifest, err)
+		result.ErrorCode = "FILE_READ_FAILED"
+		return
+	}
+	output, exitCode, err := splitExitCode(output)
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to parse exit code: %v", err)
+		result.ErrorCode = "INVALID_EXIT_CODE"
+		return
+	}
+	if exitCode > 1 {
+		result.Message = fmt.Sprintf("Failed to read %s: grep exited with %d: %s", kubeAPIServerManifest, exitCode, output)
+		result.ErrorCode = "FILE_READ_FAILED"
+		return
+	}
+
+	enabledPlugins, disabledPlugins := parseAdmissionPlugins(output)
+	result.Actual = map[string][]string{
+		"enabled":  enabledPlugins,
+		"disabled": disabledPlugins,
+	}
+
+	// An explicit disable takes precedence over enable
+	pluginEnabled := containsString(enabledPlugins, rule.Admission.PluginName) &&
+		!containsString(disabledPlugins, rule.Admission.PluginName)
+
+	switch rule.Condition {
+	case "enabled":
+		result.Expected = fmt.Sprintf("Admission plugin '%s' should be enabled", rule.Admission.PluginName)
+		result.Passed = pluginEnabled
+	case "disabled":
+		result.Expected = fmt.Sprintf("Admission plugin '%s' should be disabled", rule.Admission.PluginName)
+		result.Passed = !pluginEnabled
+	default:
+		result.Message = fmt.Sprintf("Unknown condition: %s", rule.Condition)
+		result.ErrorCode = "UNKNOWN_CONDITION"
+		return
+	}
+
+	state := "disabled"
+	if pluginEnabled {
+		state = "enabled"
+	}
+
+	if result.Passed {
+		result.Message = fmt.Sprintf("Admission plugin '%s' is %s", rule.Admission.PluginName, state)
+	} else {
+		result.Message = fmt.Sprintf("Admission plugin '%s' is %s, expected %s", rule.Admission.PluginName, state, rule.Condition)
+		result.ErrorCode = "ADMISSION_PLUGIN_MISMATCH"
+	}
+}
+
+// parseAdmissionPlugins extracts the enabled and disabled plugin lists from kube-apiserver flag lines
+func parseAdmissionPlugins(output string) (enabled []string, disabled []string) {
+	enabled = []string{}
+	disabled = []string{}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.Trim(strings.TrimSpace(line), "-\"' ")
+
+		name, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		var plugins []string
+		for _, plugin := range strings.Split(value, ",") {
+			if plugin = strings.TrimSpace(plugin); plugin != "" {
+				plugins = append(plugins, plugin)
+			}
+		}
+
+		switch name {
+		case "enable-admission-plugins":
+			enabled = append(enabled, plugins...)
+		case "disable-admission-plugins":
+			disabled = append(disabled, plugins...)
+		}
+	}
+
+	return enabled, disabled
+}
+
+// containsString reports whether value is present in list
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// splitExitCode splits the output of a command run with "; echo $?" into the command's own
+// output and its exit code, which is the last line
+func splitExitCode(output string) (string, int, error) {
+	output = strings.TrimSpace(output)
+	commandOutput := ""
+	exitCodeStr := output
+	if idx := strings.LastIndex(output, "\n"); idx >= 0 {
+		commandOutput = strings.TrimSpace(output[:idx])
+		exitCodeStr = output[idx+1:]
+	}
+	exitCode := 0
+	if _, err := fmt.Sscanf(strings.TrimSpace(exitCodeStr), "%d", &exitCode); err != nil {
+		return "", 0, err
+	}
+	return commandOutput, exitCode, nil
+}
+
+// shellQuote quotes s as a single shell word, so that scenario values cannot inject commands
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
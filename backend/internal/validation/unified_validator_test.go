@@ -0,0 +1,345 @@
+//go:build mock_kubevirt
+
+// Run with: go test -tags mock_kubevirt ./internal/validation/
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/fullstack-pw/cks/backend/internal/kubevirt"
+	"github.com/fullstack-pw/cks/backend/internal/models"
+)
+
+// newMockValidator returns a validator whose VM commands are answered by a MockClient
+func newMockValidator(responses map[string]string) (*UnifiedValidator, *kubevirt.MockClient) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client := kubevirt.NewMockClient(responses)
+	return NewUnifiedValidator(client, logger), client
+}
+
+func mockSession() *models.Session {
+	return &models.Session{
+		ID:             "session-1",
+		Namespace:      "cluster1",
+		ControlPlaneVM: "cp-cluster1",
+		WorkerNodeVM:   "wk-cluster1",
+	}
+}
+
+func TestValidateRulesWithMockClient(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      models.ValidationRule
+		responses map[string]string
+		errors    map[string]error
+		passed    bool
+		errorCode string
+	}{
+		{
+			name: "resource exists",
+			rule: models.ValidationRule{
+				Type:     "resource_exists",
+				Resource: &models.ResourceTarget{Kind: "Pod", Name: "web", Namespace: "app"},
+			},
+			responses: map[string]string{"kubectl get 'pod' 'web' -n 'app'": "web   1/1   Running"},
+			passed:    true,
+		},
+		{
+			name: "resource missing",
+			rule: models.ValidationRule{
+				Type:     "resource_exists",
+				Resource: &models.ResourceTarget{Kind: "Pod", Name: "web"},
+			},
+			responses: map[string]string{"kubectl get 'pod' 'web' -n 'default'": `Error from server (NotFound): pods "web" not found`},
+			errorCode: "RESOURCE_NOT_FOUND",
+		},
+		{
+			name: "command output equals",
+			rule: models.ValidationRule{
+				Type:      "command",
+				Command:   &models.CommandTarget{Command: "cat /etc/hostname", Target: "control-plane"},
+				Condition: "output_equals",
+				Value:     "cp-cluster1",
+			},
+			responses: map[string]string{"cat /etc/hostname": "cp-cluster1\n"},
+			passed:    true,
+		},
+		{
+			name: "command output differs",
+			rule: models.ValidationRule{
+				Type:      "command",
+				Command:   &models.CommandTarget{Command: "cat /etc/hostname", Target: "control-plane"},
+				Condition: "output_equals",
+				Value:     "cp-cluster1",
+			},
+			responses: map[string]string{"cat /etc/hostname": "wk-cluster1\n"},
+			errorCode: "OUTPUT_MISMATCH",
+		},
+		{
+			name: "command fails",
+			rule: models.ValidationRule{
+				Type:      "command",
+				Command:   &models.CommandTarget{Command: "systemctl is-active kubelet", Target: "worker"},
+				Condition: "success",
+			},
+			errors:    map[string]error{"systemctl is-active kubelet": errors.New("exit status 3")},
+			errorCode: "COMMAND_FAILED",
+		},
+		{
+			name: "file exists",
+			rule: models.ValidationRule{
+				Type: "file_exists",
+				File: &models.FileTarget{Path: "/etc/kubernetes/audit-policy.yaml", Target: "control-plane"},
+			},
+			responses: map[string]string{"test -f '/etc/kubernetes/audit-policy.yaml'": ""},
+			passed:    true,
+		},
+		{
+			name: "file missing",
+			rule: models.ValidationRule{
+				Type: "file_exists",
+				File: &models.FileTarget{Path: "/etc/kubernetes/audit-policy.yaml", Target: "control-plane"},
+			},
+			errorCode: "FILE_NOT_FOUND",
+		},
+		{
+			name: "file contains",
+			rule: models.ValidationRule{
+				Type:      "file_content",
+				File:      &models.FileTarget{Path: "/etc/kubernetes/manifests/kube-apiserver.yaml", Target: "control-plane"},
+				Condition: "contains",
+				Value:     "--audit-log-path",
+			},
+			responses: map[string]string{"cat '/etc/kubernetes/manifests/kube-apiserver.yaml'": "    - --audit-log-path=/var/log/audit.log\n"},
+			passed:    true,
+		},
+		{
+			name: "configmap value equals",
+			rule: models.ValidationRule{
+				Type:      "configmap_data",
+				ConfigMap: &models.ConfigMapTarget{Name: "settings", Namespace: "app", Key: "log.level"},
+				Condition: "equals",
+				Value:     "debug",
+			},
+			responses: map[string]string{`kubectl get configmap 'settings' -n 'app' -o 'jsonpath={.data.log\.level}'`: "debug"},
+			passed:    true,
+		},
+		{
+			name: "rbac denied",
+			rule: models.ValidationRule{
+				Type:      "rbac_allowed",
+				RBAC:      &models.RBACTarget{Verb: "delete", Resource: "pods", ServiceAccount: "viewer", Namespace: "app"},
+				Condition: "denied",
+			},
+			responses: map[string]string{"kubectl auth can-i 'delete' 'pods' -n 'app'": "no\n1"},
+			passed:    true,
+		},
+		{
+			name: "rbac allowed",
+			rule: models.ValidationRule{
+				Type:      "rbac_allowed",
+				RBAC:      &models.RBACTarget{Verb: "get", Resource: "pods", ServiceAccount: "viewer", Namespace: "app"},
+				Condition: "allowed",
+			},
+			responses: map[string]string{"kubectl auth can-i 'get' 'pods' -n 'app'": "yes\n0"},
+			passed:    true,
+		},
+		{
+			name: "rbac check fails",
+			rule: models.ValidationRule{
+				Type:      "rbac_allowed",
+				RBAC:      &models.RBACTarget{Verb: "delete", Resource: "pods", ServiceAccount: "viewer", Namespace: "app"},
+				Condition: "denied",
+			},
+			responses: map[string]string{"kubectl auth can-i 'delete' 'pods' -n 'app'": "error: the server doesn't have a resource type \"pods\"\n1"},
+			errorCode: "COMMAND_FAILED",
+		},
+		{
+			name: "admission plugin enabled",
+			rule: models.ValidationRule{
+				Type:      "admission_controller",
+				Admission: &models.AdmissionTarget{PluginName: "NodeRestriction"},
+				Condition: "enabled",
+			},
+			responses: map[string]string{"admission-plugins": "    - --enable-admission-plugins=NodeRestriction,PodSecurity\n0"},
+			passed:    true,
+		},
+		{
+			name: "admission flags unset",
+			rule: models.ValidationRule{
+				Type:      "admission_controller",
+				Admission: &models.AdmissionTarget{PluginName: "NodeRestriction"},
+				Condition: "disabled",
+			},
+			responses: map[string]string{"admission-plugins": "1"},
+			passed:    true,
+		},
+		{
+			name: "admission manifest unreadable",
+			rule: models.ValidationRule{
+				Type:      "admission_controller",
+				Admission: &models.AdmissionTarget{PluginName: "NodeRestriction"},
+				Condition: "disabled",
+			},
+			responses: map[string]string{"admission-plugins": "grep: /etc/kubernetes/manifests/kube-apiserver.yaml: No such file or directory\n2"},
+			errorCode: "FILE_READ_FAILED",
+		},
+		{
+			name:      "unknown type",
+			rule:      models.ValidationRule{Type: "no_such_rule"},
+			errorCode: "UNKNOWN_VALIDATION_TYPE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, client := newMockValidator(tt.responses)
+			for key, err := range tt.errors {
+				client.Errors[key] = err
+			}
+			tt.rule.ID = "rule"
+
+			response, err := validator.ValidateTask(context.Background(), mockSession(), []models.ValidationRule{tt.rule})
+			if err != nil {
+				t.Fatalf("ValidateTask: %v", err)
+			}
+			if len(response.Results) != 1 {
+				t.Fatalf("got %d results, want 1", len(response.Results))
+			}
+			result := response.Results[0]
+			if result.Passed != tt.passed || response.Success != tt.passed {
+				t.Errorf("passed = %v, success = %v, want %v (%s)", result.Passed, response.Success, tt.passed, result.Message)
+			}
+			if result.ErrorCode != tt.errorCode {
+				t.Errorf("error code = %q, want %q", result.ErrorCode, tt.errorCode)
+			}
+		})
+	}
+}
+
+func TestValidateTaskFailsWhenAnyRuleFails(t *testing.T) {
+	validator, client := newMockValidator(map[string]string{
+		"kubectl get 'pod' 'web' -n 'default'": "web   1/1   Running",
+	})
+
+	rules := []models.ValidationRule{
+		{ID: "pod", Type: "resource_exists", Resource: &models.ResourceTarget{Kind: "Pod", Name: "web"}},
+		{ID: "svc", Type: "resource_exists", Resource: &models.ResourceTarget{Kind: "Service", Name: "web"}},
+	}
+	response, err := validator.ValidateTask(context.Background(), mockSession(), rules)
+	if err != nil {
+		t.Fatalf("ValidateTask: %v", err)
+	}
+	if response.Success {
+		t.Error("task succeeded with a missing service")
+	}
+	if len(response.Results) != 2 || !response.Results[0].Passed || response.Results[1].Passed {
+		t.Errorf("results = %+v, want the pod rule passed and the service rule failed", response.Results)
+	}
+
+	commands := client.Commands()
+	if len(commands) != 2 || !strings.Contains(commands[1], "kubectl get 'service' 'web'") {
+		t.Errorf("commands = %q", commands)
+	}
+}
+
+func TestValidateRuleRetriesUntilPassed(t *testing.T) {
+	validator, client := newMockValidator(nil)
+	client.Errors["kubectl get 'pod' 'web'"] = errors.New("not ready")
+
+	rule := models.ValidationRule{
+		ID:                   "pod",
+		Type:                 "resource_exists",
+		Resource:             &models.ResourceTarget{Kind: "Pod", Name: "web"},
+		RetryCount:           2,
+		RetryIntervalSeconds: 1,
+	}
+	response, err := validator.ValidateTask(context.Background(), mockSession(), []models.ValidationRule{rule})
+	if err != nil {
+		t.Fatalf("ValidateTask: %v", err)
+	}
+	if response.Success {
+		t.Fatal("rule passed without a response")
+	}
+	if got := len(client.Commands()); got != 3 {
+		t.Errorf("rule ran %d times, want 3", got)
+	}
+	if !strings.Contains(response.Results[0].Message, "failed on attempt 3 of 3") {
+		t.Errorf("message = %q", response.Results[0].Message)
+	}
+}
+
+func TestValidateResourceCount(t *testing.T) {
+	const countCommand = "kubectl get 'pod' -n 'app' -l 'app=web' --no-headers"
+
+	tests := []struct {
+		name      string
+		condition string
+		value     interface{}
+		output    string
+		passed    bool
+		errorCode string
+	}{
+		{name: "equals match", condition: "equals", value: 3, output: "3\n", passed: true},
+		{name: "equals mismatch", condition: "equals", value: 3, output: "2\n", errorCode: "RESOURCE_COUNT_MISMATCH"},
+		{name: "gte above", condition: "gte", value: "2", output: "3", passed: true},
+		{name: "gte equal", condition: "gte", value: 2, output: "2", passed: true},
+		{name: "gte below", condition: "gte", value: 2, output: "1", errorCode: "RESOURCE_COUNT_MISMATCH"},
+		{name: "lte below", condition: "lte", value: 2, output: "0", passed: true},
+		{name: "lte above", condition: "lte", value: 2, output: "5", errorCode: "RESOURCE_COUNT_MISMATCH"},
+		{name: "unparseable output", condition: "equals", value: 1, output: "error: the server doesn't have a resource type", errorCode: "INVALID_COUNT_OUTPUT"},
+		{name: "unparseable expected value", condition: "equals", value: "three", output: "3", errorCode: "INVALID_EXPECTED_VALUE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, _ := newMockValidator(map[string]string{countCommand: tt.output})
+			rule := models.ValidationRule{
+				ID:            "count",
+				Type:          "resource_count",
+				ResourceCount: &models.ResourceCountTarget{Kind: "Pod", Namespace: "app", LabelSelector: "app=web"},
+				Condition:     tt.condition,
+				Value:         tt.value,
+			}
+
+			result := validator.validateRule(context.Background(), mockSession(), rule)
+			if result.Passed != tt.passed {
+				t.Errorf("passed = %v, want %v (%s)", result.Passed, tt.passed, result.Message)
+			}
+			if result.ErrorCode != tt.errorCode {
+				t.Errorf("error code = %q, want %q", result.ErrorCode, tt.errorCode)
+			}
+		})
+	}
+}
+
+func TestValidateResourceCountQuotesArguments(t *testing.T) {
+	validator, client := newMockValidator(nil)
+	rule := models.ValidationRule{
+		ID:   "count",
+		Type: "resource_count",
+		ResourceCount: &models.ResourceCountTarget{
+			Kind:          "pods;reboot",
+			Namespace:     "app$(reboot)",
+			LabelSelector: "app=web'; reboot; echo '",
+		},
+		Condition: "equals",
+		Value:     0,
+	}
+	validator.validateRule(context.Background(), mockSession(), rule)
+
+	commands := client.Commands()
+	want := `kubectl get 'pods;reboot' -n 'app$(reboot)' -l 'app=web'\''; reboot; echo '\''' --no-headers 2>/dev/null | wc -l`
+	if len(commands) != 1 || commands[0] != want {
+		t.Errorf("commands = %q, want %q", commands, want)
+	}
+}
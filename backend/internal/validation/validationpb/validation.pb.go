@@ -0,0 +1,438 @@
+// backend/proto/validation.proto - Validation engine exposed as a gRPC service
+//
+// Regenerate internal/validation/validationpb from the backend directory after changing this file:
+//
+//   protoc --go_out=. --go_opt=module=github.com/fullstack-pw/cks/backend \
+//     --go-grpc_out=. --go-grpc_opt=module=github.com/fullstack-pw/cks/backend \
+//     proto/validation.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/validation.proto
+
+package validationpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ValidateTaskRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SessionId      string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	TaskId         string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Namespace      string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ControlPlaneVm string                 `protobuf:"bytes,4,opt,name=control_plane_vm,json=controlPlaneVm,proto3" json:"control_plane_vm,omitempty"`
+	WorkerNodeVm   string                 `protobuf:"bytes,5,opt,name=worker_node_vm,json=workerNodeVm,proto3" json:"worker_node_vm,omitempty"`
+	Rules          []*ValidationRule      `protobuf:"bytes,6,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ValidateTaskRequest) Reset() {
+	*x = ValidateTaskRequest{}
+	mi := &file_proto_validation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTaskRequest) ProtoMessage() {}
+
+func (x *ValidateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTaskRequest.ProtoReflect.Descriptor instead.
+func (*ValidateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_validation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ValidateTaskRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ValidateTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ValidateTaskRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ValidateTaskRequest) GetControlPlaneVm() string {
+	if x != nil {
+		return x.ControlPlaneVm
+	}
+	return ""
+}
+
+func (x *ValidateTaskRequest) GetWorkerNodeVm() string {
+	if x != nil {
+		return x.WorkerNodeVm
+	}
+	return ""
+}
+
+func (x *ValidateTaskRequest) GetRules() []*ValidationRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// ValidationRule carries a models.ValidationRule as JSON, so that new rule
+// types do not require a schema change
+type ValidationRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Json          []byte                 `protobuf:"bytes,3,opt,name=json,proto3" json:"json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationRule) Reset() {
+	*x = ValidationRule{}
+	mi := &file_proto_validation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationRule) ProtoMessage() {}
+
+func (x *ValidationRule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationRule.ProtoReflect.Descriptor instead.
+func (*ValidationRule) Descriptor() ([]byte, []int) {
+	return file_proto_validation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ValidationRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ValidationRule) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ValidationRule) GetJson() []byte {
+	if x != nil {
+		return x.Json
+	}
+	return nil
+}
+
+type ValidationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Results       []*ValidationResult    `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationResponse) Reset() {
+	*x = ValidationResponse{}
+	mi := &file_proto_validation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationResponse) ProtoMessage() {}
+
+func (x *ValidationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationResponse.ProtoReflect.Descriptor instead.
+func (*ValidationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_validation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ValidationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ValidationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ValidationResponse) GetResults() []*ValidationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ValidationResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type ValidationResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	RuleType      string                 `protobuf:"bytes,2,opt,name=rule_type,json=ruleType,proto3" json:"rule_type,omitempty"`
+	Passed        bool                   `protobuf:"varint,3,opt,name=passed,proto3" json:"passed,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	ExpectedJson  []byte                 `protobuf:"bytes,5,opt,name=expected_json,json=expectedJson,proto3" json:"expected_json,omitempty"`
+	ActualJson    []byte                 `protobuf:"bytes,6,opt,name=actual_json,json=actualJson,proto3" json:"actual_json,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,7,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Description   string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationResult) Reset() {
+	*x = ValidationResult{}
+	mi := &file_proto_validation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationResult) ProtoMessage() {}
+
+func (x *ValidationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationResult.ProtoReflect.Descriptor instead.
+func (*ValidationResult) Descriptor() ([]byte, []int) {
+	return file_proto_validation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ValidationResult) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetRuleType() string {
+	if x != nil {
+		return x.RuleType
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *ValidationResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetExpectedJson() []byte {
+	if x != nil {
+		return x.ExpectedJson
+	}
+	return nil
+}
+
+func (x *ValidationResult) GetActualJson() []byte {
+	if x != nil {
+		return x.ActualJson
+	}
+	return nil
+}
+
+func (x *ValidationResult) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+var File_proto_validation_proto protoreflect.FileDescriptor
+
+const file_proto_validation_proto_rawDesc = "" +
+	"\n" +
+	"\x16proto/validation.proto\x12\x11cks.validation.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf4\x01\n" +
+	"\x13ValidateTaskRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12(\n" +
+	"\x10control_plane_vm\x18\x04 \x01(\tR\x0econtrolPlaneVm\x12$\n" +
+	"\x0eworker_node_vm\x18\x05 \x01(\tR\fworkerNodeVm\x127\n" +
+	"\x05rules\x18\x06 \x03(\v2!.cks.validation.v1.ValidationRuleR\x05rules\"H\n" +
+	"\x0eValidationRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04json\x18\x03 \x01(\fR\x04json\"\xc1\x01\n" +
+	"\x12ValidationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12=\n" +
+	"\aresults\x18\x03 \x03(\v2#.cks.validation.v1.ValidationResultR\aresults\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x81\x02\n" +
+	"\x10ValidationResult\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12\x1b\n" +
+	"\trule_type\x18\x02 \x01(\tR\bruleType\x12\x16\n" +
+	"\x06passed\x18\x03 \x01(\bR\x06passed\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12#\n" +
+	"\rexpected_json\x18\x05 \x01(\fR\fexpectedJson\x12\x1f\n" +
+	"\vactual_json\x18\x06 \x01(\fR\n" +
+	"actualJson\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\a \x01(\tR\terrorCode\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription2r\n" +
+	"\x11ValidationService\x12]\n" +
+	"\fValidateTask\x12&.cks.validation.v1.ValidateTaskRequest\x1a%.cks.validation.v1.ValidationResponseBFZDgithub.com/fullstack-pw/cks/backend/internal/validation/validationpbb\x06proto3"
+
+var (
+	file_proto_validation_proto_rawDescOnce sync.Once
+	file_proto_validation_proto_rawDescData []byte
+)
+
+func file_proto_validation_proto_rawDescGZIP() []byte {
+	file_proto_validation_proto_rawDescOnce.Do(func() {
+		file_proto_validation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_validation_proto_rawDesc), len(file_proto_validation_proto_rawDesc)))
+	})
+	return file_proto_validation_proto_rawDescData
+}
+
+var file_proto_validation_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_validation_proto_goTypes = []any{
+	(*ValidateTaskRequest)(nil),   // 0: cks.validation.v1.ValidateTaskRequest
+	(*ValidationRule)(nil),        // 1: cks.validation.v1.ValidationRule
+	(*ValidationResponse)(nil),    // 2: cks.validation.v1.ValidationResponse
+	(*ValidationResult)(nil),      // 3: cks.validation.v1.ValidationResult
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_proto_validation_proto_depIdxs = []int32{
+	1, // 0: cks.validation.v1.ValidateTaskRequest.rules:type_name -> cks.validation.v1.ValidationRule
+	3, // 1: cks.validation.v1.ValidationResponse.results:type_name -> cks.validation.v1.ValidationResult
+	4, // 2: cks.validation.v1.ValidationResponse.timestamp:type_name -> google.protobuf.Timestamp
+	0, // 3: cks.validation.v1.ValidationService.ValidateTask:input_type -> cks.validation.v1.ValidateTaskRequest
+	2, // 4: cks.validation.v1.ValidationService.ValidateTask:output_type -> cks.validation.v1.ValidationResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_validation_proto_init() }
+func file_proto_validation_proto_init() {
+	if File_proto_validation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_validation_proto_rawDesc), len(file_proto_validation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_validation_proto_goTypes,
+		DependencyIndexes: file_proto_validation_proto_depIdxs,
+		MessageInfos:      file_proto_validation_proto_msgTypes,
+	}.Build()
+	File_proto_validation_proto = out.File
+	file_proto_validation_proto_goTypes = nil
+	file_proto_validation_proto_depIdxs = nil
+}
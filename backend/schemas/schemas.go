@@ -0,0 +1,16 @@
+// backend/schemas/schemas.go - JSON Schemas of the scenario files
+
+// Package schemas embeds the JSON Schemas that scenariolint checks scenario files against
+package schemas
+
+import _ "embed"
+
+// ScenarioMetadata is the schema of a scenario's metadata.yaml
+//
+//go:embed scenario-metadata.json
+var ScenarioMetadata string
+
+// ScenarioValidation is the schema of a task's validation/NN-validation.yaml
+//
+//go:embed scenario-validation.json
+var ScenarioValidation string
@@ -11,6 +11,7 @@ const TaskPanel = ({ sessionId, scenarioId }) => {
     const [scenario, setScenario] = useState(null);
     const [activeTaskIndex, setActiveTaskIndex] = useState(0);
     const [showHints, setShowHints] = useState({});
+    const [hints, setHints] = useState([]);
     const [loading, setLoading] = useState(true);
     const { error, handleError, clearError } = useError('task-panel');
     const [showAllTasks, setShowAllTasks] = useState(false);
@@ -63,6 +64,30 @@ const TaskPanel = ({ sessionId, scenarioId }) => {
         return tasks[activeTaskIndex];
     }, [scenario?.tasks, activeTaskIndex]);
 
+    // Hints are not part of the scenario; fetch the ones unlocked by the task's attempts
+    const currentAttempts = session?.tasks?.find(t => t.id === currentTask?.id)?.attemptCount || 0;
+    useEffect(() => {
+        if (!sessionId || !currentTask?.id) return;
+
+        let cancelled = false;
+        const fetchHints = async () => {
+            try {
+                const response = await fetch(`/api/v1/sessions/${sessionId}/tasks/${currentTask.id}/hints`);
+                if (!response.ok) {
+                    throw new Error(`Failed to fetch hints: ${response.status}`);
+                }
+                const data = await response.json();
+                if (!cancelled) setHints(data.hints || []);
+            } catch (err) {
+                console.error('Failed to fetch hints:', err);
+                if (!cancelled) setHints([]);
+            }
+        };
+
+        fetchHints();
+        return () => { cancelled = true; };
+    }, [sessionId, currentTask?.id, currentAttempts]);
+
     // Handle validation completion
     const handleValidationComplete = (result) => {
         console.log('Validation completed:', result);
@@ -186,7 +211,7 @@ const TaskPanel = ({ sessionId, scenarioId }) => {
                     </Card>
 
                     {/* Hints */}
-                    {currentTask?.hints && currentTask.hints.length > 0 && (
+                    {hints.length > 0 && (
                         <div className="mb-6">
                             <Button
                                 variant="ghost"
@@ -200,7 +225,7 @@ const TaskPanel = ({ sessionId, scenarioId }) => {
                                 <Card className="mt-2 bg-indigo-50">
                                     <h3 className="text-sm font-medium text-indigo-800 mb-2">Hints</h3>
                                     <ul className="list-disc pl-5 space-y-1">
-                                        {currentTask.hints.map((hint, index) => (
+                                        {hints.map((hint, index) => (
                                             <li key={index} className="text-xs sm:text-sm text-indigo-700">
                                                 {hint}
                                             </li>
@@ -216,7 +241,6 @@ const TaskPanel = ({ sessionId, scenarioId }) => {
                         <TaskValidation
                             taskId={currentTask.id}
                             sessionId={sessionId}
-                            validationRules={currentTask.validation || []}
                             onValidationComplete={handleValidationComplete}
                             className="mb-6"
                         />